@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/spf13/cobra"
+)
+
+var compareToolsnapsCmd = &cobra.Command{
+	Use:   "compare-toolsnaps <old-dir> <new-dir>",
+	Short: "Report breaking changes between two tool snapshot directories",
+	Long: `Compare two __toolsnaps__ directories, for example from two releases, and print the changes that could break existing clients:
+removed tools, prompts and resource templates, newly required parameters, and narrowed enums.
+Exits with a non-zero status if any breaking changes are found.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := toolsnaps.Compare(args[0], args[1])
+		if err != nil {
+			return fmt.Errorf("failed to compare snapshots: %w", err)
+		}
+
+		if err := report.Write(os.Stdout); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+
+		if report.HasBreakingChanges() {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("found %d breaking change(s)", len(report.Changes))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareToolsnapsCmd)
+}
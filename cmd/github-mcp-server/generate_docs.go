@@ -24,13 +24,22 @@ import (
 var generateDocsCmd = &cobra.Command{
 	Use:   "generate-docs",
 	Short: "Generate documentation for tools and toolsets",
-	Long:  `Generate the automated sections of README.md and docs/remote-server.md with current tool and toolset information.`,
-	RunE: func(_ *cobra.Command, _ []string) error {
+	Long: `Generate the automated sections of README.md and docs/remote-server.md with current tool and toolset information.
+With --export-catalog, write a machine-readable JSON catalog of every tool to the given file instead.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		catalogPath, err := cmd.Flags().GetString("export-catalog")
+		if err != nil {
+			return err
+		}
+		if catalogPath != "" {
+			return exportToolCatalog(catalogPath)
+		}
 		return generateAllDocs()
 	},
 }
 
 func init() {
+	generateDocsCmd.Flags().String("export-catalog", "", "Write a JSON catalog of all tools to this file instead of generating markdown docs")
 	rootCmd.AddCommand(generateDocsCmd)
 }
 
@@ -66,7 +75,7 @@ func generateReadmeDocs(readmePath string) error {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	repoAccessCache := lockdown.GetInstance(nil)
+	repoAccessCache := lockdown.NewRepoAccessCache(nil)
 	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, github.FeatureFlags{}, repoAccessCache)
 
 	// Generate toolsets documentation
@@ -306,7 +315,7 @@ func generateRemoteToolsetsDoc() string {
 	t, _ := translations.TranslationHelper()
 
 	// Create toolset group with mock clients
-	repoAccessCache := lockdown.GetInstance(nil)
+	repoAccessCache := lockdown.NewRepoAccessCache(nil)
 	tsg := github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, github.FeatureFlags{}, repoAccessCache)
 
 	// Generate table header
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var listUntranslatedCmd = &cobra.Command{
+	Use:   "list-untranslated",
+	Short: "List the translation keys that the bundles of a locale do not define",
+	Long: `Print the translation keys that none of the bundles in the fallback chain of --locale define, as a JSON object
+mapping each key to its default value. The output can be used as a starting point for completing the bundle.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		locale := viper.GetString("locale")
+		if locale == "" {
+			return fmt.Errorf("--locale is required")
+		}
+
+		translator, err := translations.NewTranslator(
+			translations.WithLocale(locale),
+			translations.WithTranslationsDir(viper.GetString("translations-dir")),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to load translations: %w", err)
+		}
+
+		knownKeys := github.TranslationKeys()
+		untranslated := map[string]string{}
+		for _, key := range translator.UntranslatedKeys(knownKeys) {
+			untranslated[key] = knownKeys[key]
+		}
+
+		out, err := json.MarshalIndent(untranslated, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal untranslated keys: %w", err)
+		}
+		fmt.Println(string(out))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(listUntranslatedCmd)
+}
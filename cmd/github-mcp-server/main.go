@@ -9,6 +9,8 @@ import (
 
 	"github.com/github/github-mcp-server/internal/ghmcp"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -57,21 +59,48 @@ var (
 				enabledToolsets = []string{github.ToolsetMetadataDefault.ID}
 			}
 
+			var lockdownTrustedBots []string
+			if err := viper.UnmarshalKey("lockdown-trusted-bots", &lockdownTrustedBots); err != nil {
+				return fmt.Errorf("failed to unmarshal lockdown trusted bots: %w", err)
+			}
+
+			var allowedLinkHosts []string
+			if err := viper.UnmarshalKey("allowed-link-hosts", &allowedLinkHosts); err != nil {
+				return fmt.Errorf("failed to unmarshal allowed link hosts: %w", err)
+			}
+
+			// Per-organization overrides are only available in the config file, e.g.
+			// lockdown-org-overrides: {my-org: {trusted-bots: [renovate], minimum-permission: triage}}
+			var lockdownOrgOverrides map[string]ghmcp.LockdownOrgOverride
+			if err := viper.UnmarshalKey("lockdown-org-overrides", &lockdownOrgOverrides); err != nil {
+				return fmt.Errorf("failed to unmarshal lockdown org overrides: %w", err)
+			}
+
 			ttl := viper.GetDuration("repo-access-cache-ttl")
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				EnabledTools:         enabledTools,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
-				LockdownMode:         viper.GetBool("lockdown-mode"),
-				RepoAccessCacheTTL:   &ttl,
+				Version:                   version,
+				Host:                      viper.GetString("host"),
+				Token:                     token,
+				EnabledToolsets:           enabledToolsets,
+				EnabledTools:              enabledTools,
+				DynamicToolsets:           viper.GetBool("dynamic_toolsets"),
+				ReadOnly:                  viper.GetBool("read-only"),
+				ExportTranslations:        viper.GetBool("export-translations"),
+				Locale:                    viper.GetString("locale"),
+				TranslationsDir:           viper.GetString("translations-dir"),
+				EnableCommandLogging:      viper.GetBool("enable-command-logging"),
+				LogFilePath:               viper.GetString("log-file"),
+				ContentWindowSize:         viper.GetInt("content-window-size"),
+				ResponseTokenBudget:       viper.GetInt("response-token-budget"),
+				LockdownMode:              viper.GetBool("lockdown-mode"),
+				LockdownRedact:            viper.GetBool("lockdown-redact"),
+				LockdownTrustedBots:       lockdownTrustedBots,
+				LockdownMinimumPermission: viper.GetString("lockdown-minimum-permission"),
+				LockdownOrgOverrides:      lockdownOrgOverrides,
+				RepoAccessCacheTTL:        &ttl,
+				InjectionDetection:        viper.GetBool("injection-detection"),
+				InjectionThreshold:        viper.GetFloat64("injection-threshold"),
+				AllowedLinkHosts:          allowedLinkHosts,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -85,6 +114,7 @@ func init() {
 	rootCmd.SetVersionTemplate("{{.Short}}\n{{.Version}}\n")
 
 	// Add global flags that will be shared by all commands
+	rootCmd.PersistentFlags().String("config", "", "Path to a config file (JSON, YAML or TOML) setting any of the options below by flag name")
 	rootCmd.PersistentFlags().StringSlice("toolsets", nil, github.GenerateToolsetsHelp())
 	rootCmd.PersistentFlags().StringSlice("tools", nil, "Comma-separated list of specific tools to enable")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
@@ -92,12 +122,22 @@ func init() {
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
+	rootCmd.PersistentFlags().String("locale", "", "Locale of the translation bundles to load (e.g. pt-BR, falls back to pt)")
+	rootCmd.PersistentFlags().String("translations-dir", ".", "Directory containing translation bundles named <locale>.json")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().Int("response-token-budget", github.DefaultResponseTokenBudget, "Maximum size of a tool result in tokens, larger results are continued with continue_result (0 to disable)")
 	rootCmd.PersistentFlags().Bool("lockdown-mode", false, "Enable lockdown mode")
+	rootCmd.PersistentFlags().Bool("lockdown-redact", false, "In lockdown mode, redact the title, body and comments of untrusted authors instead of withholding the content")
+	rootCmd.PersistentFlags().StringSlice("lockdown-trusted-bots", lockdown.DefaultTrustedBots, "Comma-separated list of bot logins whose content is always shown in lockdown mode")
+	rootCmd.PersistentFlags().String("lockdown-minimum-permission", "write", "Repository permission authors need for their content to be shown in lockdown mode (read, triage, write, maintain or admin)")
 	rootCmd.PersistentFlags().Duration("repo-access-cache-ttl", 5*time.Minute, "Override the repo access cache TTL (e.g. 1m, 0s to disable)")
+	rootCmd.PersistentFlags().StringSlice("allowed-link-hosts", sanitize.DefaultAllowedLinkHosts, "Comma-separated list of hosts that markdown links and images may point to, \"*.\" matches subdomains. The GitHub Enterprise host is always allowed")
+	rootCmd.PersistentFlags().Bool("injection-detection", false, "Flag possible prompt injections in tool results with injection_warnings")
+	rootCmd.PersistentFlags().Float64("injection-threshold", sanitize.DefaultInjectionThreshold, "Minimum score, from 0 to 1, of text flagged as a possible prompt injection")
 
 	// Bind flag to viper
+	_ = viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("tools", rootCmd.PersistentFlags().Lookup("tools"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
@@ -105,10 +145,19 @@ func init() {
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
+	_ = viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
+	_ = viper.BindPFlag("translations-dir", rootCmd.PersistentFlags().Lookup("translations-dir"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("response-token-budget", rootCmd.PersistentFlags().Lookup("response-token-budget"))
 	_ = viper.BindPFlag("lockdown-mode", rootCmd.PersistentFlags().Lookup("lockdown-mode"))
+	_ = viper.BindPFlag("lockdown-redact", rootCmd.PersistentFlags().Lookup("lockdown-redact"))
+	_ = viper.BindPFlag("lockdown-trusted-bots", rootCmd.PersistentFlags().Lookup("lockdown-trusted-bots"))
+	_ = viper.BindPFlag("lockdown-minimum-permission", rootCmd.PersistentFlags().Lookup("lockdown-minimum-permission"))
 	_ = viper.BindPFlag("repo-access-cache-ttl", rootCmd.PersistentFlags().Lookup("repo-access-cache-ttl"))
+	_ = viper.BindPFlag("allowed-link-hosts", rootCmd.PersistentFlags().Lookup("allowed-link-hosts"))
+	_ = viper.BindPFlag("injection-detection", rootCmd.PersistentFlags().Lookup("injection-detection"))
+	_ = viper.BindPFlag("injection-threshold", rootCmd.PersistentFlags().Lookup("injection-threshold"))
 
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
@@ -120,6 +169,10 @@ func initConfig() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
 
+	if configFile := viper.GetString("config"); configFile != "" {
+		viper.SetConfigFile(configFile)
+		cobra.CheckErr(viper.ReadInConfig())
+	}
 }
 
 func main() {
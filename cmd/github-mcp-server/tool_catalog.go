@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolCatalogVersion is the version of the catalog format. It must be incremented whenever
+// a field is removed or changes meaning, so that consumers can tell catalogs apart.
+const toolCatalogVersion = 1
+
+// toolCatalog is the machine-readable description of every tool the server can expose.
+type toolCatalog struct {
+	CatalogVersion int                  `json:"catalog_version"`
+	ServerVersion  string               `json:"server_version"`
+	Toolsets       []toolCatalogToolset `json:"toolsets"`
+	Tools          []toolCatalogTool    `json:"tools"`
+}
+
+type toolCatalogToolset struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+}
+
+type toolCatalogTool struct {
+	Toolset         string               `json:"toolset"`
+	Name            string               `json:"name"`
+	Description     string               `json:"description"`
+	Access          string               `json:"access"`
+	Annotations     *mcp.ToolAnnotations `json:"annotations"`
+	InputSchema     any                  `json:"input_schema"`
+	RequiredScopes  []string             `json:"required_scopes"`
+	TranslationKeys map[string]string    `json:"translation_keys,omitempty"`
+}
+
+// keyTranslationHelper returns the translation key instead of the translated value, so that the keys used
+// for a tool's strings can be recovered from a toolset group built with it.
+func keyTranslationHelper(key string, _ string) string {
+	return key
+}
+
+// buildToolCatalog builds the catalog from the default toolset group, using the default (untranslated) strings.
+func buildToolCatalog() (*toolCatalog, error) {
+	newToolsetGroup := func(t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
+		return github.DefaultToolsetGroup(false, mockGetClient, mockGetGQLClient, mockGetRawClient, t, 5000, github.FeatureFlags{}, nil)
+	}
+	return newToolCatalog(newToolsetGroup, translations.NullTranslationHelper, github.GetDefaultToolsetIDs())
+}
+
+// newToolCatalog builds the catalog from the toolset group returned by newToolsetGroup for t. The translation
+// keys are recovered from a second group built with keyTranslationHelper.
+func newToolCatalog(newToolsetGroup func(translations.TranslationHelperFunc) *toolsets.ToolsetGroup, t translations.TranslationHelperFunc, defaultToolsets []string) (*toolCatalog, error) {
+	tsg := newToolsetGroup(t)
+	keysTsg := newToolsetGroup(keyTranslationHelper)
+
+	catalog := &toolCatalog{
+		CatalogVersion: toolCatalogVersion,
+		ServerVersion:  version,
+		Toolsets:       []toolCatalogToolset{},
+		Tools:          []toolCatalogTool{},
+	}
+
+	toolsetNames := make([]string, 0, len(tsg.Toolsets))
+	for name := range tsg.Toolsets {
+		toolsetNames = append(toolsetNames, name)
+	}
+	sort.Strings(toolsetNames)
+
+	for _, toolsetName := range toolsetNames {
+		toolset := tsg.Toolsets[toolsetName]
+		catalog.Toolsets = append(catalog.Toolsets, toolCatalogToolset{
+			ID:          toolset.Name,
+			Description: toolset.Description,
+			Default:     contains(defaultToolsets, toolset.Name),
+		})
+
+		tools := toolset.GetAvailableTools()
+		sort.Slice(tools, func(i, j int) bool {
+			return tools[i].Tool.Name < tools[j].Tool.Name
+		})
+
+		for _, serverTool := range tools {
+			entry, err := buildToolCatalogEntry(toolsetName, serverTool, keysTsg)
+			if err != nil {
+				return nil, err
+			}
+			catalog.Tools = append(catalog.Tools, entry)
+		}
+	}
+
+	return catalog, nil
+}
+
+func buildToolCatalogEntry(toolsetName string, serverTool toolsets.ServerTool, keysTsg *toolsets.ToolsetGroup) (toolCatalogTool, error) {
+	tool := serverTool.Tool
+
+	scopes, ok := github.RequiredScopes(toolsetName, tool.Name)
+	if !ok {
+		return toolCatalogTool{}, fmt.Errorf("no required scopes known for toolset %s", toolsetName)
+	}
+
+	access := "write"
+	if tool.Annotations != nil && tool.Annotations.ReadOnlyHint {
+		access = "read"
+	}
+
+	keysTool, _, err := keysTsg.FindToolByName(tool.Name)
+	if err != nil {
+		return toolCatalogTool{}, fmt.Errorf("failed to find translation keys for tool %s: %w", tool.Name, err)
+	}
+
+	// Strings that were not passed through the translation helper come back unchanged.
+	translationKeys := map[string]string{}
+	if keysTool.Tool.Description != tool.Description {
+		translationKeys["description"] = keysTool.Tool.Description
+	}
+	if keysTool.Tool.Annotations != nil && tool.Annotations != nil && keysTool.Tool.Annotations.Title != tool.Annotations.Title {
+		translationKeys["title"] = keysTool.Tool.Annotations.Title
+	}
+
+	return toolCatalogTool{
+		Toolset:         toolsetName,
+		Name:            tool.Name,
+		Description:     tool.Description,
+		Access:          access,
+		Annotations:     tool.Annotations,
+		InputSchema:     tool.InputSchema,
+		RequiredScopes:  scopes,
+		TranslationKeys: translationKeys,
+	}, nil
+}
+
+func exportToolCatalog(catalogPath string) error {
+	catalog, err := buildToolCatalog()
+	if err != nil {
+		return fmt.Errorf("failed to build tool catalog: %w", err)
+	}
+
+	catalogJSON, err := json.MarshalIndent(catalog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool catalog: %w", err)
+	}
+
+	if err := os.WriteFile(catalogPath, append(catalogJSON, '\n'), 0600); err != nil { //#nosec G306
+		return fmt.Errorf("failed to write tool catalog: %w", err)
+	}
+
+	fmt.Printf("Successfully exported tool catalog to %s\n", catalogPath)
+	return nil
+}
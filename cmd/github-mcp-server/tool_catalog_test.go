@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWidgetToolsetGroup(t translations.TranslationHelperFunc) *toolsets.ToolsetGroup {
+	handler := func(_ context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	}
+
+	repos := toolsets.NewToolset("repos", "Widgets of repositories").
+		AddReadTools(toolsets.NewServerTool(mcp.Tool{
+			Name:        "get_widget",
+			Description: t("TOOL_GET_WIDGET_DESCRIPTION", "Get a widget"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_WIDGET_USER_TITLE", "Get widget"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"id": {Type: "string", Description: "Widget ID"},
+				},
+				Required: []string{"id"},
+			},
+		}, handler)).
+		AddWriteTools(toolsets.NewServerTool(mcp.Tool{
+			Name:        "update_widget",
+			Description: "Update a widget",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Update widget",
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{Type: "object"},
+		}, handler))
+	gists := toolsets.NewToolset("gists", "Widgets of gists")
+
+	tsg := toolsets.NewToolsetGroup(false)
+	tsg.AddToolset(repos)
+	tsg.AddToolset(gists)
+	return tsg
+}
+
+func TestToolCatalogSnapshot(t *testing.T) {
+	translate := func(key string, defaultValue string) string {
+		switch key {
+		case "TOOL_GET_WIDGET_DESCRIPTION":
+			return "Obtenir un widget"
+		case "TOOL_GET_WIDGET_USER_TITLE":
+			return "Obtenir le widget"
+		}
+		return defaultValue
+	}
+
+	catalog, err := newToolCatalog(newWidgetToolsetGroup, translate, []string{"repos"})
+	require.NoError(t, err)
+
+	// Translated strings are exported along with the keys they come from
+	require.Len(t, catalog.Tools, 2)
+	assert.Equal(t, "Obtenir un widget", catalog.Tools[0].Description)
+	assert.Equal(t, map[string]string{"description": "TOOL_GET_WIDGET_DESCRIPTION", "title": "TOOL_GET_WIDGET_USER_TITLE"}, catalog.Tools[0].TranslationKeys)
+
+	require.NoError(t, toolsnaps.TestArtifact(toolsnaps.KindToolCatalog, "widgets", catalog))
+}
+
+func TestExportToolCatalog(t *testing.T) {
+	catalogPath := filepath.Join(t.TempDir(), "tools.json")
+	require.NoError(t, exportToolCatalog(catalogPath))
+
+	data, err := os.ReadFile(catalogPath)
+	require.NoError(t, err)
+	var catalog toolCatalog
+	require.NoError(t, json.Unmarshal(data, &catalog))
+
+	assert.Equal(t, toolCatalogVersion, catalog.CatalogVersion)
+	assert.NotEmpty(t, catalog.Toolsets)
+	require.NotEmpty(t, catalog.Tools)
+	for _, tool := range catalog.Tools {
+		assert.NotNil(t, tool.RequiredScopes, tool.Name)
+		assert.NotEmpty(t, tool.TranslationKeys["description"], tool.Name)
+	}
+}
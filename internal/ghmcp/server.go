@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -18,6 +19,8 @@ import (
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/translations"
 	gogithub "github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -55,13 +58,90 @@ type MCPServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// ResponseTokenBudget is the maximum size of a tool result in tokens, larger results are cut into parts
+	// returned by the continue_result tool. Zero disables the budget.
+	ResponseTokenBudget int
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
+	// LockdownRedact redacts untrusted content in lockdown mode instead of withholding it.
+	LockdownRedact bool
+
+	// LockdownTrustedBots overrides the bot logins whose content is always shown in lockdown mode.
+	LockdownTrustedBots []string
+
+	// LockdownMinimumPermission is the repository permission authors need for their content to be shown in
+	// lockdown mode, e.g. "triage". Empty means push access.
+	LockdownMinimumPermission string
+
+	// LockdownOrgOverrides overrides the lockdown policy for the repositories of specific organizations.
+	LockdownOrgOverrides map[string]LockdownOrgOverride
+
 	// Logger is used for logging within the server
 	Logger *slog.Logger
 	// RepoAccessTTL overrides the default TTL for repository access cache entries.
 	RepoAccessTTL *time.Duration
+
+	// InjectionDetection flags possible prompt injections in tool results.
+	InjectionDetection bool
+
+	// InjectionThreshold is the minimum score of text flagged as a possible prompt injection.
+	InjectionThreshold float64
+
+	// AllowedLinkHosts are the hosts that markdown links and images in user content may point to, in addition
+	// to the GitHub Enterprise host. Nil means sanitize.DefaultAllowedLinkHosts.
+	AllowedLinkHosts []string
+}
+
+// LockdownOrgOverride overrides the lockdown policy for the repositories of an organization. Empty fields fall
+// back to the server-wide policy.
+type LockdownOrgOverride struct {
+	TrustedBots       []string `mapstructure:"trusted-bots"`
+	MinimumPermission string   `mapstructure:"minimum-permission"`
+}
+
+func lockdownOptions(cfg MCPServerConfig) ([]lockdown.RepoAccessOption, error) {
+	var opts []lockdown.RepoAccessOption
+	if cfg.LockdownTrustedBots != nil {
+		opts = append(opts, lockdown.WithTrustedBots(cfg.LockdownTrustedBots...))
+	}
+	if cfg.LockdownMinimumPermission != "" {
+		permission, err := lockdown.ParsePermission(cfg.LockdownMinimumPermission)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lockdown minimum permission: %w", err)
+		}
+		opts = append(opts, lockdown.WithMinimumPermission(permission))
+	}
+	for org, override := range cfg.LockdownOrgOverrides {
+		policy := lockdown.OrgPolicy{TrustedBots: override.TrustedBots}
+		if override.MinimumPermission != "" {
+			permission, err := lockdown.ParsePermission(override.MinimumPermission)
+			if err != nil {
+				return nil, fmt.Errorf("invalid lockdown minimum permission for %s: %w", org, err)
+			}
+			policy.MinimumPermission = permission
+		}
+		opts = append(opts, lockdown.WithOrgPolicy(org, policy))
+	}
+	return opts, nil
+}
+
+// allowedLinkHosts returns the hosts that markdown links and images may point to, adding the GitHub Enterprise
+// host and its subdomains to the configured ones.
+func allowedLinkHosts(cfg MCPServerConfig) []string {
+	hosts := cfg.AllowedLinkHosts
+	if hosts == nil {
+		hosts = sanitize.DefaultAllowedLinkHosts
+	}
+	if cfg.Host == "" {
+		return hosts
+	}
+	u, err := url.Parse(cfg.Host)
+	if err != nil || u.Hostname() == "" || strings.HasSuffix(u.Hostname(), "github.com") {
+		return hosts
+	}
+	return append(slices.Clone(hosts), u.Hostname(), "*."+u.Hostname())
 }
 
 func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
@@ -86,7 +166,10 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		},
 	} // We're going to wrap the Transport later in beforeInit
 	gqlClient := githubv4.NewEnterpriseClient(apiHost.graphqlURL.String(), gqlHTTPClient)
-	repoAccessOpts := []lockdown.RepoAccessOption{}
+	repoAccessOpts, err := lockdownOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
 	if cfg.RepoAccessTTL != nil {
 		repoAccessOpts = append(repoAccessOpts, lockdown.WithTTL(*cfg.RepoAccessTTL))
 	}
@@ -95,7 +178,7 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 	repoAccessOpts = append(repoAccessOpts, lockdown.WithLogger(repoAccessLogger))
 	var repoAccessCache *lockdown.RepoAccessCache
 	if cfg.LockdownMode {
-		repoAccessCache = lockdown.GetInstance(gqlClient, repoAccessOpts...)
+		repoAccessCache = lockdown.GetInstance(lockdown.TokenIdentity(cfg.Token), gqlClient, repoAccessOpts...)
 	}
 
 	enabledToolsets := cfg.EnabledToolsets
@@ -146,10 +229,6 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		CompletionHandler: github.CompletionsHandler(getClient),
 	})
 
-	// Add middlewares
-	ghServer.AddReceivingMiddleware(addGitHubAPIErrorToContext)
-	ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, restClient, gqlHTTPClient))
-
 	// Create default toolsets
 	tsg := github.DefaultToolsetGroup(
 		cfg.ReadOnly,
@@ -158,10 +237,30 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		getRawClient,
 		cfg.Translator,
 		cfg.ContentWindowSize,
-		github.FeatureFlags{LockdownMode: cfg.LockdownMode},
+		github.FeatureFlags{LockdownMode: cfg.LockdownMode, LockdownRedact: cfg.LockdownRedact},
 		repoAccessCache,
 	)
 
+	// Add middlewares
+	ghServer.AddReceivingMiddleware(addGitHubAPIErrorToContext)
+	ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, restClient, gqlHTTPClient))
+	// User-authored text is sanitized first, so that the other middlewares only see sanitized results
+	ghServer.AddReceivingMiddleware(github.SanitizationMiddleware(sanitize.NewSanitizer(allowedLinkHosts(cfg)), github.UserContentFields(tsg)))
+	ghServer.AddReceivingMiddleware(github.FieldProjectionMiddleware)
+
+	// Injections are flagged in projected results, before they are cut to fit the budget
+	if cfg.InjectionDetection {
+		detector := sanitize.NewInjectionDetector(sanitize.WithInjectionThreshold(cfg.InjectionThreshold))
+		ghServer.AddReceivingMiddleware(github.InjectionDetectionMiddleware(detector))
+	}
+
+	// The budget applies to projected results, so it must wrap the field projection
+	var resultBudget *github.ResultBudget
+	if cfg.ResponseTokenBudget > 0 {
+		resultBudget = github.NewResultBudget(cfg.ResponseTokenBudget)
+		ghServer.AddReceivingMiddleware(resultBudget.Middleware)
+	}
+
 	// Enable and register toolsets if configured
 	// This always happens if toolsets are specified, regardless of whether tools are also specified
 	if len(enabledToolsets) > 0 {
@@ -192,6 +291,11 @@ func NewMCPServer(cfg MCPServerConfig) (*mcp.Server, error) {
 		dynamic.RegisterTools(ghServer)
 	}
 
+	// Results cut to fit the budget are continued with continue_result, whatever the enabled tools
+	if resultBudget != nil {
+		toolsets.NewServerTool(github.ContinueResult(resultBudget, cfg.Translator)).RegisterFunc(ghServer)
+	}
+
 	return ghServer, nil
 }
 
@@ -224,6 +328,12 @@ type StdioServerConfig struct {
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
 
+	// Locale selects the translation bundles to load, e.g. pt-BR, which falls back to pt
+	Locale string
+
+	// TranslationsDir is the directory that translation bundles named <locale>.json are read from
+	TranslationsDir string
+
 	// EnableCommandLogging indicates if we should log commands
 	EnableCommandLogging bool
 
@@ -233,11 +343,35 @@ type StdioServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// ResponseTokenBudget is the maximum size of a tool result in tokens, zero disables the budget
+	ResponseTokenBudget int
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
+	// LockdownRedact redacts untrusted content in lockdown mode instead of withholding it
+	LockdownRedact bool
+
+	// LockdownTrustedBots overrides the bot logins whose content is always shown in lockdown mode
+	LockdownTrustedBots []string
+
+	// LockdownMinimumPermission is the repository permission authors need for their content to be shown in lockdown mode
+	LockdownMinimumPermission string
+
+	// LockdownOrgOverrides overrides the lockdown policy per organization
+	LockdownOrgOverrides map[string]LockdownOrgOverride
+
 	// RepoAccessCacheTTL overrides the default TTL for repository access cache entries.
 	RepoAccessCacheTTL *time.Duration
+
+	// InjectionDetection flags possible prompt injections in tool results
+	InjectionDetection bool
+
+	// InjectionThreshold is the minimum score of text flagged as a possible prompt injection
+	InjectionThreshold float64
+
+	// AllowedLinkHosts are the hosts that markdown links and images in user content may point to
+	AllowedLinkHosts []string
 }
 
 // RunStdioServer is not concurrent safe.
@@ -246,7 +380,13 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	t, dumpTranslations := translations.TranslationHelper()
+	translator, err := translations.NewTranslator(
+		translations.WithLocale(cfg.Locale),
+		translations.WithTranslationsDir(cfg.TranslationsDir),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load translations: %w", err)
+	}
 
 	var slogHandler slog.Handler
 	var logOutput io.Writer
@@ -265,26 +405,43 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
 
 	ghServer, err := NewMCPServer(MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		EnabledTools:      cfg.EnabledTools,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-		LockdownMode:      cfg.LockdownMode,
-		Logger:            logger,
-		RepoAccessTTL:     cfg.RepoAccessCacheTTL,
+		Version:                   cfg.Version,
+		Host:                      cfg.Host,
+		Token:                     cfg.Token,
+		EnabledToolsets:           cfg.EnabledToolsets,
+		EnabledTools:              cfg.EnabledTools,
+		DynamicToolsets:           cfg.DynamicToolsets,
+		ReadOnly:                  cfg.ReadOnly,
+		Translator:                translator.T,
+		ContentWindowSize:         cfg.ContentWindowSize,
+		ResponseTokenBudget:       cfg.ResponseTokenBudget,
+		LockdownMode:              cfg.LockdownMode,
+		LockdownRedact:            cfg.LockdownRedact,
+		LockdownTrustedBots:       cfg.LockdownTrustedBots,
+		LockdownMinimumPermission: cfg.LockdownMinimumPermission,
+		LockdownOrgOverrides:      cfg.LockdownOrgOverrides,
+		Logger:                    logger,
+		RepoAccessTTL:             cfg.RepoAccessCacheTTL,
+		InjectionDetection:        cfg.InjectionDetection,
+		InjectionThreshold:        cfg.InjectionThreshold,
+		AllowedLinkHosts:          cfg.AllowedLinkHosts,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
+	if cfg.LockdownMode {
+		defer lockdown.ReleaseInstance(lockdown.TokenIdentity(cfg.Token))
+	}
+
+	for _, key := range translator.UnknownKeys(github.TranslationKeys()) {
+		logger.Warn("translation key does not match any tool, prompt or resource", "key", key)
+	}
 
 	if cfg.ExportTranslations {
 		// Once server is initialized, all translations are loaded
-		dumpTranslations()
+		if err := translator.Dump(); err != nil {
+			return fmt.Errorf("failed to export translations: %w", err)
+		}
 	}
 
 	// Start listening for messages
@@ -0,0 +1,79 @@
+package ghmcp
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func listToolNames(t *testing.T, cfg MCPServerConfig) []string {
+	t.Helper()
+
+	cfg.Logger = slog.New(slog.DiscardHandler)
+	cfg.Translator = translations.NullTranslationHelper
+	server, err := NewMCPServer(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	result, err := session.ListTools(ctx, &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestNewMCPServer_ContinueResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    MCPServerConfig
+		expect bool
+	}{
+		{
+			name:   "registered with a budget and the context toolset disabled",
+			cfg:    MCPServerConfig{EnabledToolsets: []string{"repos"}, ResponseTokenBudget: 1000},
+			expect: true,
+		},
+		{
+			name:   "registered with a budget and specific tools only",
+			cfg:    MCPServerConfig{EnabledTools: []string{"get_file_contents"}, ResponseTokenBudget: 1000},
+			expect: true,
+		},
+		{
+			name:   "registered with a budget and the context toolset enabled",
+			cfg:    MCPServerConfig{EnabledToolsets: []string{"context"}, ResponseTokenBudget: 1000},
+			expect: true,
+		},
+		{
+			name:   "not registered without a budget",
+			cfg:    MCPServerConfig{EnabledToolsets: []string{"repos"}},
+			expect: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			names := listToolNames(t, tc.cfg)
+			if tc.expect {
+				require.Contains(t, names, "continue_result")
+			} else {
+				require.NotContains(t, names, "continue_result")
+			}
+		})
+	}
+}
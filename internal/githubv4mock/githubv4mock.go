@@ -146,11 +146,18 @@ func githubv4InputStructToMap(s any) (map[string]any, error) {
 //	  StateReason *IssueClosedStateReason `json:"stateReason,omitempty"`
 //	}
 //
+// Every matcher's query or mutation is validated against the bundled GitHub GraphQL schema when the client is
+// constructed, and this function panics if any of them selects unknown fields, passes unknown arguments or declares
+// variables of the wrong type. This catches mistakes in `graphql:"..."` struct tags that an exact string match cannot.
+//
 // This client does not currently provide a mechanism for out-of-band errors e.g. returning a 500,
 // and errors are constrained to GQL errors returned in the response body with a 200 status code.
 func NewMockedHTTPClient(ms ...Matcher) *http.Client {
 	matchers := make(map[string]Matcher, len(ms))
 	for _, m := range ms {
+		if err := validateAgainstSchema(m.Request); err != nil {
+			panic(fmt.Sprintf("githubv4mock: invalid matcher: %s", err))
+		}
 		matchers[m.Request] = m
 	}
 
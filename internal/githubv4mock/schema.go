@@ -0,0 +1,44 @@
+package githubv4mock
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// schemaSDL is the public GitHub GraphQL schema, as published at https://docs.github.com/public/fpt/schema.docs.graphql.
+// It can be refreshed by running script/update-graphql-schema.
+//
+//go:embed schema.graphql
+var schemaSDL string
+
+var loadSchema = sync.OnceValues(func() (*ast.Schema, error) {
+	return gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+})
+
+// validateAgainstSchema parses the provided query or mutation and validates it against the bundled GitHub GraphQL
+// schema. This ensures that every selected field exists on its parent type, that arguments are known, and that
+// variables are declared with types that are allowed in the positions they are used.
+//
+// Without this, a typo in a `graphql:"..."` struct tag would produce a query that matches its mock exactly, and the
+// mistake would only surface when the query is sent to the real API.
+func validateAgainstSchema(request string) error {
+	schema, err := loadSchema()
+	if err != nil {
+		return fmt.Errorf("failed to load GitHub GraphQL schema: %w", err)
+	}
+
+	if _, errs := gqlparser.LoadQuery(schema, request); len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, e := range errs {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("query is not valid against the GitHub GraphQL schema:\n\t%s\nquery: %s", strings.Join(messages, "\n\t"), request)
+	}
+
+	return nil
+}
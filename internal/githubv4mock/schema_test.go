@@ -0,0 +1,141 @@
+package githubv4mock
+
+import (
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAgainstSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       any
+		variables   map[string]any
+		expectedErr string
+	}{
+		{
+			name: "valid query",
+			query: struct {
+				Repository struct {
+					Issue struct {
+						Number githubv4.Int
+						Title  githubv4.String
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"number": githubv4.Int(42),
+			},
+		},
+		{
+			name: "unknown field",
+			query: struct {
+				Repository struct {
+					Issue struct {
+						Titel githubv4.String
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"number": githubv4.Int(42),
+			},
+			expectedErr: `Cannot query field "titel" on type "Issue"`,
+		},
+		{
+			name: "unknown argument",
+			query: struct {
+				Repository struct {
+					Issue struct {
+						Title githubv4.String
+					} `graphql:"issue(num: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"number": githubv4.Int(42),
+			},
+			expectedErr: `Unknown argument "num" on field "Repository.issue"`,
+		},
+		{
+			name: "wrong variable type",
+			query: struct {
+				Repository struct {
+					Issue struct {
+						Title githubv4.String
+					} `graphql:"issue(number: $number)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			variables: map[string]any{
+				"owner":  githubv4.String("owner"),
+				"repo":   githubv4.String("repo"),
+				"number": githubv4.String("42"),
+			},
+			expectedErr: `Variable "$number" of type "String!" used in position expecting type "Int!"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAgainstSchema(constructQuery(tc.query, tc.variables))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateAgainstSchemaMutation(t *testing.T) {
+	var mutation struct {
+		CloseIssue struct {
+			Issue struct {
+				ID githubv4.ID
+			}
+		} `graphql:"closeIssue(input: $input)"`
+	}
+
+	matcher := NewMutationMatcher(mutation, githubv4.CloseIssueInput{IssueID: githubv4.ID("I_1")}, nil, DataResponse(nil))
+	require.NoError(t, validateAgainstSchema(matcher.Request))
+
+	var unknownMutation struct {
+		ShutIssue struct {
+			Issue struct {
+				ID githubv4.ID
+			}
+		} `graphql:"shutIssue(input: $input)"`
+	}
+
+	matcher = NewMutationMatcher(unknownMutation, githubv4.CloseIssueInput{IssueID: githubv4.ID("I_1")}, nil, DataResponse(nil))
+	err := validateAgainstSchema(matcher.Request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Cannot query field "shutIssue" on type "Mutation"`)
+}
+
+func TestNewMockedHTTPClientPanicsOnInvalidMatcher(t *testing.T) {
+	matcher := NewQueryMatcher(
+		struct {
+			Viewer struct {
+				Logn githubv4.String
+			}
+		}{},
+		nil,
+		DataResponse(nil),
+	)
+
+	assert.PanicsWithValue(t,
+		"githubv4mock: invalid matcher: query is not valid against the GitHub GraphQL schema:\n\t"+
+			`Cannot query field "logn" on type "User". Did you mean "login"?`+
+			"\nquery: {viewer{logn}}",
+		func() { NewMockedHTTPClient(matcher) },
+	)
+}
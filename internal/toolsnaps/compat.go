@@ -0,0 +1,269 @@
+package toolsnaps
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ChangeType categorises a breaking change found between two snapshot directories.
+type ChangeType string
+
+const (
+	ChangeRemoved       ChangeType = "removed"
+	ChangeNewlyRequired ChangeType = "newly required"
+	ChangeNarrowedEnum  ChangeType = "narrowed enum"
+)
+
+// BreakingChange describes a single difference between two snapshot directories
+// that may break clients built against the older snapshots.
+type BreakingChange struct {
+	Kind   Kind
+	Type   ChangeType
+	Name   string
+	Detail string
+}
+
+func (c BreakingChange) String() string {
+	label := strings.TrimSuffix(c.Kind.snapshotName(), " snapshot")
+	if c.Detail == "" {
+		return fmt.Sprintf("%s %s", label, c.Name)
+	}
+	return fmt.Sprintf("%s %s: %s", label, c.Name, c.Detail)
+}
+
+// CompatReport lists the breaking changes found by Compare.
+type CompatReport struct {
+	Changes []BreakingChange
+}
+
+// HasBreakingChanges reports whether any breaking changes were found.
+func (r *CompatReport) HasBreakingChanges() bool {
+	return len(r.Changes) > 0
+}
+
+// Write renders the report in a human readable form, grouped by type of change.
+func (r *CompatReport) Write(w io.Writer) error {
+	if !r.HasBreakingChanges() {
+		_, err := fmt.Fprintln(w, "No breaking changes found.")
+		return err
+	}
+
+	sections := []struct {
+		changeType ChangeType
+		title      string
+	}{
+		{ChangeRemoved, "Removed"},
+		{ChangeNewlyRequired, "Newly required parameters"},
+		{ChangeNarrowedEnum, "Narrowed enums"},
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Found %d breaking change(s).\n", len(r.Changes))
+	for _, section := range sections {
+		var lines []string
+		for _, change := range r.Changes {
+			if change.Type == section.changeType {
+				lines = append(lines, change.String())
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n%s:\n", section.title)
+		for _, line := range lines {
+			fmt.Fprintf(&buf, "  - %s\n", line)
+		}
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// Compare reads the snapshots in two __toolsnaps__ directories and reports the changes in newDir that are
+// breaking for clients built against oldDir: removed tools, prompts and resource templates, parameters
+// or prompt arguments that became required, and enums that no longer accept a previously allowed value.
+func Compare(oldDir, newDir string) (*CompatReport, error) {
+	report := &CompatReport{}
+
+	for _, kind := range []Kind{KindTool, KindPrompt, KindResourceTemplate} {
+		oldSnaps, err := readSnaps(filepath.Join(oldDir, string(kind)))
+		if err != nil {
+			return nil, err
+		}
+		newSnaps, err := readSnaps(filepath.Join(newDir, string(kind)))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range sortedKeys(oldSnaps) {
+			newSnap, ok := newSnaps[name]
+			if !ok {
+				report.Changes = append(report.Changes, BreakingChange{Kind: kind, Type: ChangeRemoved, Name: name})
+				continue
+			}
+
+			changes, err := compareSnap(kind, name, oldSnaps[name], newSnap)
+			if err != nil {
+				return nil, err
+			}
+			report.Changes = append(report.Changes, changes...)
+		}
+	}
+
+	return report, nil
+}
+
+// readSnaps reads every snapshot file directly inside dir, keyed by name. A missing directory is treated as empty.
+func readSnaps(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory %s: %w", dir, err)
+	}
+
+	snaps := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".snap" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		contents, err := os.ReadFile(path) //nolint:gosec // snapshot directories are provided by the caller on purpose.
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+		}
+		snaps[strings.TrimSuffix(entry.Name(), ".snap")] = contents
+	}
+	return snaps, nil
+}
+
+// schemaSnap is the subset of a JSON schema that is relevant for compatibility checks.
+type schemaSnap struct {
+	Required   []string               `json:"required"`
+	Properties map[string]*schemaSnap `json:"properties"`
+	Items      *schemaSnap            `json:"items"`
+	Enum       []any                  `json:"enum"`
+}
+
+type toolSnap struct {
+	InputSchema *schemaSnap `json:"inputSchema"`
+}
+
+type promptSnap struct {
+	Arguments []struct {
+		Name     string `json:"name"`
+		Required bool   `json:"required"`
+	} `json:"arguments"`
+}
+
+type resourceTemplateSnap struct {
+	URITemplate string `json:"uriTemplate"`
+}
+
+func compareSnap(kind Kind, name string, oldJSON, newJSON []byte) ([]BreakingChange, error) {
+	switch kind {
+	case KindTool:
+		var oldTool, newTool toolSnap
+		if err := unmarshalSnaps(name, oldJSON, newJSON, &oldTool, &newTool); err != nil {
+			return nil, err
+		}
+		return compareSchema(kind, name, "", oldTool.InputSchema, newTool.InputSchema), nil
+	case KindPrompt:
+		var oldPrompt, newPrompt promptSnap
+		if err := unmarshalSnaps(name, oldJSON, newJSON, &oldPrompt, &newPrompt); err != nil {
+			return nil, err
+		}
+		wasRequired := make(map[string]bool, len(oldPrompt.Arguments))
+		for _, arg := range oldPrompt.Arguments {
+			wasRequired[arg.Name] = arg.Required
+		}
+		var changes []BreakingChange
+		for _, arg := range newPrompt.Arguments {
+			if arg.Required && !wasRequired[arg.Name] {
+				changes = append(changes, BreakingChange{Kind: kind, Type: ChangeNewlyRequired, Name: name, Detail: fmt.Sprintf("argument %q is now required", arg.Name)})
+			}
+		}
+		return changes, nil
+	case KindResourceTemplate:
+		var oldTemplate, newTemplate resourceTemplateSnap
+		if err := unmarshalSnaps(name, oldJSON, newJSON, &oldTemplate, &newTemplate); err != nil {
+			return nil, err
+		}
+		if oldTemplate.URITemplate != newTemplate.URITemplate {
+			return []BreakingChange{{Kind: kind, Type: ChangeRemoved, Name: name, Detail: fmt.Sprintf("URI template %q was replaced by %q", oldTemplate.URITemplate, newTemplate.URITemplate)}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func unmarshalSnaps(name string, oldJSON, newJSON []byte, oldSnap, newSnap any) error {
+	if err := json.Unmarshal(oldJSON, oldSnap); err != nil {
+		return fmt.Errorf("failed to parse old snapshot JSON for %s: %w", name, err)
+	}
+	if err := json.Unmarshal(newJSON, newSnap); err != nil {
+		return fmt.Errorf("failed to parse new snapshot JSON for %s: %w", name, err)
+	}
+	return nil
+}
+
+// compareSchema walks the old and new schemas side by side. Parameters that only exist in the new schema are
+// checked for being required, but are not descended into, as no existing client can be sending them.
+func compareSchema(kind Kind, name, path string, oldSchema, newSchema *schemaSnap) []BreakingChange {
+	if oldSchema == nil || newSchema == nil {
+		return nil
+	}
+
+	var changes []BreakingChange
+
+	if len(newSchema.Enum) > 0 {
+		var removed []string
+		if len(oldSchema.Enum) == 0 {
+			removed = append(removed, "any value")
+		}
+		for _, value := range oldSchema.Enum {
+			if !slices.ContainsFunc(newSchema.Enum, func(v any) bool { return fmt.Sprint(v) == fmt.Sprint(value) }) {
+				removed = append(removed, fmt.Sprintf("%q", fmt.Sprint(value)))
+			}
+		}
+		if len(removed) > 0 {
+			changes = append(changes, BreakingChange{Kind: kind, Type: ChangeNarrowedEnum, Name: name, Detail: fmt.Sprintf("parameter %q no longer accepts %s", path, strings.Join(removed, ", "))})
+		}
+	}
+
+	for _, required := range newSchema.Required {
+		if !slices.Contains(oldSchema.Required, required) {
+			changes = append(changes, BreakingChange{Kind: kind, Type: ChangeNewlyRequired, Name: name, Detail: fmt.Sprintf("parameter %q is now required", joinPath(path, required))})
+		}
+	}
+
+	for _, property := range sortedKeys(oldSchema.Properties) {
+		changes = append(changes, compareSchema(kind, name, joinPath(path, property), oldSchema.Properties[property], newSchema.Properties[property])...)
+	}
+
+	changes = append(changes, compareSchema(kind, name, path+"[]", oldSchema.Items, newSchema.Items)...)
+
+	return changes
+}
+
+func joinPath(path, property string) string {
+	if path == "" {
+		return property
+	}
+	return path + "." + property
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
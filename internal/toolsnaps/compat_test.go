@@ -0,0 +1,121 @@
+package toolsnaps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSnapFile(t *testing.T, dir string, kind Kind, name, contents string) {
+	t.Helper()
+	path := filepath.Join(dir, string(kind), name+".snap")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+}
+
+func TestCompare(t *testing.T) {
+	oldDir := t.TempDir()
+	newDir := t.TempDir()
+
+	// Given a tool that is removed
+	writeSnapFile(t, oldDir, KindTool, "removed_tool", `{"name":"removed_tool","inputSchema":{"type":"object"}}`)
+
+	// And a tool with a newly required parameter, a narrowed enum and a new enum on a nested parameter
+	writeSnapFile(t, oldDir, KindTool, "changed_tool", `{
+		"name": "changed_tool",
+		"inputSchema": {
+			"type": "object",
+			"required": ["owner"],
+			"properties": {
+				"owner": {"type": "string"},
+				"repo": {"type": "string"},
+				"state": {"type": "string", "enum": ["open", "closed", "all"]},
+				"files": {"type": "array", "items": {"type": "object", "properties": {"mode": {"type": "string"}}}}
+			}
+		}
+	}`)
+	writeSnapFile(t, newDir, KindTool, "changed_tool", `{
+		"name": "changed_tool",
+		"inputSchema": {
+			"type": "object",
+			"required": ["owner", "repo"],
+			"properties": {
+				"owner": {"type": "string"},
+				"repo": {"type": "string"},
+				"state": {"type": "string", "enum": ["open", "closed", "merged"]},
+				"files": {"type": "array", "items": {"type": "object", "properties": {"mode": {"type": "string", "enum": ["100644"]}}}}
+			}
+		}
+	}`)
+
+	// And a tool that only gains optional parameters and enum values
+	writeSnapFile(t, oldDir, KindTool, "compatible_tool", `{"name":"compatible_tool","inputSchema":{"type":"object","properties":{"state":{"type":"string","enum":["open"]}}}}`)
+	writeSnapFile(t, newDir, KindTool, "compatible_tool", `{"name":"compatible_tool","inputSchema":{"type":"object","properties":{"state":{"type":"string","enum":["open","closed"]},"page":{"type":"number"}}}}`)
+
+	// And a prompt with a newly required argument
+	writeSnapFile(t, oldDir, KindPrompt, "my_prompt", `{"name":"my_prompt","arguments":[{"name":"repo","required":true},{"name":"title"}]}`)
+	writeSnapFile(t, newDir, KindPrompt, "my_prompt", `{"name":"my_prompt","arguments":[{"name":"repo","required":true},{"name":"title","required":true}]}`)
+
+	// And a resource template that is removed
+	writeSnapFile(t, oldDir, KindResourceTemplate, "my_resource", `{"name":"my_resource","uriTemplate":"repo://{owner}/{repo}"}`)
+
+	// Instructions never count as breaking
+	writeSnapFile(t, oldDir, KindInstructions, "default", `{"instructions":"old"}`)
+
+	// When we compare the directories
+	report, err := Compare(oldDir, newDir)
+	require.NoError(t, err)
+
+	// Then every breaking change is reported
+	var changes []string
+	for _, change := range report.Changes {
+		changes = append(changes, string(change.Type)+": "+change.String())
+	}
+	assert.ElementsMatch(t, []string{
+		`removed: tool removed_tool`,
+		`newly required: tool changed_tool: parameter "repo" is now required`,
+		`narrowed enum: tool changed_tool: parameter "state" no longer accepts "all"`,
+		`narrowed enum: tool changed_tool: parameter "files[].mode" no longer accepts any value`,
+		`newly required: prompt my_prompt: argument "title" is now required`,
+		`removed: resource template my_resource`,
+	}, changes)
+	assert.True(t, report.HasBreakingChanges())
+
+	var out strings.Builder
+	require.NoError(t, report.Write(&out))
+	assert.Contains(t, out.String(), "Found 6 breaking change(s).")
+	assert.Contains(t, out.String(), "Newly required parameters:\n")
+}
+
+func TestCompareNoBreakingChanges(t *testing.T) {
+	oldDir := t.TempDir()
+	newDir := t.TempDir()
+
+	writeSnapFile(t, oldDir, KindTool, "tool", `{"name":"tool","inputSchema":{"type":"object"}}`)
+	writeSnapFile(t, newDir, KindTool, "tool", `{"name":"tool","inputSchema":{"type":"object"}}`)
+	writeSnapFile(t, newDir, KindTool, "new_tool", `{"name":"new_tool","inputSchema":{"type":"object","required":["owner"]}}`)
+
+	report, err := Compare(oldDir, newDir)
+	require.NoError(t, err)
+	assert.False(t, report.HasBreakingChanges())
+
+	var out strings.Builder
+	require.NoError(t, report.Write(&out))
+	assert.Equal(t, "No breaking changes found.\n", out.String())
+}
+
+func TestCompareMalformedSnapshot(t *testing.T) {
+	oldDir := t.TempDir()
+	newDir := t.TempDir()
+
+	writeSnapFile(t, oldDir, KindTool, "tool", `{"name":"tool"}`)
+	writeSnapFile(t, newDir, KindTool, "tool", `not-json`)
+
+	_, err := Compare(oldDir, newDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse new snapshot JSON for tool")
+}
@@ -11,6 +11,51 @@ import (
 	"github.com/josephburnett/jd/v2"
 )
 
+// Kind identifies the type of server artifact that a snapshot was taken of.
+// Tool snapshots live directly in the __toolsnaps__ directory, while every other
+// kind is stored in a subdirectory named after the kind so that names cannot collide.
+type Kind string
+
+const (
+	KindTool             Kind = ""
+	KindInstructions     Kind = "instructions"
+	KindPrompt           Kind = "prompts"
+	KindResourceTemplate Kind = "resources"
+	KindToolCatalog      Kind = "catalog"
+)
+
+// subject returns how an artifact of this kind is referred to in error messages.
+func (k Kind) subject() string {
+	switch k {
+	case KindInstructions:
+		return "instructions"
+	case KindPrompt:
+		return "prompt definition"
+	case KindResourceTemplate:
+		return "resource template"
+	case KindToolCatalog:
+		return "tool catalog"
+	default:
+		return "tool schema"
+	}
+}
+
+// snapshotName returns how an artifact of this kind is referred to when its snapshot is missing.
+func (k Kind) snapshotName() string {
+	switch k {
+	case KindInstructions:
+		return "instructions snapshot"
+	case KindPrompt:
+		return "prompt snapshot"
+	case KindResourceTemplate:
+		return "resource template snapshot"
+	case KindToolCatalog:
+		return "tool catalog snapshot"
+	default:
+		return "tool snapshot"
+	}
+}
+
 // Test checks that the JSON schema for a tool has not changed unexpectedly.
 // It compares the marshaled JSON of the provided tool against a stored snapshot file.
 // If the UPDATE_TOOLSNAPS environment variable is set to "true", it updates the snapshot file instead.
@@ -19,48 +64,55 @@ import (
 // If the snapshot exists, it compares the tool's JSON to the snapshot and returns an error if they differ.
 // Returns an error if marshaling, reading, or comparing fails.
 func Test(toolName string, tool any) error {
-	toolJSON, err := json.MarshalIndent(tool, "", "  ")
+	return TestArtifact(KindTool, toolName, tool)
+}
+
+// TestArtifact checks that a server artifact of the given kind, such as a prompt definition, a resource template
+// or the generated server instructions, has not changed unexpectedly. It behaves exactly like Test, but stores
+// the snapshot in the subdirectory for the kind, e.g. __toolsnaps__/prompts/<name>.snap.
+func TestArtifact(kind Kind, name string, artifact any) error {
+	artifactJSON, err := json.MarshalIndent(artifact, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal tool %s: %w", toolName, err)
+		return fmt.Errorf("failed to marshal %s %s: %w", kind.subject(), name, err)
 	}
 
-	snapPath := fmt.Sprintf("__toolsnaps__/%s.snap", toolName)
+	snapPath := filepath.Join("__toolsnaps__", string(kind), name+".snap")
 
-	// If UPDATE_TOOLSNAPS is set, then we write the tool JSON to the snapshot file and exit
+	// If UPDATE_TOOLSNAPS is set, then we write the artifact JSON to the snapshot file and exit
 	if os.Getenv("UPDATE_TOOLSNAPS") == "true" {
-		return writeSnap(snapPath, toolJSON)
+		return writeSnap(snapPath, artifactJSON)
 	}
 
 	snapJSON, err := os.ReadFile(snapPath) //nolint:gosec // filepaths are controlled by the test suite, so this is safe.
 	// If the snapshot file does not exist, this must be the first time this test is run.
-	// We write the tool JSON to the snapshot file and exit.
+	// We write the artifact JSON to the snapshot file and exit.
 	if os.IsNotExist(err) {
 		// If we're running in CI, we will error if there is not snapshot because it's important that snapshots
 		// are committed alongside the tests, rather than just being constructed and not committed during a CI run.
 		if os.Getenv("GITHUB_ACTIONS") == "true" {
-			return fmt.Errorf("tool snapshot does not exist for %s. Please run the tests with UPDATE_TOOLSNAPS=true to create it", toolName)
+			return fmt.Errorf("%s does not exist for %s. Please run the tests with UPDATE_TOOLSNAPS=true to create it", kind.snapshotName(), name)
 		}
 
-		return writeSnap(snapPath, toolJSON)
+		return writeSnap(snapPath, artifactJSON)
 	}
 
-	// Otherwise we will compare the tool JSON to the snapshot JSON
-	toolNode, err := jd.ReadJsonString(string(toolJSON))
+	// Otherwise we will compare the artifact JSON to the snapshot JSON
+	artifactNode, err := jd.ReadJsonString(string(artifactJSON))
 	if err != nil {
-		return fmt.Errorf("failed to parse tool JSON for %s: %w", toolName, err)
+		return fmt.Errorf("failed to parse %s JSON for %s: %w", kind.subject(), name, err)
 	}
 
 	snapNode, err := jd.ReadJsonString(string(snapJSON))
 	if err != nil {
-		return fmt.Errorf("failed to parse snapshot JSON for %s: %w", toolName, err)
+		return fmt.Errorf("failed to parse snapshot JSON for %s: %w", name, err)
 	}
 
 	// jd.Set allows arrays to be compared without order sensitivity,
 	// which is useful because we don't really care about this when exposing tool schemas.
-	diff := toolNode.Diff(snapNode, jd.SET).Render()
+	diff := artifactNode.Diff(snapNode, jd.SET).Render()
 	if diff != "" {
 		// If there is a difference, we return an error with the diff
-		return fmt.Errorf("tool schema for %s has changed unexpectedly:\n%s\nrun with `UPDATE_TOOLSNAPS=true` if this is expected", toolName, diff)
+		return fmt.Errorf("%s for %s has changed unexpectedly:\n%s\nrun with `UPDATE_TOOLSNAPS=true` if this is expected", kind.subject(), name, diff)
 	}
 
 	return nil
@@ -131,3 +131,28 @@ func TestMalformedSnapshotJSON(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse snapshot JSON for dummy", "expected error about malformed snapshot JSON")
 }
+
+func TestArtifactSnapshotsAreStoredPerKind(t *testing.T) {
+	withIsolatedWorkingDir(t)
+	t.Setenv("UPDATE_TOOLSNAPS", "false")
+	t.Setenv("GITHUB_ACTIONS", "false")
+
+	// Given a tool and a prompt with the same name
+	tool := dummyTool{"foo", 42}
+	prompt := dummyTool{"foo", 1}
+
+	// When we test both snapshots
+	require.NoError(t, Test("dummy", tool))
+	require.NoError(t, TestArtifact(KindPrompt, "dummy", prompt))
+
+	// Then they are written to separate files
+	_, statErr := os.Stat(filepath.Join("__toolsnaps__", "dummy.snap"))
+	assert.NoError(t, statErr, "expected tool snapshot file to be written")
+	_, statErr = os.Stat(filepath.Join("__toolsnaps__", "prompts", "dummy.snap"))
+	assert.NoError(t, statErr, "expected prompt snapshot file to be written")
+
+	// And a change to the prompt is reported as such
+	err := TestArtifact(KindPrompt, "dummy", dummyTool{"foo", 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prompt definition for dummy has changed unexpectedly")
+}
@@ -24,7 +24,7 @@ const (
 )
 
 // ListWorkflows creates a tool to list workflows in a repository
-func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.Workflows]) {
 	return mcp.Tool{
 			Name:        "list_workflows",
 			Description: t("TOOL_LIST_WORKFLOWS_DESCRIPTION", "List workflows in a repository"),
@@ -32,7 +32,7 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_LIST_WORKFLOWS_USER_TITLE", "List workflows"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithResultFormat(WithWrappedListFieldProjection(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -45,9 +45,10 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 					},
 				},
 				Required: []string{"owner", "repo"},
-			}),
+			}), "workflows", "id", "name")),
+			OutputSchema: ProjectedOutputSchema[*github.Workflows](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.Workflows, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -63,6 +64,11 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -85,12 +91,17 @@ func ListWorkflows(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "workflows", WorkflowColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, workflows, nil
 		}
 }
 
 // ListWorkflowRuns creates a tool to list workflow runs for a specific workflow
-func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.WorkflowRuns]) {
 	return mcp.Tool{
 			Name:        "list_workflow_runs",
 			Description: t("TOOL_LIST_WORKFLOW_RUNS_DESCRIPTION", "List workflow runs for a specific workflow"),
@@ -98,7 +109,7 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_WORKFLOW_RUNS_USER_TITLE", "List workflow runs"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithResultFormat(WithWrappedListFieldProjection(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -166,9 +177,10 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 					},
 				},
 				Required: []string{"owner", "repo", "workflow_id"},
-			}),
+			}), "workflow_runs", "id", "status", "conclusion")),
+			OutputSchema: ProjectedOutputSchema[*github.WorkflowRuns](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.WorkflowRuns, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -206,6 +218,11 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -234,7 +251,12 @@ func ListWorkflowRuns(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "workflow_runs", WorkflowRunColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, workflowRuns, nil
 		}
 }
 
@@ -346,7 +368,7 @@ func RunWorkflow(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 }
 
 // GetWorkflowRun creates a tool to get details of a specific workflow run
-func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.WorkflowRun]) {
 	return mcp.Tool{
 			Name:        "get_workflow_run",
 			Description: t("TOOL_GET_WORKFLOW_RUN_DESCRIPTION", "Get details of a specific workflow run"),
@@ -354,7 +376,7 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USER_TITLE", "Get workflow run"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -371,9 +393,10 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*github.WorkflowRun](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.WorkflowRun, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -404,12 +427,21 @@ func GetWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), workflowRun, nil
 		}
 }
 
 // GetWorkflowRunLogs creates a tool to download logs for a specific workflow run
-func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+// WorkflowRunLogs is the output of get_workflow_run_logs.
+type WorkflowRunLogs struct {
+	LogsURL         string `json:"logs_url"`
+	Message         string `json:"message"`
+	Note            string `json:"note"`
+	Warning         string `json:"warning"`
+	OptimizationTip string `json:"optimization_tip"`
+}
+
+func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *WorkflowRunLogs]) {
 	return mcp.Tool{
 			Name:        "get_workflow_run_logs",
 			Description: t("TOOL_GET_WORKFLOW_RUN_LOGS_DESCRIPTION", "Download logs for a specific workflow run (EXPENSIVE: downloads ALL logs as ZIP. Consider using get_job_logs with failed_only=true for debugging failed jobs)"),
@@ -435,8 +467,9 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				},
 				Required: []string{"owner", "repo", "run_id"},
 			},
+			OutputSchema: OutputSchema[*WorkflowRunLogs](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *WorkflowRunLogs, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -464,12 +497,12 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 			defer func() { _ = resp.Body.Close() }()
 
 			// Create response with the logs URL and information
-			result := map[string]any{
-				"logs_url":         url.String(),
-				"message":          "Workflow run logs are available for download",
-				"note":             "The logs_url provides a download link for the complete workflow run logs as a ZIP archive. You can download this archive to extract and examine individual job logs.",
-				"warning":          "This downloads ALL logs as a ZIP file which can be large and expensive. For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id instead.",
-				"optimization_tip": "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
+			result := &WorkflowRunLogs{
+				LogsURL:         url.String(),
+				Message:         "Workflow run logs are available for download",
+				Note:            "The logs_url provides a download link for the complete workflow run logs as a ZIP archive. You can download this archive to extract and examine individual job logs.",
+				Warning:         "This downloads ALL logs as a ZIP file which can be large and expensive. For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id instead.",
+				OptimizationTip: "Use: get_job_logs with parameters {run_id: " + fmt.Sprintf("%d", runID) + ", failed_only: true} for more efficient failed job debugging",
 			}
 
 			r, err := json.Marshal(result)
@@ -477,12 +510,18 @@ func GetWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelperF
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), result, nil
 		}
 }
 
+// WorkflowJobsPage is the output of list_workflow_jobs.
+type WorkflowJobsPage struct {
+	Jobs            *github.Jobs `json:"jobs"`
+	OptimizationTip string       `json:"optimization_tip"`
+}
+
 // ListWorkflowJobs creates a tool to list jobs for a specific workflow run
-func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *WorkflowJobsPage]) {
 	return mcp.Tool{
 			Name:        "list_workflow_jobs",
 			Description: t("TOOL_LIST_WORKFLOW_JOBS_DESCRIPTION", "List jobs for a specific workflow run"),
@@ -490,7 +529,7 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_WORKFLOW_JOBS_USER_TITLE", "List workflow jobs"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithResultFormat(WithWrappedListFieldProjection(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -512,9 +551,10 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			}),
+			}), "jobs.jobs", "id", "name", "conclusion")),
+			OutputSchema: ProjectedOutputSchema[*WorkflowJobsPage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *WorkflowJobsPage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -541,6 +581,11 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -562,9 +607,9 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 			defer func() { _ = resp.Body.Close() }()
 
 			// Add optimization tip for failed job debugging
-			response := map[string]any{
-				"jobs":             jobs,
-				"optimization_tip": "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first",
+			response := &WorkflowJobsPage{
+				Jobs:            jobs,
+				OptimizationTip: "For debugging failed jobs, consider using get_job_logs with failed_only=true and run_id=" + fmt.Sprintf("%d", runID) + " to get logs directly without needing to list jobs first",
 			}
 
 			r, err := json.Marshal(response)
@@ -572,10 +617,37 @@ func ListWorkflowJobs(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "jobs.jobs", WorkflowJobColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, response, nil
 		}
 }
 
+// JobLogs is the output of get_job_logs for a single job, holding either the URL or the content of its logs.
+type JobLogs struct {
+	JobID          int64  `json:"job_id"`
+	JobName        string `json:"job_name,omitempty"`
+	LogsURL        string `json:"logs_url,omitempty"`
+	LogsContent    string `json:"logs_content,omitempty"`
+	OriginalLength int    `json:"original_length,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Note           string `json:"note,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// FailedJobLogs is the output of get_job_logs for the failed jobs of a workflow run.
+type FailedJobLogs struct {
+	Message      string          `json:"message"`
+	RunID        int64           `json:"run_id"`
+	TotalJobs    int             `json:"total_jobs"`
+	FailedJobs   int             `json:"failed_jobs"`
+	Logs         []*JobLogs      `json:"logs,omitempty"`
+	ReturnFormat map[string]bool `json:"return_format,omitempty"`
+}
+
 // GetJobLogs creates a tool to download logs for a specific workflow job or efficiently get all failed job logs for a workflow run
 func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, contentWindowSize int) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	return mcp.Tool{
@@ -620,6 +692,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 				},
 				Required: []string{"owner", "repo"},
 			},
+			OutputSchema: AlternativeOutputSchema(OutputSchema[*JobLogs](), OutputSchema[*FailedJobLogs]()),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			owner, err := RequiredParam[string](args, "owner")
@@ -683,7 +756,7 @@ func GetJobLogs(getClient GetClientFn, t translations.TranslationHelperFunc, con
 }
 
 // handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
+func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, *FailedJobLogs, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -702,26 +775,26 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 	}
 
 	if len(failedJobs) == 0 {
-		result := map[string]any{
-			"message":     "No failed jobs found in this workflow run",
-			"run_id":      runID,
-			"total_jobs":  len(jobs.Jobs),
-			"failed_jobs": 0,
+		result := &FailedJobLogs{
+			Message:    "No failed jobs found in this workflow run",
+			RunID:      runID,
+			TotalJobs:  len(jobs.Jobs),
+			FailedJobs: 0,
 		}
 		r, _ := json.Marshal(result)
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), result, nil
 	}
 
 	// Collect logs for all failed jobs
-	var logResults []map[string]any
+	var logResults []*JobLogs
 	for _, job := range failedJobs {
 		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
-			jobResult = map[string]any{
-				"job_id":   job.GetID(),
-				"job_name": job.GetName(),
-				"error":    err.Error(),
+			jobResult = &JobLogs{
+				JobID:   job.GetID(),
+				JobName: job.GetName(),
+				Error:   err.Error(),
 			}
 			// Enable reporting of status codes and error causes
 			_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get job logs", resp, err) // Explicitly ignore error for graceful handling
@@ -730,13 +803,13 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		logResults = append(logResults, jobResult)
 	}
 
-	result := map[string]any{
-		"message":       fmt.Sprintf("Retrieved logs for %d failed jobs", len(failedJobs)),
-		"run_id":        runID,
-		"total_jobs":    len(jobs.Jobs),
-		"failed_jobs":   len(failedJobs),
-		"logs":          logResults,
-		"return_format": map[string]bool{"content": returnContent, "urls": !returnContent},
+	result := &FailedJobLogs{
+		Message:      fmt.Sprintf("Retrieved logs for %d failed jobs", len(failedJobs)),
+		RunID:        runID,
+		TotalJobs:    len(jobs.Jobs),
+		FailedJobs:   len(failedJobs),
+		Logs:         logResults,
+		ReturnFormat: map[string]bool{"content": returnContent, "urls": !returnContent},
 	}
 
 	r, err := json.Marshal(result)
@@ -744,11 +817,11 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil, nil
+	return utils.NewToolResultText(string(r)), result, nil
 }
 
 // handleSingleJobLogs gets logs for a single job
-func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
+func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, *JobLogs, error) {
 	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines, contentWindowSize)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil, nil
@@ -759,11 +832,11 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil, nil
+	return utils.NewToolResultText(string(r)), jobResult, nil
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
-func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int, contentWindowSize int) (map[string]any, *github.Response, error) {
+func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int, contentWindowSize int) (*JobLogs, *github.Response, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
 	if err != nil {
@@ -771,11 +844,9 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	result := map[string]any{
-		"job_id": jobID,
-	}
-	if jobName != "" {
-		result["job_name"] = jobName
+	result := &JobLogs{
+		JobID:   jobID,
+		JobName: jobName,
 	}
 
 	if returnContent {
@@ -788,14 +859,14 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 			}
 			return nil, ghRes, fmt.Errorf("failed to download log content for job %d: %w", jobID, err)
 		}
-		result["logs_content"] = content
-		result["message"] = "Job logs content retrieved successfully"
-		result["original_length"] = originalLength
+		result.LogsContent = content
+		result.Message = "Job logs content retrieved successfully"
+		result.OriginalLength = originalLength
 	} else {
 		// Return just the URL
-		result["logs_url"] = url.String()
-		result["message"] = "Job logs are available for download"
-		result["note"] = "The logs_url provides a download link for the individual job logs in plain text format. Use return_content=true to get the actual log content."
+		result.LogsURL = url.String()
+		result.Message = "Job logs are available for download"
+		result.Note = "The logs_url provides a download link for the individual job logs in plain text format. Use return_content=true to get the actual log content."
 	}
 
 	return result, resp, nil
@@ -1049,7 +1120,7 @@ func CancelWorkflowRun(getClient GetClientFn, t translations.TranslationHelperFu
 }
 
 // ListWorkflowRunArtifacts creates a tool to list artifacts for a workflow run
-func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.ArtifactList]) {
 	return mcp.Tool{
 			Name:        "list_workflow_run_artifacts",
 			Description: t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_DESCRIPTION", "List artifacts for a workflow run"),
@@ -1057,7 +1128,7 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				Title:        t("TOOL_LIST_WORKFLOW_RUN_ARTIFACTS_USER_TITLE", "List workflow artifacts"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithWrappedListFieldProjection(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1074,9 +1145,10 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			}),
+			}), "artifacts", "id", "name"),
+			OutputSchema: ProjectedOutputSchema[*github.ArtifactList](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.ArtifactList, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1119,12 +1191,20 @@ func ListWorkflowRunArtifacts(getClient GetClientFn, t translations.TranslationH
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), artifacts, nil
 		}
 }
 
 // DownloadWorkflowRunArtifact creates a tool to download a workflow run artifact
-func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+// ArtifactDownload is the output of download_workflow_run_artifact.
+type ArtifactDownload struct {
+	DownloadURL string `json:"download_url"`
+	Message     string `json:"message"`
+	Note        string `json:"note"`
+	ArtifactID  int64  `json:"artifact_id"`
+}
+
+func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *ArtifactDownload]) {
 	return mcp.Tool{
 			Name:        "download_workflow_run_artifact",
 			Description: t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_DESCRIPTION", "Get download URL for a workflow run artifact"),
@@ -1132,7 +1212,7 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				Title:        t("TOOL_DOWNLOAD_WORKFLOW_RUN_ARTIFACT_USER_TITLE", "Download workflow artifact"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1149,9 +1229,10 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 					},
 				},
 				Required: []string{"owner", "repo", "artifact_id"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*ArtifactDownload](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *ArtifactDownload, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1179,11 +1260,11 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 			defer func() { _ = resp.Body.Close() }()
 
 			// Create response with the download URL and information
-			result := map[string]any{
-				"download_url": url.String(),
-				"message":      "Artifact is available for download",
-				"note":         "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time.",
-				"artifact_id":  artifactID,
+			result := &ArtifactDownload{
+				DownloadURL: url.String(),
+				Message:     "Artifact is available for download",
+				Note:        "The download_url provides a download link for the artifact as a ZIP archive. The link is temporary and expires after a short time.",
+				ArtifactID:  artifactID,
 			}
 
 			r, err := json.Marshal(result)
@@ -1191,7 +1272,7 @@ func DownloadWorkflowRunArtifact(getClient GetClientFn, t translations.Translati
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), result, nil
 		}
 }
 
@@ -1267,7 +1348,7 @@ func DeleteWorkflowRunLogs(getClient GetClientFn, t translations.TranslationHelp
 }
 
 // GetWorkflowRunUsage creates a tool to get usage metrics for a workflow run
-func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.WorkflowRunUsage]) {
 	return mcp.Tool{
 			Name:        "get_workflow_run_usage",
 			Description: t("TOOL_GET_WORKFLOW_RUN_USAGE_DESCRIPTION", "Get usage metrics for a workflow run"),
@@ -1275,7 +1356,7 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 				Title:        t("TOOL_GET_WORKFLOW_RUN_USAGE_USER_TITLE", "Get workflow usage"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -1292,9 +1373,10 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 					},
 				},
 				Required: []string{"owner", "repo", "run_id"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*github.WorkflowRunUsage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.WorkflowRunUsage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1325,6 +1407,6 @@ func GetWorkflowRunUsage(getClient GetClientFn, t translations.TranslationHelper
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), usage, nil
 		}
 }
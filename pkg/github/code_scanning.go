@@ -15,7 +15,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.Alert]) {
 	return mcp.Tool{
 			Name:        "get_code_scanning_alert",
 			Description: t("TOOL_GET_CODE_SCANNING_ALERT_DESCRIPTION", "Get details of a specific code scanning alert in a GitHub repository."),
@@ -23,7 +23,7 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				Title:        t("TOOL_GET_CODE_SCANNING_ALERT_USER_TITLE", "Get code scanning alert"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -40,9 +40,10 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 					},
 				},
 				Required: []string{"owner", "repo", "alertNumber"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*github.Alert](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.Alert, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -84,11 +85,11 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), alert, nil
 		}
 }
 
-func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.Alert]) {
 	return mcp.Tool{
 			Name:        "list_code_scanning_alerts",
 			Description: t("TOOL_LIST_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts in a GitHub repository."),
@@ -96,7 +97,7 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				Title:        t("TOOL_LIST_CODE_SCANNING_ALERTS_USER_TITLE", "List code scanning alerts"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithResultFormat(WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -128,9 +129,10 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 					},
 				},
 				Required: []string{"owner", "repo"},
-			},
+			})),
+			OutputSchema: ProjectedOutputSchema[[]*github.Alert](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.Alert, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -156,6 +158,11 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
@@ -183,6 +190,11 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 				return utils.NewToolResultErrorFromErr("failed to marshal alerts", err), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "", CodeScanningAlertColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, alerts, nil
 		}
 }
@@ -111,12 +111,13 @@ func Test_GetMe(t *testing.T) {
 			_, handler := GetMe(tc.stubbedGetClientFn, translations.NullTranslationHelper)
 
 			request := createMCPRequest(tc.requestArgs)
-			result, _, _ := handler(context.Background(), &request, tc.requestArgs)
+			result, output, _ := handler(context.Background(), &request, tc.requestArgs)
 			textContent := getTextResult(t, result)
 
 			if tc.expectToolError {
 				assert.True(t, result.IsError, "expected tool call result to be an error")
 				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				assert.Nil(t, output)
 				return
 			}
 
@@ -125,6 +126,10 @@ func Test_GetMe(t *testing.T) {
 			err := json.Unmarshal([]byte(textContent.Text), &returnedUser)
 			require.NoError(t, err)
 
+			// The structured output matches the text content
+			require.NotNil(t, output)
+			assert.Equal(t, returnedUser, *output)
+
 			// Verify minimal user details
 			assert.Equal(t, *tc.expectedUser.Login, returnedUser.Login)
 			assert.Equal(t, *tc.expectedUser.HTMLURL, returnedUser.ProfileURL)
@@ -15,7 +15,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.DependabotAlert]) {
 	tool := mcp.Tool{
 		Name:        "get_dependabot_alert",
 		Description: t("TOOL_GET_DEPENDABOT_ALERT_DESCRIPTION", "Get details of a specific dependabot alert in a GitHub repository."),
@@ -23,7 +23,7 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 			Title:        t("TOOL_GET_DEPENDABOT_ALERT_USER_TITLE", "Get dependabot alert"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -40,10 +40,11 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 				},
 			},
 			Required: []string{"owner", "repo", "alertNumber"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.DependabotAlert](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.DependabotAlert](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.DependabotAlert, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -85,13 +86,13 @@ func GetDependabotAlert(getClient GetClientFn, t translations.TranslationHelperF
 			return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, err
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), alert, nil
 	})
 
 	return tool, handler
 }
 
-func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.DependabotAlert]) {
 	tool := mcp.Tool{
 		Name:        "list_dependabot_alerts",
 		Description: t("TOOL_LIST_DEPENDABOT_ALERTS_DESCRIPTION", "List dependabot alerts in a GitHub repository."),
@@ -99,7 +100,7 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			Title:        t("TOOL_LIST_DEPENDABOT_ALERTS_USER_TITLE", "List dependabot alerts"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithResultFormat(WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -123,10 +124,11 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		})),
+		OutputSchema: ProjectedOutputSchema[[]*github.DependabotAlert](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.DependabotAlert](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.DependabotAlert, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -144,6 +146,11 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			return utils.NewToolResultError(err.Error()), nil, nil
 		}
 
+		format, err := OptionalResultFormat(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
 		client, err := getClient(ctx)
 		if err != nil {
 			return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, err
@@ -175,7 +182,12 @@ func ListDependabotAlerts(getClient GetClientFn, t translations.TranslationHelpe
 			return utils.NewToolResultErrorFromErr("failed to marshal alerts", err), nil, err
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		result, err := FormatListResult(r, format, "", DependabotAlertColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return result, alerts, nil
 	})
 
 	return tool, handler
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/go-viper/mapstructure/v2"
@@ -68,6 +70,50 @@ type PageInfoFragment struct {
 	EndCursor       githubv4.String
 }
 
+// DiscussionsPage describes the output of list_discussions. In lockdown redaction mode, redacted discussions
+// are marked with "redacted": true.
+type DiscussionsPage struct {
+	Discussions []*github.Discussion `json:"discussions"`
+	PageInfo    CursorPageInfo       `json:"pageInfo"`
+	TotalCount  int                  `json:"totalCount"`
+}
+
+// DiscussionDetails is the output of get_discussion, which includes fields missing from go-github's Discussion.
+type DiscussionDetails struct {
+	Number         int                `json:"number"`
+	Title          string             `json:"title"`
+	Body           string             `json:"body"`
+	URL            string             `json:"url"`
+	Closed         bool               `json:"closed"`
+	IsAnswered     bool               `json:"isAnswered"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	AnswerChosenAt *time.Time         `json:"answerChosenAt,omitempty"`
+	User           MinimalUser        `json:"user"`
+	Category       DiscussionCategory `json:"category"`
+	Redacted       bool               `json:"redacted,omitempty"`
+}
+
+// DiscussionCommentsPage describes the output of get_discussion_comments. In lockdown redaction mode, redacted
+// comments are marked with "redacted": true.
+type DiscussionCommentsPage struct {
+	Comments   []*github.IssueComment `json:"comments"`
+	PageInfo   CursorPageInfo         `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+// DiscussionCategory is a discussion category, of which get_discussion returns only the name.
+type DiscussionCategory struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// DiscussionCategoriesPage is the output of list_discussion_categories.
+type DiscussionCategoriesPage struct {
+	Categories []DiscussionCategory `json:"categories"`
+	PageInfo   CursorPageInfo       `json:"pageInfo"`
+	TotalCount int                  `json:"totalCount"`
+}
+
 type BasicNoOrder struct {
 	Repository struct {
 		Discussions DiscussionFragment `graphql:"discussions(first: $first, after: $after)"`
@@ -121,7 +167,7 @@ func getQueryType(useOrdering bool, categoryID *githubv4.ID) any {
 	return &BasicNoOrder{}
 }
 
-func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListDiscussions(getGQLClient GetGQLClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, map[string]any]) {
 	return mcp.Tool{
 			Name:        "list_discussions",
 			Description: t("TOOL_LIST_DISCUSSIONS_DESCRIPTION", "List discussions for a repository or organisation."),
@@ -129,7 +175,7 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				Title:        t("TOOL_LIST_DISCUSSIONS_USER_TITLE", "List discussions"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithCursorPagination(&jsonschema.Schema{
+			InputSchema: WithResultFormat(WithWrappedListFieldProjection(WithCursorPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -156,9 +202,10 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 					},
 				},
 				Required: []string{"owner"},
-			}),
+			}), "discussions", "number", "title")),
+			OutputSchema: ProjectedOutputSchema[*DiscussionsPage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -198,6 +245,11 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				return nil, nil, err
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
@@ -250,27 +302,43 @@ func ListDiscussions(getGQLClient GetGQLClientFn, t translations.TranslationHelp
 				totalCount = fragment.TotalCount
 			}
 
+			var discussionList any = discussions
+			if flags.LockdownMode {
+				discussionList, err = lockdownContent(ctx, cache, flags, discussions, func(discussion *github.Discussion) (string, string, string) {
+					return discussion.GetUser().GetLogin(), owner, repo
+				}, "title", "body")
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+			}
+
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"discussions": discussions,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
+			// A map, as discussions redacted by lockdown mode are not *github.Discussion; see DiscussionsPage
+			response := map[string]any{
+				"discussions": discussionList,
+				"pageInfo": CursorPageInfo{
+					HasNextPage:     pageInfo.HasNextPage,
+					HasPreviousPage: pageInfo.HasPreviousPage,
+					StartCursor:     string(pageInfo.StartCursor),
+					EndCursor:       string(pageInfo.EndCursor),
 				},
-				"totalCount": totalCount,
+				"totalCount": int(totalCount),
 			}
 
 			out, err := json.Marshal(response)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal discussions: %w", err)
 			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			result, err := FormatListResult(out, format, "discussions", DiscussionColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, response, nil
 		}
 }
 
-func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetDiscussion(getGQLClient GetGQLClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *DiscussionDetails]) {
 	return mcp.Tool{
 			Name:        "get_discussion",
 			Description: t("TOOL_GET_DISCUSSION_DESCRIPTION", "Get a specific discussion by ID"),
@@ -278,7 +346,7 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 				Title:        t("TOOL_GET_DISCUSSION_USER_TITLE", "Get discussion"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -295,9 +363,10 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 					},
 				},
 				Required: []string{"owner", "repo", "discussionNumber"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*DiscussionDetails](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *DiscussionDetails, error) {
 			// Decode params
 			var params struct {
 				Owner            string
@@ -326,6 +395,9 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 						Category       struct {
 							Name githubv4.String
 						} `graphql:"category"`
+						Author struct {
+							Login githubv4.String
+						}
 					} `graphql:"discussion(number: $discussionNumber)"`
 				} `graphql:"repository(owner: $owner, name: $repo)"`
 			}
@@ -339,26 +411,46 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 			}
 			d := q.Repository.Discussion
 
-			// Build response as map to include fields not present in go-github's Discussion struct.
-			// The go-github library's Discussion type lacks isAnswered and answerChosenAt fields,
-			// so we use map[string]interface{} for the response (consistent with other functions
-			// like ListDiscussions and GetDiscussionComments).
-			response := map[string]interface{}{
-				"number":     int(d.Number),
-				"title":      string(d.Title),
-				"body":       string(d.Body),
-				"url":        string(d.URL),
-				"closed":     bool(d.Closed),
-				"isAnswered": bool(d.IsAnswered),
-				"createdAt":  d.CreatedAt.Time,
-				"category": map[string]interface{}{
-					"name": string(d.Category.Name),
-				},
+			var redacted bool
+			if flags.LockdownMode {
+				if cache == nil {
+					return nil, nil, fmt.Errorf("lockdown cache is not configured")
+				}
+				login := string(d.Author.Login)
+				if login != "" {
+					isSafeContent, err := cache.IsSafeContent(ctx, login, params.Owner, params.Repo)
+					if err != nil {
+						return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+					}
+					if !isSafeContent && !flags.LockdownRedact {
+						return utils.NewToolResultError("access to discussion details is restricted by lockdown mode"), nil, nil
+					}
+					redacted = !isSafeContent
+				}
+			}
+
+			// The go-github library's Discussion type lacks the isAnswered and answerChosenAt fields
+			response := &DiscussionDetails{
+				Number:     int(d.Number),
+				Title:      string(d.Title),
+				Body:       string(d.Body),
+				URL:        string(d.URL),
+				Closed:     bool(d.Closed),
+				IsAnswered: bool(d.IsAnswered),
+				CreatedAt:  d.CreatedAt.Time,
+				User:       MinimalUser{Login: string(d.Author.Login)},
+				Category:   DiscussionCategory{Name: string(d.Category.Name)},
 			}
 
 			// Add optional timestamp fields if present
 			if d.AnswerChosenAt != nil {
-				response["answerChosenAt"] = d.AnswerChosenAt.Time
+				response.AnswerChosenAt = &d.AnswerChosenAt.Time
+			}
+
+			if redacted {
+				response.Title = RedactedContentPlaceholder
+				response.Body = RedactedContentPlaceholder
+				response.Redacted = true
 			}
 
 			out, err := json.Marshal(response)
@@ -366,11 +458,11 @@ func GetDiscussion(getGQLClient GetGQLClientFn, t translations.TranslationHelper
 				return nil, nil, fmt.Errorf("failed to marshal discussion: %w", err)
 			}
 
-			return utils.NewToolResultText(string(out)), nil, nil
+			return utils.NewToolResultText(string(out)), response, nil
 		}
 }
 
-func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetDiscussionComments(getGQLClient GetGQLClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, map[string]any]) {
 	return mcp.Tool{
 			Name:        "get_discussion_comments",
 			Description: t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion"),
@@ -378,7 +470,7 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				Title:        t("TOOL_GET_DISCUSSION_COMMENTS_USER_TITLE", "Get discussion comments"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithCursorPagination(&jsonschema.Schema{
+			InputSchema: WithWrappedListFieldProjection(WithCursorPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -395,9 +487,10 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					},
 				},
 				Required: []string{"owner", "repo", "discussionNumber"},
-			}),
+			}), "comments", "body"),
+			OutputSchema: ProjectedOutputSchema[*DiscussionCommentsPage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
 			// Decode params
 			var params struct {
 				Owner            string
@@ -439,7 +532,10 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
-								Body githubv4.String
+								Body   githubv4.String
+								Author struct {
+									Login githubv4.String
+								}
 							}
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
@@ -469,17 +565,31 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 
 			var comments []*github.IssueComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comments = append(comments, &github.IssueComment{
+					Body: github.Ptr(string(c.Body)),
+					User: &github.User{Login: github.Ptr(string(c.Author.Login))},
+				})
+			}
+
+			var commentList any = comments
+			if flags.LockdownMode {
+				commentList, err = lockdownContent(ctx, cache, flags, comments, func(comment *github.IssueComment) (string, string, string) {
+					return comment.GetUser().GetLogin(), params.Owner, params.Repo
+				}, "body")
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"comments": comments,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
+			// A map, as comments redacted by lockdown mode are not *github.IssueComment; see DiscussionCommentsPage
+			response := map[string]any{
+				"comments": commentList,
+				"pageInfo": CursorPageInfo{
+					HasNextPage:     bool(q.Repository.Discussion.Comments.PageInfo.HasNextPage),
+					HasPreviousPage: bool(q.Repository.Discussion.Comments.PageInfo.HasPreviousPage),
+					StartCursor:     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
+					EndCursor:       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
 				},
 				"totalCount": q.Repository.Discussion.Comments.TotalCount,
 			}
@@ -489,11 +599,11 @@ func GetDiscussionComments(getGQLClient GetGQLClientFn, t translations.Translati
 				return nil, nil, fmt.Errorf("failed to marshal comments: %w", err)
 			}
 
-			return utils.NewToolResultText(string(out)), nil, nil
+			return utils.NewToolResultText(string(out)), response, nil
 		}
 }
 
-func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *DiscussionCategoriesPage]) {
 	return mcp.Tool{
 			Name:        "list_discussion_categories",
 			Description: t("TOOL_LIST_DISCUSSION_CATEGORIES_DESCRIPTION", "List discussion categories with their id and name, for a repository or organisation."),
@@ -501,7 +611,7 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				Title:        t("TOOL_LIST_DISCUSSION_CATEGORIES_USER_TITLE", "List discussion categories"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -514,9 +624,10 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 					},
 				},
 				Required: []string{"owner"},
-			},
+			}, "categories", "name"),
+			OutputSchema: ProjectedOutputSchema[*DiscussionCategoriesPage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *DiscussionCategoriesPage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -562,30 +673,30 @@ func ListDiscussionCategories(getGQLClient GetGQLClientFn, t translations.Transl
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			var categories []map[string]string
+			categories := []DiscussionCategory{}
 			for _, c := range q.Repository.DiscussionCategories.Nodes {
-				categories = append(categories, map[string]string{
-					"id":   fmt.Sprint(c.ID),
-					"name": string(c.Name),
+				categories = append(categories, DiscussionCategory{
+					ID:   fmt.Sprint(c.ID),
+					Name: string(c.Name),
 				})
 			}
 
 			// Create response with pagination info
-			response := map[string]interface{}{
-				"categories": categories,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     q.Repository.DiscussionCategories.PageInfo.HasNextPage,
-					"hasPreviousPage": q.Repository.DiscussionCategories.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
+			response := &DiscussionCategoriesPage{
+				Categories: categories,
+				PageInfo: CursorPageInfo{
+					HasNextPage:     bool(q.Repository.DiscussionCategories.PageInfo.HasNextPage),
+					HasPreviousPage: bool(q.Repository.DiscussionCategories.PageInfo.HasPreviousPage),
+					StartCursor:     string(q.Repository.DiscussionCategories.PageInfo.StartCursor),
+					EndCursor:       string(q.Repository.DiscussionCategories.PageInfo.EndCursor),
 				},
-				"totalCount": q.Repository.DiscussionCategories.TotalCount,
+				TotalCount: int(q.Repository.DiscussionCategories.TotalCount),
 			}
 
 			out, err := json.Marshal(response)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal discussion categories: %w", err)
 			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			return utils.NewToolResultText(string(out)), response, nil
 		}
 }
@@ -214,7 +214,7 @@ var (
 
 func Test_ListDiscussions(t *testing.T) {
 	mockClient := githubv4.NewClient(nil)
-	toolDef, _ := ListDiscussions(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	toolDef, _ := ListDiscussions(stubGetGQLClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
 
 	assert.Equal(t, "list_discussions", toolDef.Name)
@@ -447,7 +447,7 @@ func Test_ListDiscussions(t *testing.T) {
 			}
 
 			gqlClient := githubv4.NewClient(httpClient)
-			_, handler := ListDiscussions(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+			_, handler := ListDiscussions(stubGetGQLClientFn(gqlClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			req := createMCPRequest(tc.reqParams)
 			res, _, err := handler(context.Background(), &req, tc.reqParams)
@@ -494,7 +494,7 @@ func Test_ListDiscussions(t *testing.T) {
 
 func Test_GetDiscussion(t *testing.T) {
 	// Verify tool definition and schema
-	toolDef, _ := GetDiscussion(nil, translations.NullTranslationHelper)
+	toolDef, _ := GetDiscussion(nil, repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
 
 	assert.Equal(t, "get_discussion", toolDef.Name)
@@ -507,7 +507,7 @@ func Test_GetDiscussion(t *testing.T) {
 	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,closed,isAnswered,answerChosenAt,url,category{name}}}}"
+	qGetDiscussion := "query($discussionNumber:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){number,title,body,createdAt,closed,isAnswered,answerChosenAt,url,category{name},author{login}}}}"
 
 	vars := map[string]interface{}{
 		"owner":            "owner",
@@ -515,11 +515,12 @@ func Test_GetDiscussion(t *testing.T) {
 		"discussionNumber": float64(1),
 	}
 	tests := []struct {
-		name        string
-		response    githubv4mock.GQLResponse
-		expectError bool
-		expected    map[string]interface{}
-		errContains string
+		name            string
+		response        githubv4mock.GQLResponse
+		expectError     bool
+		expected        map[string]interface{}
+		errContains     string
+		lockdownEnabled bool
 	}{
 		{
 			name: "successful retrieval",
@@ -551,13 +552,32 @@ func Test_GetDiscussion(t *testing.T) {
 			expectError: true,
 			errContains: "discussion not found",
 		},
+		{
+			name: "lockdown enabled - author lacks push access",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"discussion": map[string]any{
+					"number":     1,
+					"title":      "Test Discussion Title",
+					"body":       "This is a test discussion",
+					"url":        "https://github.com/owner/repo/discussions/1",
+					"createdAt":  "2025-04-25T12:00:00Z",
+					"closed":     false,
+					"isAnswered": false,
+					"category":   map[string]any{"name": "General"},
+					"author":     map[string]any{"login": "testuser"},
+				}},
+			}),
+			expectError:     true,
+			errContains:     "access to discussion details is restricted by lockdown mode",
+			lockdownEnabled: true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			matcher := githubv4mock.NewQueryMatcher(qGetDiscussion, vars, tc.response)
 			httpClient := githubv4mock.NewMockedHTTPClient(matcher)
 			gqlClient := githubv4.NewClient(httpClient)
-			_, handler := GetDiscussion(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+			_, handler := GetDiscussion(stubGetGQLClientFn(gqlClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}))
 
 			reqParams := map[string]interface{}{"owner": "owner", "repo": "repo", "discussionNumber": int32(1)}
 			req := createMCPRequest(reqParams)
@@ -589,7 +609,7 @@ func Test_GetDiscussion(t *testing.T) {
 
 func Test_GetDiscussionComments(t *testing.T) {
 	// Verify tool definition and schema
-	toolDef, _ := GetDiscussionComments(nil, translations.NullTranslationHelper)
+	toolDef, _ := GetDiscussionComments(nil, repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(toolDef.Name, toolDef))
 
 	assert.Equal(t, "get_discussion_comments", toolDef.Name)
@@ -602,7 +622,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]interface{}{
@@ -635,7 +655,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	matcher := githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)
 	httpClient := githubv4mock.NewMockedHTTPClient(matcher)
 	gqlClient := githubv4.NewClient(httpClient)
-	_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+	_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 	reqParams := map[string]interface{}{
 		"owner":            "owner",
@@ -670,6 +690,55 @@ func Test_GetDiscussionComments(t *testing.T) {
 	}
 }
 
+func Test_GetDiscussionComments_Lockdown(t *testing.T) {
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body,author{login}},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	vars := map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": float64(1),
+		"first":            float64(30),
+		"after":            (*string)(nil),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussion": map[string]any{
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						{"body": "Maintainer comment", "author": map[string]any{"login": "maintainer"}},
+						{"body": "External user comment", "author": map[string]any{"login": "testuser"}},
+					},
+					"pageInfo": map[string]any{
+						"hasNextPage":     false,
+						"hasPreviousPage": false,
+						"startCursor":     "",
+						"endCursor":       "",
+					},
+					"totalCount": 2,
+				},
+			},
+		},
+	})
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qGetComments, vars, mockResponse)))
+	_, handler := GetDiscussionComments(stubGetGQLClientFn(gqlClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+	reqParams := map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"discussionNumber": int32(1),
+	}
+	request := createMCPRequest(reqParams)
+	result, _, err := handler(context.Background(), &request, reqParams)
+	require.NoError(t, err)
+
+	var response struct {
+		Comments []*github.IssueComment `json:"comments"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	require.Len(t, response.Comments, 1)
+	assert.Equal(t, "Maintainer comment", response.Comments[0].GetBody())
+	assert.Equal(t, "maintainer", response.Comments[0].GetUser().GetLogin())
+}
+
 func Test_ListDiscussionCategories(t *testing.T) {
 	mockClient := githubv4.NewClient(nil)
 	toolDef, _ := ListDiscussionCategories(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
@@ -813,3 +882,44 @@ func Test_ListDiscussionCategories(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListDiscussions_Lockdown(t *testing.T) {
+	qBasicNoOrder := "query($after:String$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussions(first: $first, after: $after){nodes{number,title,createdAt,updatedAt,closed,isAnswered,answerChosenAt,author{login},category{name},url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}"
+	vars := map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"first": float64(30),
+		"after": (*string)(nil),
+	}
+	mockResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"discussions": map[string]any{
+				"nodes": []map[string]any{
+					{"number": 1, "title": "Maintainer discussion", "author": map[string]any{"login": "maintainer"}},
+					{"number": 2, "title": "External discussion", "author": map[string]any{"login": "testuser"}},
+				},
+				"pageInfo": map[string]any{
+					"hasNextPage":     false,
+					"hasPreviousPage": false,
+					"startCursor":     "",
+					"endCursor":       "",
+				},
+				"totalCount": 2,
+			},
+		},
+	})
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qBasicNoOrder, vars, mockResponse)))
+	_, handler := ListDiscussions(stubGetGQLClientFn(gqlClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+	reqParams := map[string]interface{}{"owner": "owner", "repo": "repo"}
+	req := createMCPRequest(reqParams)
+	res, _, err := handler(context.Background(), &req, reqParams)
+	require.NoError(t, err)
+
+	var response struct {
+		Discussions []*github.Discussion `json:"discussions"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, res).Text), &response))
+	require.Len(t, response.Discussions, 1)
+	assert.Equal(t, 1, response.Discussions[0].GetNumber())
+}
@@ -69,7 +69,7 @@ func EnableToolset(s *mcp.Server, toolsetGroup *toolsets.ToolsetGroup, t transla
 		})
 }
 
-func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []map[string]string]) {
 	return mcp.Tool{
 			Name:        "list_available_toolsets",
 			Description: t("TOOL_LIST_AVAILABLE_TOOLSETS_DESCRIPTION", "List all available toolsets this GitHub MCP server can offer, providing the enabled status of each. Use this when a task could be achieved with a GitHub tool and the currently available tools aren't enough. Call get_toolset_tools with these toolset names to discover specific tools you can call"),
@@ -81,8 +81,9 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				Type:       "object",
 				Properties: map[string]*jsonschema.Schema{},
 			},
+			OutputSchema: OutputSchema[[]map[string]string](),
 		},
-		mcp.ToolHandlerFor[map[string]any, any](func(_ context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+		mcp.ToolHandlerFor[map[string]any, []map[string]string](func(_ context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, []map[string]string, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
 
 			payload := []map[string]string{}
@@ -104,11 +105,11 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				return nil, nil, fmt.Errorf("failed to marshal features: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), payload, nil
 		})
 }
 
-func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []map[string]string]) {
 	return mcp.Tool{
 			Name:        "get_toolset_tools",
 			Description: t("TOOL_GET_TOOLSET_TOOLS_DESCRIPTION", "Lists all the capabilities that are enabled with the specified toolset, use this to get clarity on whether enabling a toolset would help you to complete a task"),
@@ -127,8 +128,9 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				},
 				Required: []string{"toolset"},
 			},
+			OutputSchema: OutputSchema[[]map[string]string](),
 		},
-		mcp.ToolHandlerFor[map[string]any, any](func(_ context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		mcp.ToolHandlerFor[map[string]any, []map[string]string](func(_ context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []map[string]string, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
 			toolsetName, err := RequiredParam[string](args, "toolset")
 			if err != nil {
@@ -155,6 +157,6 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				return nil, nil, fmt.Errorf("failed to marshal features: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), payload, nil
 		})
 }
@@ -3,4 +3,7 @@ package github
 // FeatureFlags defines runtime feature toggles that adjust tool behavior.
 type FeatureFlags struct {
 	LockdownMode bool
+	// LockdownRedact makes lockdown mode redact the title, body and comments of untrusted authors, keeping the
+	// metadata, instead of withholding the content.
+	LockdownRedact bool
 }
@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FieldsParam is the name of the parameter used to project the result of a read tool.
+const FieldsParam = "fields"
+
+// WithFieldProjection adds the fields parameter to a tool, which lets callers request only the parts of the
+// result they need. The projection is applied to the result by FieldProjectionMiddleware. It panics if the tool
+// already has a fields parameter of its own, such as the field IDs of list_project_items, which would be lost.
+func WithFieldProjection(schema *jsonschema.Schema) *jsonschema.Schema {
+	if _, ok := schema.Properties[FieldsParam]; ok {
+		panic(fmt.Sprintf("input schema already has a %s parameter", FieldsParam))
+	}
+	schema.Properties[FieldsParam] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Comma-separated fields to return, with dots for nested fields, e.g. 'number,title,user.login'. Applies to each item of lists. Omit to return all fields.",
+	}
+
+	return schema
+}
+
+// WithWrappedListFieldProjection adds the fields parameter to a tool whose result wraps its list in an object,
+// such as {"issues": [...], "pageInfo": {...}}. Field paths are applied to the result as a whole, so the fields of
+// the items are prefixed with list, the path of the list in the result. The description shows an example built
+// from itemFields.
+func WithWrappedListFieldProjection(schema *jsonschema.Schema, list string, itemFields ...string) *jsonschema.Schema {
+	example := make([]string, len(itemFields))
+	for i, field := range itemFields {
+		example[i] = list + "." + field
+	}
+
+	WithFieldProjection(schema)
+	schema.Properties[FieldsParam].Description = fmt.Sprintf("Comma-separated fields to return, with dots for nested fields. The items are listed under '%s', so their fields are prefixed with it, e.g. '%s'. Omit to return all fields.", list, strings.Join(example, ","))
+
+	return schema
+}
+
+// hasFieldProjection reports whether a tool with the given input schema accepts the fields parameter of
+// WithFieldProjection, rather than having no fields parameter or one of its own.
+func hasFieldProjection(schema *jsonschema.Schema) bool {
+	property, ok := schema.Properties[FieldsParam]
+	return ok && property.Type == "string"
+}
+
+// ParseFields parses a comma-separated list of field paths, such as "number,title,user.login".
+func ParseFields(fields string) ([][]string, error) {
+	var paths [][]string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		path := strings.Split(field, ".")
+		for _, segment := range path {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// ProjectFields returns the parts of a decoded JSON value selected by the given field paths. Paths are applied
+// to each item of arrays, so the same paths select fields of a single object or of a list of objects. Lists
+// wrapped in an object are reached through their field, as in "issues.number". Fields that do not exist in the
+// value are omitted.
+func ProjectFields(v any, paths [][]string) any {
+	switch v := v.(type) {
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, ProjectFields(item, paths))
+		}
+		return items
+	case map[string]any:
+		projected := make(map[string]any)
+		nested := make(map[string][][]string)
+		for _, path := range paths {
+			value, ok := v[path[0]]
+			if !ok {
+				continue
+			}
+			if len(path) == 1 {
+				projected[path[0]] = value
+				continue
+			}
+			nested[path[0]] = append(nested[path[0]], path[1:])
+		}
+		for key, subpaths := range nested {
+			if _, ok := projected[key]; ok {
+				// The whole field was requested
+				continue
+			}
+			switch value := v[key].(type) {
+			case map[string]any, []any:
+				projected[key] = ProjectFields(value, subpaths)
+			}
+		}
+		return projected
+	default:
+		return v
+	}
+}
+
+// FieldProjectionMiddleware applies the fields parameter of tool calls to their results. Only JSON results are
+// projected; other results, such as file contents or logs, are returned unchanged. The structured content is
+// projected the same way, which keeps it valid against output schemas as they require no properties.
+func FieldProjectionMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolRequest, ok := req.(*mcp.CallToolRequest)
+		if !ok || callToolRequest.Params == nil {
+			return next(ctx, method, req)
+		}
+
+		var args map[string]any
+		if err := json.Unmarshal(callToolRequest.Params.Arguments, &args); err != nil {
+			return next(ctx, method, req)
+		}
+		// The fields parameter of tools that have their own, such as the field IDs of list_project_items, is left
+		// to them
+		fields, ok := args[FieldsParam].(string)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		paths, err := ParseFields(fields)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil
+		}
+
+		result, err := next(ctx, method, req)
+		if err != nil || len(paths) == 0 {
+			return result, err
+		}
+
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult.IsError || len(callToolResult.Content) != 1 {
+			return result, nil
+		}
+		textContent, ok := callToolResult.Content[0].(*mcp.TextContent)
+		if !ok {
+			return result, nil
+		}
+
+		var v any
+		if err := json.Unmarshal([]byte(textContent.Text), &v); err != nil {
+			return result, nil
+		}
+		switch v.(type) {
+		case map[string]any, []any:
+		default:
+			return result, nil
+		}
+
+		r, err := json.Marshal(ProjectFields(v, paths))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal projected result: %w", err)
+		}
+
+		projected := utils.NewToolResultText(string(r))
+		projected.Meta = callToolResult.Meta
+		if callToolResult.StructuredContent != nil {
+			_, list := v.([]any)
+			projected.StructuredContent, err = projectStructuredContent(callToolResult.StructuredContent, paths, list)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return projected, nil
+	}
+}
+
+// projectStructuredContent applies field paths to the structured content of a tool result. The structured
+// content of tools returning a list has the list under "items", which is projected when list is set.
+func projectStructuredContent(structured any, paths [][]string, list bool) (any, error) {
+	data, err := json.Marshal(structured)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal structured content: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal structured content: %w", err)
+	}
+
+	if object, ok := v.(map[string]any); ok && list {
+		if items, ok := object["items"].([]any); ok {
+			object["items"] = ProjectFields(items, paths)
+			return object, nil
+		}
+	}
+	return ProjectFields(v, paths), nil
+}
@@ -0,0 +1,359 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		fields      string
+		expected    [][]string
+		expectError bool
+	}{
+		{
+			name:     "empty",
+			fields:   "",
+			expected: nil,
+		},
+		{
+			name:     "top level and nested fields",
+			fields:   "number, title,user.login,head.ref",
+			expected: [][]string{{"number"}, {"title"}, {"user", "login"}, {"head", "ref"}},
+		},
+		{
+			name:     "trailing comma",
+			fields:   "number,",
+			expected: [][]string{{"number"}},
+		},
+		{
+			name:        "empty segment",
+			fields:      "user..login",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, err := ParseFields(tc.fields)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, paths)
+		})
+	}
+}
+
+func Test_ProjectFields(t *testing.T) {
+	pullRequest := map[string]any{
+		"number": float64(1),
+		"title":  "Fix bug",
+		"body":   "A long description",
+		"user":   map[string]any{"login": "octocat", "id": float64(1)},
+		"head":   map[string]any{"ref": "fix", "sha": "abc"},
+		"labels": []any{
+			map[string]any{"name": "bug", "color": "red"},
+			map[string]any{"name": "p1", "color": "blue"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		value    any
+		fields   string
+		expected any
+	}{
+		{
+			name:   "object",
+			value:  pullRequest,
+			fields: "number,title,user.login,head.ref",
+			expected: map[string]any{
+				"number": float64(1),
+				"title":  "Fix bug",
+				"user":   map[string]any{"login": "octocat"},
+				"head":   map[string]any{"ref": "fix"},
+			},
+		},
+		{
+			name:   "list of objects",
+			value:  []any{pullRequest, map[string]any{"number": float64(2)}},
+			fields: "number,user.login",
+			expected: []any{
+				map[string]any{"number": float64(1), "user": map[string]any{"login": "octocat"}},
+				map[string]any{"number": float64(2)},
+			},
+		},
+		{
+			name:   "nested list",
+			value:  pullRequest,
+			fields: "labels.name",
+			expected: map[string]any{
+				"labels": []any{map[string]any{"name": "bug"}, map[string]any{"name": "p1"}},
+			},
+		},
+		{
+			name:   "whole field takes precedence over nested fields",
+			value:  pullRequest,
+			fields: "user.login,user",
+			expected: map[string]any{
+				"user": map[string]any{"login": "octocat", "id": float64(1)},
+			},
+		},
+		{
+			name:     "missing and scalar fields are omitted",
+			value:    pullRequest,
+			fields:   "missing,title.length",
+			expected: map[string]any{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			paths, err := ParseFields(tc.fields)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ProjectFields(tc.value, paths))
+		})
+	}
+}
+
+func Test_FieldProjectionMiddleware(t *testing.T) {
+	structured := map[string]any{"number": 1, "title": "Fix bug"}
+	meta := mcp.Meta{"continuation": "abc"}
+
+	tests := []struct {
+		name               string
+		args               map[string]any
+		result             *mcp.CallToolResult
+		expectedText       string
+		expectedStructured any
+		expectError        bool
+		expectUnchanged    bool
+	}{
+		{
+			name:               "projects JSON result",
+			args:               map[string]any{"fields": "title"},
+			result:             &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: `{"number":1,"title":"Fix bug"}`}}, StructuredContent: structured, Meta: meta},
+			expectedText:       `{"title":"Fix bug"}`,
+			expectedStructured: map[string]any{"title": "Fix bug"},
+		},
+		{
+			name: "projects items of list result",
+			args: map[string]any{"fields": "name"},
+			result: &mcp.CallToolResult{
+				Content:           []mcp.Content{&mcp.TextContent{Text: `[{"name":"main","sha":"abc"}]`}},
+				StructuredContent: map[string]any{"items": []MinimalBranch{{Name: "main", SHA: "abc"}}},
+				Meta:              meta,
+			},
+			expectedText:       `[{"name":"main"}]`,
+			expectedStructured: map[string]any{"items": []any{map[string]any{"name": "main"}}},
+		},
+		{
+			name:         "result without structured content",
+			args:         map[string]any{"fields": "title"},
+			result:       utils.NewToolResultText(`{"number":1,"title":"Fix bug"}`),
+			expectedText: `{"title":"Fix bug"}`,
+		},
+		{
+			name:            "no fields",
+			args:            map[string]any{},
+			result:          &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: `{"number":1,"title":"Fix bug"}`}}, StructuredContent: structured},
+			expectUnchanged: true,
+		},
+		{
+			name:            "non-JSON result",
+			args:            map[string]any{"fields": "title"},
+			result:          utils.NewToolResultText("diff --git a/README.md b/README.md"),
+			expectUnchanged: true,
+		},
+		{
+			name:            "error result",
+			args:            map[string]any{"fields": "title"},
+			result:          utils.NewToolResultError(`{"message":"Not Found"}`),
+			expectUnchanged: true,
+		},
+		{
+			name:        "invalid fields",
+			args:        map[string]any{"fields": "user..login"},
+			result:      utils.NewToolResultText(`{}`),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				return tc.result, nil
+			}
+
+			request := createMCPRequest(tc.args)
+			result, err := FieldProjectionMiddleware(next)(context.Background(), "tools/call", &request)
+			require.NoError(t, err)
+
+			callToolResult, ok := result.(*mcp.CallToolResult)
+			require.True(t, ok)
+
+			if tc.expectError {
+				require.True(t, callToolResult.IsError)
+				return
+			}
+			if tc.expectUnchanged {
+				assert.Same(t, tc.result, callToolResult)
+				return
+			}
+
+			textContent := getTextResult(t, callToolResult)
+			assert.JSONEq(t, tc.expectedText, textContent.Text)
+			assert.Equal(t, tc.expectedStructured, callToolResult.StructuredContent)
+			assert.Equal(t, tc.result.Meta, callToolResult.Meta)
+		})
+	}
+}
+
+func Test_FieldProjectionMiddleware_WrappedList(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetSearchIssues, &github.IssuesSearchResult{
+			Total:             github.Ptr(1),
+			IncompleteResults: github.Ptr(false),
+			Issues: []*github.Issue{
+				{Number: github.Ptr(42), Title: github.Ptr("Bug"), Body: github.Ptr("A long description")},
+			},
+		}),
+	))
+	tool, handler := SearchIssues(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
+
+	// The description tells how to reach the fields of the wrapped items
+	assert.Contains(t, tool.InputSchema.(*jsonschema.Schema).Properties[FieldsParam].Description, "'items.number,items.title'")
+
+	next := func(ctx context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		request := req.(*mcp.CallToolRequest)
+		var args map[string]any
+		require.NoError(t, json.Unmarshal(request.Params.Arguments, &args))
+		result, out, err := handler(ctx, request, args)
+		result.StructuredContent = out
+		return result, err
+	}
+	request := createMCPRequest(map[string]any{"query": "bug", "fields": "total_count,items.number"})
+	result, err := FieldProjectionMiddleware(next)(context.Background(), "tools/call", &request)
+	require.NoError(t, err)
+
+	callToolResult := result.(*mcp.CallToolResult)
+	expected := `{"total_count":1,"items":[{"number":42}]}`
+	assert.JSONEq(t, expected, getTextResult(t, callToolResult).Text)
+	structured, err := json.Marshal(callToolResult.StructuredContent)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(structured))
+}
+
+func Test_FieldProjectionMiddleware_OtherMethods(t *testing.T) {
+	called := false
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		called = true
+		return &mcp.ListToolsResult{}, nil
+	}
+
+	result, err := FieldProjectionMiddleware(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.IsType(t, &mcp.ListToolsResult{}, result)
+
+	// A fields parameter that isn't a string belongs to the tool
+	called = false
+	request := createMCPRequest(map[string]any{"fields": []any{"123"}})
+	result, err = FieldProjectionMiddleware(next)(context.Background(), "tools/call", &request)
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.IsType(t, &mcp.ListToolsResult{}, result)
+}
+
+func Test_WithFieldProjection_ToolWithFields(t *testing.T) {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"fields": {Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+		},
+	}
+	assert.Panics(t, func() { WithFieldProjection(schema) })
+	assert.Equal(t, "array", schema.Properties[FieldsParam].Type)
+}
+
+func Test_FieldProjectionMiddleware_Server(t *testing.T) {
+	items := []map[string]any{
+		{"id": 301, "content_type": "Issue", "fields": []map[string]any{{"id": 123, "name": "Status", "value": "Done"}}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{Pattern: "/orgs/{org}/projectsV2/{project}/items", Method: http.MethodGet},
+			expectQueryParams(t, map[string]string{"fields": "123", "per_page": "50"}).andThen(
+				mockResponse(t, http.StatusOK, items),
+			),
+		),
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, &github.Issue{
+			Number: github.Ptr(42),
+			Title:  github.Ptr("Bug"),
+			Body:   github.Ptr("A long description"),
+		}),
+	))
+	getClient := stubGetClientFn(client)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "0.0.1"}, nil)
+	server.AddReceivingMiddleware(FieldProjectionMiddleware)
+	toolsets.NewServerTool(ListProjectItems(getClient, translations.NullTranslationHelper)).RegisterFunc(server)
+	toolsets.NewServerTool(IssueRead(getClient, stubGetGQLClientFn(githubv4.NewClient(nil)), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))).RegisterFunc(server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	go func() {
+		_ = server.Run(ctx, serverTransport)
+	}()
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	// The project field IDs of list_project_items reach the tool
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "list_project_items",
+		Arguments: map[string]any{
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": 1,
+			"fields":         []string{"123"},
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+	var page ProjectItemsPage
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &page))
+	require.Len(t, page.Items, 1)
+
+	// Other read tools are projected
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "issue_read",
+		Arguments: map[string]any{
+			"method":       "get",
+			"owner":        "owner",
+			"repo":         "repo",
+			"issue_number": 42,
+			"fields":       "number,title",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+	assert.JSONEq(t, `{"number":42,"title":"Bug"}`, getTextResult(t, result).Text)
+}
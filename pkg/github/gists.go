@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -15,7 +16,7 @@ import (
 )
 
 // ListGists creates a tool to list gists for a user
-func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.Gist]) {
 	tool := mcp.Tool{
 		Name:        "list_gists",
 		Description: t("TOOL_LIST_GISTS_DESCRIPTION", "List gists for a user"),
@@ -23,7 +24,7 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 			Title:        t("TOOL_LIST_GISTS", "List Gists"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"username": {
@@ -35,10 +36,11 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 					Description: "Only gists updated after this time (ISO 8601 timestamp)",
 				},
 			},
-		}),
+		})),
+		OutputSchema: ProjectedOutputSchema[[]*github.Gist](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.Gist](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.Gist, error) {
 		username, err := OptionalParam[string](args, "username")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -89,19 +91,33 @@ func ListGists(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 			return utils.NewToolResultError(fmt.Sprintf("failed to list gists: %s", string(body))), nil, nil
 		}
 
+		if flags.LockdownMode {
+			viewer, err := viewerLogin(ctx, client)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to check lockdown mode", err), nil, nil
+			}
+			filteredGists := make([]*github.Gist, 0, len(gists))
+			for _, gist := range gists {
+				if strings.EqualFold(gist.GetOwner().GetLogin(), viewer) {
+					filteredGists = append(filteredGists, gist)
+				}
+			}
+			gists = filteredGists
+		}
+
 		r, err := json.Marshal(gists)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), gists, nil
 	})
 
 	return tool, handler
 }
 
 // GetGist creates a tool to get the content of a gist
-func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.Gist]) {
 	tool := mcp.Tool{
 		Name:        "get_gist",
 		Description: t("TOOL_GET_GIST_DESCRIPTION", "Get gist content of a particular gist, by gist ID"),
@@ -109,7 +125,7 @@ func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.T
 			Title:        t("TOOL_GET_GIST", "Get Gist Content"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"gist_id": {
@@ -118,10 +134,11 @@ func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.T
 				},
 			},
 			Required: []string{"gist_id"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.Gist](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.Gist](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.Gist, error) {
 		gistID, err := RequiredParam[string](args, "gist_id")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -146,12 +163,22 @@ func GetGist(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.T
 			return utils.NewToolResultError(fmt.Sprintf("failed to get gist: %s", string(body))), nil, nil
 		}
 
+		if flags.LockdownMode {
+			viewer, err := viewerLogin(ctx, client)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to check lockdown mode", err), nil, nil
+			}
+			if !strings.EqualFold(gist.GetOwner().GetLogin(), viewer) {
+				return utils.NewToolResultError("access to gists of other users is restricted by lockdown mode"), nil, nil
+			}
+		}
+
 		r, err := json.Marshal(gist)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), gist, nil
 	})
 
 	return tool, handler
@@ -358,3 +385,15 @@ func UpdateGist(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 
 	return tool, handler
 }
+
+// viewerLogin returns the login of the authenticated user, the only user whose gists are shown in lockdown mode
+// as gists are not part of a repository with collaborators.
+func viewerLogin(ctx context.Context, client *github.Client) (string, error) {
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return user.GetLogin(), nil
+}
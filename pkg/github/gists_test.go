@@ -19,7 +19,7 @@ import (
 func Test_ListGists(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
-	tool, _ := ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListGists(stubGetClientFn(mockClient), translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
@@ -158,7 +158,7 @@ func Test_ListGists(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListGists(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ListGists(stubGetClientFn(client), translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -203,7 +203,7 @@ func Test_ListGists(t *testing.T) {
 func Test_GetGist(t *testing.T) {
 	// Verify tool definition
 	mockClient := github.NewClient(nil)
-	tool, _ := GetGist(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetGist(stubGetClientFn(mockClient), translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
@@ -276,7 +276,7 @@ func Test_GetGist(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetGist(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetGist(stubGetClientFn(client), translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -619,3 +619,41 @@ func Test_UpdateGist(t *testing.T) {
 		})
 	}
 }
+
+func Test_Gists_Lockdown(t *testing.T) {
+	viewer := &github.User{Login: github.Ptr("octocat")}
+	ownGist := &github.Gist{ID: github.Ptr("1"), Owner: &github.User{Login: github.Ptr("octocat")}}
+	otherGist := &github.Gist{ID: github.Ptr("2"), Owner: &github.User{Login: github.Ptr("someone-else")}}
+	flags := stubFeatureFlags(map[string]bool{"lockdown-mode": true})
+
+	t.Run("list gists only returns the gists of the authenticated user", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetUser, viewer),
+			mock.WithRequestMatch(mock.GetGists, []*github.Gist{ownGist, otherGist}),
+		))
+		_, handler := ListGists(stubGetClientFn(client), translations.NullTranslationHelper, flags)
+
+		args := map[string]interface{}{}
+		request := createMCPRequest(args)
+		result, gists, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, gists, 1)
+		assert.Equal(t, "1", gists[0].GetID())
+	})
+
+	t.Run("get gist of another user is restricted", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetUser, viewer),
+			mock.WithRequestMatch(mock.GetGistsByGistId, otherGist),
+		))
+		_, handler := GetGist(stubGetClientFn(client), translations.NullTranslationHelper, flags)
+
+		args := map[string]interface{}{"gist_id": "2"}
+		request := createMCPRequest(args)
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "restricted by lockdown mode")
+	})
+}
@@ -1,10 +1,15 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -37,7 +42,7 @@ type TreeResponse struct {
 }
 
 // GetRepositoryTree creates a tool to get the tree structure of a GitHub repository.
-func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *TreeResponse]) {
 	tool := mcp.Tool{
 		Name:        "get_repository_tree",
 		Description: t("TOOL_GET_REPOSITORY_TREE_DESCRIPTION", "Get the tree structure (files and directories) of a GitHub repository at a specific ref or SHA"),
@@ -45,7 +50,7 @@ func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFu
 			Title:        t("TOOL_GET_REPOSITORY_TREE_USER_TITLE", "Get repository tree"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -71,11 +76,12 @@ func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFu
 				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		}, "tree", "path", "type"),
+		OutputSchema: ProjectedOutputSchema[*TreeResponse](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *TreeResponse](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *TreeResponse, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -138,24 +144,10 @@ func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFu
 				filteredEntries = tree.Entries
 			}
 
-			treeEntries := make([]TreeEntryResponse, len(filteredEntries))
-			for i, entry := range filteredEntries {
-				treeEntries[i] = TreeEntryResponse{
-					Path: entry.GetPath(),
-					Type: entry.GetType(),
-					Mode: entry.GetMode(),
-					SHA:  entry.GetSHA(),
-					URL:  entry.GetURL(),
-				}
-				if entry.Size != nil {
-					treeEntries[i].Size = entry.Size
-				}
-			}
-
 			response := TreeResponse{
 				SHA:       *tree.SHA,
 				Truncated: *tree.Truncated,
-				Tree:      treeEntries,
+				Tree:      treeEntryResponses(filteredEntries),
 				TreeSHA:   treeSHA,
 				Owner:     owner,
 				Repo:      repo,
@@ -168,7 +160,835 @@ func GetRepositoryTree(getClient GetClientFn, t translations.TranslationHelperFu
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), &response, nil
+		},
+	)
+
+	return tool, handler
+}
+
+func treeEntryResponses(entries []*github.TreeEntry) []TreeEntryResponse {
+	treeEntries := make([]TreeEntryResponse, len(entries))
+	for i, entry := range entries {
+		treeEntries[i] = TreeEntryResponse{
+			Path: entry.GetPath(),
+			Type: entry.GetType(),
+			Mode: entry.GetMode(),
+			SHA:  entry.GetSHA(),
+			URL:  entry.GetURL(),
+		}
+		if entry.Size != nil {
+			treeEntries[i].Size = entry.Size
+		}
+	}
+	return treeEntries
+}
+
+// CreateTagResult represents the response structure for a created tag.
+type CreateTagResult struct {
+	Tag          string `json:"tag"`
+	Ref          string `json:"ref"`
+	SHA          string `json:"sha"`
+	TagObjectSHA string `json:"tag_object_sha,omitempty"`
+	Annotated    bool   `json:"annotated"`
+}
+
+// CreateTag creates a tool to create a lightweight or annotated tag in a GitHub repository.
+func CreateTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *CreateTagResult]) {
+	tool := mcp.Tool{
+		Name:        "create_tag",
+		Description: t("TOOL_CREATE_TAG_DESCRIPTION", "Create a tag in a GitHub repository at a commit SHA or the head of a branch. The tag is annotated if a message is given, and lightweight otherwise. Fails if the tag already exists."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_CREATE_TAG_USER_TITLE", "Create tag"),
+			ReadOnlyHint: false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"tag": {
+					Type:        "string",
+					Description: "Tag name (e.g., 'v1.0.0')",
+				},
+				"sha": {
+					Type:        "string",
+					Description: "Commit SHA to tag. Takes precedence over branch",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Branch whose head is tagged. Defaults to the repository's default branch",
+				},
+				"message": {
+					Type:        "string",
+					Description: "Tag message. If given, an annotated tag object is created",
+				},
+				"tagger_name": {
+					Type:        "string",
+					Description: "Name of the tagger of an annotated tag. Defaults to the authenticated user",
+				},
+				"tagger_email": {
+					Type:        "string",
+					Description: "Email of the tagger of an annotated tag. Required if tagger_name is given",
+				},
+			},
+			Required: []string{"owner", "repo", "tag"},
+		},
+		OutputSchema: OutputSchema[*CreateTagResult](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *CreateTagResult](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *CreateTagResult, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			tag, err := RequiredParam[string](args, "tag")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sha, err := OptionalParam[string](args, "sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := OptionalParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			message, err := OptionalParam[string](args, "message")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			taggerName, err := OptionalParam[string](args, "tagger_name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			taggerEmail, err := OptionalParam[string](args, "tagger_email")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if (taggerName != "" || taggerEmail != "") && message == "" {
+				return utils.NewToolResultError("tagger_name and tagger_email can only be used with a message"), nil, nil
+			}
+			if (taggerName == "") != (taggerEmail == "") {
+				return utils.NewToolResultError("tagger_name and tagger_email must be given together"), nil, nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			ref := "refs/tags/" + tag
+			existing, resp, err := client.Git.GetRef(ctx, owner, repo, ref)
+			if err == nil {
+				_ = resp.Body.Close()
+				return utils.NewToolResultError(fmt.Sprintf("tag %q already exists at %s", tag, existing.GetObject().GetSHA())), nil, nil
+			}
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to check for existing tag",
+					resp,
+					err,
+				), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			branchRef := ""
+			if branch != "" {
+				branchRef = "refs/heads/" + branch
+			}
+			target, err := resolveGitReference(ctx, client, owner, repo, branchRef, sha)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to resolve the commit to tag: %s", err)), nil, nil
+			}
+
+			result := &CreateTagResult{
+				Tag: tag,
+				Ref: ref,
+				SHA: target.SHA,
+			}
+			// A lightweight tag points at the commit, an annotated tag at a tag object pointing at the commit
+			refSHA := target.SHA
+			if message != "" {
+				tagObject := github.CreateTag{
+					Tag:     tag,
+					Message: message,
+					Object:  target.SHA,
+					Type:    "commit",
+				}
+				if taggerName != "" {
+					tagObject.Tagger = &github.CommitAuthor{
+						Name:  github.Ptr(taggerName),
+						Email: github.Ptr(taggerEmail),
+					}
+				}
+				createdTag, resp, err := client.Git.CreateTag(ctx, owner, repo, tagObject)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to create tag object",
+						resp,
+						err,
+					), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				refSHA = createdTag.GetSHA()
+				result.TagObjectSHA = refSHA
+				result.Annotated = true
+			}
+
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: ref, SHA: refSHA})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tag reference",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), result, nil
+		},
+	)
+
+	return tool, handler
+}
+
+// BlobResponse represents the response structure for a Git blob.
+type BlobResponse struct {
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+	// Encoding is utf-8 for text content, and base64 for binary content.
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GetBlob creates a tool to get a Git blob by its SHA.
+func GetBlob(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *BlobResponse]) {
+	tool := mcp.Tool{
+		Name:        "get_blob",
+		Description: t("TOOL_GET_BLOB_DESCRIPTION", "Get the content of a Git blob by its SHA, as text or, for binary content, base64"),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_GET_BLOB_USER_TITLE", "Get Git blob"),
+			ReadOnlyHint: true,
+		},
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"sha": {
+					Type:        "string",
+					Description: "SHA of the blob, e.g. from get_repository_tree",
+				},
+			},
+			Required: []string{"owner", "repo", "sha"},
+		}),
+		OutputSchema: ProjectedOutputSchema[*BlobResponse](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *BlobResponse](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *BlobResponse, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sha, err := RequiredParam[string](args, "sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			blob, resp, err := client.Git.GetBlob(ctx, owner, repo, sha)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get blob",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			response := &BlobResponse{
+				SHA:      blob.GetSHA(),
+				Size:     blob.GetSize(),
+				Encoding: blob.GetEncoding(),
+				Content:  blob.GetContent(),
+			}
+			if response.Encoding == "base64" {
+				// The API wraps base64 content in lines
+				data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(response.Content, "\n", ""))
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to decode blob content: %w", err)
+				}
+				response.Content = base64.StdEncoding.EncodeToString(data)
+				if utf8.Valid(data) && !bytes.Contains(data, []byte{0}) {
+					response.Encoding = "utf-8"
+					response.Content = string(data)
+				}
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), response, nil
+		},
+	)
+
+	return tool, handler
+}
+
+// CreateTreeResponse represents the response structure for a created Git tree.
+type CreateTreeResponse struct {
+	SHA  string              `json:"sha"`
+	Tree []TreeEntryResponse `json:"tree"`
+}
+
+// CreateTree creates a tool to create a Git tree, on top of a base tree or from scratch.
+func CreateTree(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *CreateTreeResponse]) {
+	tool := mcp.Tool{
+		Name:        "create_tree",
+		Description: t("TOOL_CREATE_TREE_DESCRIPTION", "Create a Git tree in a GitHub repository by adding, replacing, deleting or changing the mode of entries of a base tree. Use create_commit to commit the tree."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_CREATE_TREE_USER_TITLE", "Create Git tree"),
+			ReadOnlyHint: false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"base_tree": {
+					Type:        "string",
+					Description: "SHA of the tree the entries are applied to. If omitted, the new tree only contains the given entries",
+				},
+				"entries": {
+					Type:        "array",
+					Description: "Entries to add, replace or delete. Each entry sets exactly one of content, sha or delete",
+					Items: &jsonschema.Schema{
+						Type: "object",
+						Properties: map[string]*jsonschema.Schema{
+							"path": {
+								Type:        "string",
+								Description: "Path of the entry, relative to the root of the tree",
+							},
+							"mode": {
+								Type:        "string",
+								Description: "File mode: '100644' for a file, '100755' for an executable, '040000' for a subdirectory, '160000' for a submodule or '120000' for a symlink. Defaults to '100644'",
+								Enum:        []any{"100644", "100755", "040000", "160000", "120000"},
+							},
+							"type": {
+								Type:        "string",
+								Description: "Object type. Defaults to the type of the mode",
+								Enum:        []any{"blob", "tree", "commit"},
+							},
+							"content": {
+								Type:        "string",
+								Description: "UTF-8 content of a new blob",
+							},
+							"sha": {
+								Type:        "string",
+								Description: "SHA of an existing object, e.g. to change the mode or path of a file",
+							},
+							"delete": {
+								Type:        "boolean",
+								Description: "Delete the entry at path from the base tree",
+							},
+						},
+						Required: []string{"path"},
+					},
+				},
+			},
+			Required: []string{"owner", "repo", "entries"},
+		},
+		OutputSchema: OutputSchema[*CreateTreeResponse](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *CreateTreeResponse](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *CreateTreeResponse, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			baseTree, err := OptionalParam[string](args, "base_tree")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			entriesObj, ok := args["entries"].([]any)
+			if !ok || len(entriesObj) == 0 {
+				return utils.NewToolResultError("entries parameter must be a non-empty array of objects"), nil, nil
+			}
+
+			entries := make([]*github.TreeEntry, 0, len(entriesObj))
+			for _, entryObj := range entriesObj {
+				entryMap, ok := entryObj.(map[string]any)
+				if !ok {
+					return utils.NewToolResultError("each entry must be an object with a path"), nil, nil
+				}
+				entry, err := treeEntryFromParams(entryMap)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if entry.SHA == nil && entry.Content == nil && baseTree == "" {
+					return utils.NewToolResultError(fmt.Sprintf("cannot delete %s without a base_tree", entry.GetPath())), nil, nil
+				}
+				entries = append(entries, entry)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			tree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseTree, entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create tree",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			response := &CreateTreeResponse{
+				SHA:  tree.GetSHA(),
+				Tree: treeEntryResponses(tree.Entries),
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), response, nil
+		},
+	)
+
+	return tool, handler
+}
+
+// treeEntryFromParams returns the tree entry described by an item of the entries parameter of create_tree. A
+// deleted entry has neither a SHA nor content, which go-github sends as a null SHA.
+func treeEntryFromParams(entryMap map[string]any) (*github.TreeEntry, error) {
+	path, err := RequiredParam[string](entryMap, "path")
+	if err != nil {
+		return nil, fmt.Errorf("each entry must have a path")
+	}
+	mode, err := OptionalParam[string](entryMap, "mode")
+	if err != nil {
+		return nil, err
+	}
+	entryType, err := OptionalParam[string](entryMap, "type")
+	if err != nil {
+		return nil, err
+	}
+	content, hasContent, err := OptionalParamOK[string](entryMap, "content")
+	if err != nil {
+		return nil, err
+	}
+	sha, err := OptionalParam[string](entryMap, "sha")
+	if err != nil {
+		return nil, err
+	}
+	deleteEntry, err := OptionalParam[bool](entryMap, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	set := 0
+	for _, ok := range []bool{hasContent, sha != "", deleteEntry} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("entry %s must set exactly one of content, sha or delete", path)
+	}
+
+	if mode == "" {
+		mode = "100644"
+	}
+	if entryType == "" {
+		switch mode {
+		case "040000":
+			entryType = "tree"
+		case "160000":
+			entryType = "commit"
+		default:
+			entryType = "blob"
+		}
+	}
+
+	entry := &github.TreeEntry{
+		Path: github.Ptr(path),
+		Mode: github.Ptr(mode),
+		Type: github.Ptr(entryType),
+	}
+	switch {
+	case hasContent:
+		entry.Content = github.Ptr(content)
+	case sha != "":
+		entry.SHA = github.Ptr(sha)
+	}
+	return entry, nil
+}
+
+// GitCommitResponse represents the response structure for a Git commit object.
+type GitCommitResponse struct {
+	SHA       string               `json:"sha"`
+	HTMLURL   string               `json:"html_url,omitempty"`
+	Message   string               `json:"message"`
+	TreeSHA   string               `json:"tree_sha"`
+	Parents   []string             `json:"parents"`
+	Author    *MinimalCommitAuthor `json:"author,omitempty"`
+	Committer *MinimalCommitAuthor `json:"committer,omitempty"`
+}
+
+// CreateCommit creates a tool to create a Git commit object from a tree.
+func CreateCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *GitCommitResponse]) {
+	tool := mcp.Tool{
+		Name:        "create_commit",
+		Description: t("TOOL_CREATE_COMMIT_DESCRIPTION", "Create a Git commit object in a GitHub repository from a tree and any number of parents. The commit is not on any branch until a ref is updated to it with update_ref."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_CREATE_COMMIT_USER_TITLE", "Create Git commit"),
+			ReadOnlyHint: false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"message": {
+					Type:        "string",
+					Description: "Commit message",
+				},
+				"tree": {
+					Type:        "string",
+					Description: "SHA of the tree of the commit, e.g. from create_tree",
+				},
+				"parents": {
+					Type:        "array",
+					Description: "SHAs of the parent commits: one for a regular commit, several for a merge commit, none for a root commit",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+				},
+				"author_name": {
+					Type:        "string",
+					Description: "Name of the author. Defaults to the authenticated user",
+				},
+				"author_email": {
+					Type:        "string",
+					Description: "Email of the author. Required if author_name is given",
+				},
+				"author_date": {
+					Type:        "string",
+					Description: "Date of authorship in ISO 8601 format (e.g., '2024-01-15T10:30:00Z'). Defaults to now",
+				},
+				"committer_name": {
+					Type:        "string",
+					Description: "Name of the committer. Defaults to the author",
+				},
+				"committer_email": {
+					Type:        "string",
+					Description: "Email of the committer. Required if committer_name is given",
+				},
+				"committer_date": {
+					Type:        "string",
+					Description: "Date of the commit in ISO 8601 format (e.g., '2024-01-15T10:30:00Z'). Defaults to the author date",
+				},
+			},
+			Required: []string{"owner", "repo", "message", "tree"},
+		},
+		OutputSchema: OutputSchema[*GitCommitResponse](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *GitCommitResponse](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *GitCommitResponse, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			message, err := RequiredParam[string](args, "message")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			treeSHA, err := RequiredParam[string](args, "tree")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parents, err := OptionalStringArrayParam(args, "parents")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			author, err := commitAuthorFromParams(args, "author")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			committer, err := commitAuthorFromParams(args, "committer")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			commit := github.Commit{
+				Message:   github.Ptr(message),
+				Tree:      &github.Tree{SHA: github.Ptr(treeSHA)},
+				Parents:   make([]*github.Commit, len(parents)),
+				Author:    author,
+				Committer: committer,
+			}
+			for i, parent := range parents {
+				commit.Parents[i] = &github.Commit{SHA: github.Ptr(parent)}
+			}
+
+			newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to create commit",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			response := &GitCommitResponse{
+				SHA:       newCommit.GetSHA(),
+				HTMLURL:   newCommit.GetHTMLURL(),
+				Message:   newCommit.GetMessage(),
+				TreeSHA:   newCommit.GetTree().GetSHA(),
+				Parents:   make([]string, len(newCommit.Parents)),
+				Author:    minimalCommitAuthor(newCommit.Author),
+				Committer: minimalCommitAuthor(newCommit.Committer),
+			}
+			for i, parent := range newCommit.Parents {
+				response.Parents[i] = parent.GetSHA()
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), response, nil
+		},
+	)
+
+	return tool, handler
+}
+
+// commitAuthorFromParams returns the identity given by the <prefix>_name, <prefix>_email and <prefix>_date
+// parameters, or nil if no name is given.
+func commitAuthorFromParams(args map[string]any, prefix string) (*github.CommitAuthor, error) {
+	name, err := OptionalParam[string](args, prefix+"_name")
+	if err != nil {
+		return nil, err
+	}
+	email, err := OptionalParam[string](args, prefix+"_email")
+	if err != nil {
+		return nil, err
+	}
+	date, err := OptionalParam[string](args, prefix+"_date")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		if email != "" || date != "" {
+			return nil, fmt.Errorf("%s_email and %s_date can only be used with %s_name", prefix, prefix, prefix)
+		}
+		return nil, nil
+	}
+	if email == "" {
+		return nil, fmt.Errorf("%s_email is required with %s_name", prefix, prefix)
+	}
+
+	author := &github.CommitAuthor{
+		Name:  github.Ptr(name),
+		Email: github.Ptr(email),
+	}
+	if date != "" {
+		parsed, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s_date %q, must be in ISO 8601 format: %w", prefix, date, err)
+		}
+		author.Date = &github.Timestamp{Time: parsed}
+	}
+	return author, nil
+}
+
+func minimalCommitAuthor(author *github.CommitAuthor) *MinimalCommitAuthor {
+	if author == nil {
+		return nil
+	}
+	minimalAuthor := &MinimalCommitAuthor{
+		Name:  author.GetName(),
+		Email: author.GetEmail(),
+	}
+	if author.Date != nil {
+		minimalAuthor.Date = author.Date.Format(time.RFC3339)
+	}
+	return minimalAuthor
+}
+
+// RefResponse represents the response structure for a Git reference.
+type RefResponse struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// UpdateRef creates a tool to point a branch or tag at another commit.
+func UpdateRef(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *RefResponse]) {
+	tool := mcp.Tool{
+		Name:        "update_ref",
+		Description: t("TOOL_UPDATE_REF_DESCRIPTION", "Point a branch or tag of a GitHub repository at another commit. Unless force is set, only fast-forward updates are allowed."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:           t("TOOL_UPDATE_REF_USER_TITLE", "Update Git reference"),
+			ReadOnlyHint:    false,
+			DestructiveHint: github.Ptr(true),
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"ref": {
+					Type:        "string",
+					Description: "Reference to update, e.g. 'heads/main' for a branch or 'tags/v1.0.0' for a tag",
+				},
+				"sha": {
+					Type:        "string",
+					Description: "SHA the reference points at after the update",
+				},
+				"force": {
+					Type:        "boolean",
+					Description: "Allow updates that are not fast-forwards, discarding commits. Default is false",
+					Default:     json.RawMessage(`false`),
+				},
+			},
+			Required: []string{"owner", "repo", "ref", "sha"},
+		},
+		OutputSchema: OutputSchema[*RefResponse](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *RefResponse](
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *RefResponse, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := RequiredParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sha, err := RequiredParam[string](args, "sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			force, err := OptionalBoolParamWithDefault(args, "force", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			ref = strings.TrimPrefix(ref, "refs/")
+			if !strings.HasPrefix(ref, "heads/") && !strings.HasPrefix(ref, "tags/") {
+				return utils.NewToolResultError(fmt.Sprintf("invalid ref %q, must start with 'heads/' or 'tags/'", ref)), nil, nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, github.UpdateRef{
+				SHA:   sha,
+				Force: github.Ptr(force),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update reference",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			response := &RefResponse{
+				Ref: updatedRef.GetRef(),
+				SHA: updatedRef.GetObject().GetSHA(),
+			}
+
+			r, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), response, nil
 		},
 	)
 
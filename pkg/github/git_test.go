@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -194,3 +195,582 @@ func Test_GetRepositoryTree(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateTag(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTag(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tag", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	inputSchema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected InputSchema to be *jsonschema.Schema")
+	assert.Contains(t, inputSchema.Properties, "tag")
+	assert.Contains(t, inputSchema.Properties, "sha")
+	assert.Contains(t, inputSchema.Properties, "branch")
+	assert.Contains(t, inputSchema.Properties, "message")
+	assert.Contains(t, inputSchema.Properties, "tagger_name")
+	assert.ElementsMatch(t, inputSchema.Required, []string{"owner", "repo", "tag"})
+
+	// getRef serves the heads of main and release, and the existing tag v0.9.0
+	getRef := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refs := map[string]string{
+			"heads/main":    "mainsha",
+			"heads/release": "releasesha",
+			"tags/v0.9.0":   "oldsha",
+		}
+		ref := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/git/ref/")
+		sha, ok := refs[ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&github.Reference{
+			Ref:    github.Ptr("refs/" + ref),
+			Object: &github.GitObject{SHA: github.Ptr(sha)},
+		})
+	})
+	createRef := func(expectedSHA string) mock.MockBackendOption {
+		return mock.WithRequestMatchHandler(
+			mock.PostReposGitRefsByOwnerByRepo,
+			expectRequestBody(t, map[string]any{
+				"ref": "refs/tags/v1.0.0",
+				"sha": expectedSHA,
+			}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr("refs/tags/v1.0.0")}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedResult *CreateTagResult
+		expectedErrMsg string
+	}{
+		{
+			name: "lightweight tag at the default branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, getRef),
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, &github.Repository{DefaultBranch: github.Ptr("main")}),
+				createRef("mainsha"),
+			),
+			requestArgs:    map[string]any{},
+			expectedResult: &CreateTagResult{Tag: "v1.0.0", Ref: "refs/tags/v1.0.0", SHA: "mainsha"},
+		},
+		{
+			name: "lightweight tag at a SHA",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, getRef),
+				createRef("abc123"),
+			),
+			requestArgs:    map[string]any{"sha": "abc123", "branch": "release"},
+			expectedResult: &CreateTagResult{Tag: "v1.0.0", Ref: "refs/tags/v1.0.0", SHA: "abc123"},
+		},
+		{
+			name: "annotated tag at a branch head",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, getRef),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTagsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag":     "v1.0.0",
+						"message": "First release",
+						"object":  "releasesha",
+						"type":    "commit",
+						"tagger":  map[string]any{"name": "Release Bot", "email": "bot@example.com"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Tag{SHA: github.Ptr("tagobjectsha")}),
+					),
+				),
+				createRef("tagobjectsha"),
+			),
+			requestArgs: map[string]any{
+				"branch":       "release",
+				"message":      "First release",
+				"tagger_name":  "Release Bot",
+				"tagger_email": "bot@example.com",
+			},
+			expectedResult: &CreateTagResult{Tag: "v1.0.0", Ref: "refs/tags/v1.0.0", SHA: "releasesha", TagObjectSHA: "tagobjectsha", Annotated: true},
+		},
+		{
+			name: "existing tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, getRef),
+			),
+			requestArgs:    map[string]any{"tag": "v0.9.0"},
+			expectedErrMsg: `tag "v0.9.0" already exists at oldsha`,
+		},
+		{
+			name: "missing branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.GetReposGitRefByOwnerByRepoByRef, getRef),
+			),
+			requestArgs:    map[string]any{"branch": "missing"},
+			expectedErrMsg: "failed to resolve the commit to tag",
+		},
+		{
+			name:           "tagger without message",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"tagger_name": "Release Bot", "tagger_email": "bot@example.com"},
+			expectedErrMsg: "tagger_name and tagger_email can only be used with a message",
+		},
+		{
+			name:           "tagger without email",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"message": "First release", "tagger_name": "Release Bot"},
+			expectedErrMsg: "tagger_name and tagger_email must be given together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTag(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "tag": "v1.0.0"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned CreateTagResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expectedResult, &returned)
+			assert.Equal(t, tc.expectedResult, output)
+		})
+	}
+}
+
+func Test_GetBlob(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_blob", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	inputSchema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected InputSchema to be *jsonschema.Schema")
+	assert.ElementsMatch(t, inputSchema.Required, []string{"owner", "repo", "sha"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expected       *BlobResponse
+		expectedErrMsg string
+	}{
+		{
+			name: "text blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					&github.Blob{SHA: github.Ptr("blobsha"), Size: github.Ptr(12), Encoding: github.Ptr("base64"), Content: github.Ptr("aGVsbG8g\nd29ybGQK\n")},
+				),
+			),
+			expected: &BlobResponse{SHA: "blobsha", Size: 12, Encoding: "utf-8", Content: "hello world\n"},
+		},
+		{
+			name: "binary blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					&github.Blob{SHA: github.Ptr("blobsha"), Size: github.Ptr(3), Encoding: github.Ptr("base64"), Content: github.Ptr("AAH/\n")},
+				),
+			),
+			expected: &BlobResponse{SHA: "blobsha", Size: 3, Encoding: "base64", Content: "AAH/"},
+		},
+		{
+			name: "missing blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectedErrMsg: "failed to get blob",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "sha": "blobsha"}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned BlobResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_CreateTree(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	inputSchema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected InputSchema to be *jsonschema.Schema")
+	assert.Contains(t, inputSchema.Properties, "base_tree")
+	assert.Contains(t, inputSchema.Properties, "entries")
+	assert.ElementsMatch(t, inputSchema.Required, []string{"owner", "repo", "entries"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("newtreesha"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("run.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("runsha")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expected       *CreateTreeResponse
+		expectedErrMsg string
+	}{
+		{
+			name: "add, delete and change mode",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"base_tree": "basesha",
+						"tree": []any{
+							map[string]any{"path": "docs/new.md", "mode": "100644", "type": "blob", "content": "# New"},
+							map[string]any{"path": "old.txt", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]any{"path": "run.sh", "mode": "100755", "type": "blob", "sha": "runsha"},
+							map[string]any{"path": "vendor/lib", "mode": "160000", "type": "commit", "sha": "libsha"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"base_tree": "basesha",
+				"entries": []any{
+					map[string]any{"path": "docs/new.md", "content": "# New"},
+					map[string]any{"path": "old.txt", "delete": true},
+					map[string]any{"path": "run.sh", "mode": "100755", "sha": "runsha"},
+					map[string]any{"path": "vendor/lib", "mode": "160000", "sha": "libsha"},
+				},
+			},
+			expected: &CreateTreeResponse{
+				SHA:  "newtreesha",
+				Tree: []TreeEntryResponse{{Path: "run.sh", Mode: "100755", Type: "blob", SHA: "runsha"}},
+			},
+		},
+		{
+			name:           "entry with content and sha",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"entries": []any{map[string]any{"path": "a.txt", "content": "a", "sha": "asha"}}},
+			expectedErrMsg: "entry a.txt must set exactly one of content, sha or delete",
+		},
+		{
+			name:           "delete without base tree",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"entries": []any{map[string]any{"path": "a.txt", "delete": true}}},
+			expectedErrMsg: "cannot delete a.txt without a base_tree",
+		},
+		{
+			name:           "no entries",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"entries": []any{}},
+			expectedErrMsg: "entries parameter must be a non-empty array",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned CreateTreeResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_CreateCommit(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_commit", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	inputSchema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected InputSchema to be *jsonschema.Schema")
+	assert.Contains(t, inputSchema.Properties, "parents")
+	assert.Contains(t, inputSchema.Properties, "author_name")
+	assert.Contains(t, inputSchema.Properties, "committer_date")
+	assert.ElementsMatch(t, inputSchema.Required, []string{"owner", "repo", "message", "tree"})
+
+	authoredAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	committedAt := time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)
+	mockCommit := &github.Commit{
+		SHA:     github.Ptr("mergesha"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/mergesha"),
+		Message: github.Ptr("Merge feature"),
+		Tree:    &github.Tree{SHA: github.Ptr("treesha")},
+		Parents: []*github.Commit{{SHA: github.Ptr("mainsha")}, {SHA: github.Ptr("featuresha")}},
+		Author:  &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Email: github.Ptr("octocat@example.com"), Date: &github.Timestamp{Time: authoredAt}},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expected       *GitCommitResponse
+		expectedErrMsg string
+	}{
+		{
+			name: "merge commit with custom author",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"message": "Merge feature",
+						"tree":    "treesha",
+						"parents": []any{"mainsha", "featuresha"},
+						"author":  map[string]any{"name": "Octo Cat", "email": "octocat@example.com", "date": "2024-01-15T10:30:00Z"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockCommit),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"parents":      []any{"mainsha", "featuresha"},
+				"author_name":  "Octo Cat",
+				"author_email": "octocat@example.com",
+				"author_date":  "2024-01-15T10:30:00Z",
+			},
+			expected: &GitCommitResponse{
+				SHA:     "mergesha",
+				HTMLURL: "https://github.com/owner/repo/commit/mergesha",
+				Message: "Merge feature",
+				TreeSHA: "treesha",
+				Parents: []string{"mainsha", "featuresha"},
+				Author:  &MinimalCommitAuthor{Name: "Octo Cat", Email: "octocat@example.com", Date: "2024-01-15T10:30:00Z"},
+			},
+		},
+		{
+			name:           "author without email",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"author_name": "Octo Cat"},
+			expectedErrMsg: "author_email is required with author_name",
+		},
+		{
+			name:           "invalid author date",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"author_name": "Octo Cat", "author_email": "octocat@example.com", "author_date": "yesterday"},
+			expectedErrMsg: `invalid author_date "yesterday"`,
+		},
+		{
+			name: "committer without author",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"message":   "Merge feature",
+						"tree":      "treesha",
+						"committer": map[string]any{"name": "Bot", "email": "bot@example.com", "date": "2024-01-16T08:00:00Z"},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Commit{
+							SHA:       github.Ptr("botsha"),
+							Message:   github.Ptr("Merge feature"),
+							Tree:      &github.Tree{SHA: github.Ptr("treesha")},
+							Committer: &github.CommitAuthor{Name: github.Ptr("Bot"), Email: github.Ptr("bot@example.com"), Date: &github.Timestamp{Time: committedAt}},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]any{"committer_name": "Bot", "committer_email": "bot@example.com", "committer_date": "2024-01-16T08:00:00Z"},
+			expected: &GitCommitResponse{
+				SHA:       "botsha",
+				Message:   "Merge feature",
+				TreeSHA:   "treesha",
+				Parents:   []string{},
+				Committer: &MinimalCommitAuthor{Name: "Bot", Email: "bot@example.com", Date: "2024-01-16T08:00:00Z"},
+			},
+		},
+		{
+			name:           "invalid committer date",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"committer_name": "Bot", "committer_email": "bot@example.com", "committer_date": "today"},
+			expectedErrMsg: `invalid committer_date "today"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "message": "Merge feature", "tree": "treesha"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned GitCommitResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_UpdateRef(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	inputSchema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "expected InputSchema to be *jsonschema.Schema")
+	assert.Contains(t, inputSchema.Properties, "force")
+	assert.ElementsMatch(t, inputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	updatedRef := func(force bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/git/refs/heads/feature/x", r.URL.Path)
+			expectRequestBody(t, map[string]any{"sha": "newsha", "force": force}).andThen(
+				mockResponse(t, http.StatusOK, &github.Reference{
+					Ref:    github.Ptr("refs/heads/feature/x"),
+					Object: &github.GitObject{SHA: github.Ptr("newsha")},
+				}),
+			).ServeHTTP(w, r)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.PatchReposGitRefsByOwnerByRepoByRef, updatedRef(false)),
+			),
+			requestArgs: map[string]any{"ref": "heads/feature/x"},
+		},
+		{
+			name: "forced with a fully qualified ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(mock.PatchReposGitRefsByOwnerByRepoByRef, updatedRef(true)),
+			),
+			requestArgs: map[string]any{"ref": "refs/heads/feature/x", "force": true},
+		},
+		{
+			name: "not a fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Update is not a fast forward"}`),
+				),
+			),
+			requestArgs:    map[string]any{"ref": "heads/feature/x"},
+			expectedErrMsg: "failed to update reference",
+		},
+		{
+			name:           "invalid ref",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"ref": "feature/x"},
+			expectedErrMsg: `invalid ref "feature/x"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "sha": "newsha"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned RefResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			expected := &RefResponse{Ref: "refs/heads/feature/x", SHA: "newsha"}
+			assert.Equal(t, expected, &returned)
+			assert.Equal(t, expected, output)
+		})
+	}
+}
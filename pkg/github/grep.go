@@ -0,0 +1,439 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// grepMaxFileSize is the size above which files are skipped by grep_repository.
+	grepMaxFileSize = 1024 * 1024
+	// grepMaxFiles is the number of files searched by grep_repository, beyond which results are incomplete.
+	grepMaxFiles = 1000
+	// grepConcurrency is the number of files downloaded at the same time by grep_repository.
+	grepConcurrency = 8
+	// grepMaxLineLength is the length beyond which the lines of grep_repository results are cut.
+	grepMaxLineLength = 500
+	// grepBinarySniffLength is the number of bytes looked at to tell binary files apart.
+	grepBinarySniffLength = 8000
+)
+
+// GrepMatch is a line matching the pattern of grep_repository.
+type GrepMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+	// Before and After are the lines around the matching line.
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// GrepResult is the result of grep_repository.
+type GrepResult struct {
+	// SHA is the commit that was searched.
+	SHA           string      `json:"sha"`
+	Matches       []GrepMatch `json:"matches"`
+	FilesSearched int         `json:"files_searched"`
+	// SkippedFiles are the files matching the path filters that were not searched, as they are larger than the
+	// size limit or could not be downloaded.
+	SkippedFiles []string `json:"skipped_files,omitempty"`
+	// Truncated is set when there were more matches than max_results.
+	Truncated bool `json:"truncated"`
+	// IncompleteResults is set when some files were not searched, as the repository has too many files matching
+	// the path filters.
+	IncompleteResults bool `json:"incomplete_results"`
+}
+
+// GrepRepository creates a tool to search the files of a repository at any ref with a regular expression.
+func GrepRepository(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *GrepResult]) {
+	tool := mcp.Tool{
+		Name:        "grep_repository",
+		Description: t("TOOL_GREP_REPOSITORY_DESCRIPTION", "Search the files of a single repository with a regular expression, at any branch, tag, commit or pull request head, returning matching lines with the lines around them. Unlike search_code, it sees recent pushes and every branch, at the cost of downloading the files; use paths to narrow down large repositories."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_GREP_REPOSITORY_USER_TITLE", "Grep repository"),
+			ReadOnlyHint: true,
+		},
+		InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"pattern": {
+					Type:        "string",
+					Description: "Regular expression to search for, in RE2 syntax, matched against each line",
+				},
+				"ref": {
+					Type:        "string",
+					Description: "Branch, tag or ref such as `refs/pull/{pr_number}/head` to search. Defaults to the default branch",
+				},
+				"sha": {
+					Type:        "string",
+					Description: "Commit SHA to search. If specified, it will be used instead of ref",
+				},
+				"paths": {
+					Type:        "array",
+					Description: "Glob patterns of the files to search, e.g. 'src/**/*.go'. Patterns without a slash match file names in any directory, e.g. '*.md'. Defaults to all files",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+				},
+				"exclude_paths": {
+					Type:        "array",
+					Description: "Glob patterns of the files not to search, e.g. 'vendor/**'",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+				},
+				"ignore_case": {
+					Type:        "boolean",
+					Description: "Match the pattern case-insensitively",
+					Default:     json.RawMessage(`false`),
+				},
+				"context_lines": {
+					Type:        "number",
+					Description: "Number of lines to return before and after each match",
+					Default:     json.RawMessage(`2`),
+					Minimum:     jsonschema.Ptr(0.0),
+					Maximum:     jsonschema.Ptr(10.0),
+				},
+				"max_results": {
+					Type:        "number",
+					Description: "Maximum number of matches to return",
+					Default:     json.RawMessage(`100`),
+					Minimum:     jsonschema.Ptr(1.0),
+					Maximum:     jsonschema.Ptr(500.0),
+				},
+			},
+			Required: []string{"owner", "repo", "pattern"},
+		}, "matches", "path", "line"),
+		OutputSchema: ProjectedOutputSchema[*GrepResult](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *GrepResult](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *GrepResult, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		pattern, err := RequiredParam[string](args, "pattern")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		ref, err := OptionalParam[string](args, "ref")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		sha, err := OptionalParam[string](args, "sha")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		paths, err := OptionalStringArrayParam(args, "paths")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		excludePaths, err := OptionalStringArrayParam(args, "exclude_paths")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		ignoreCase, err := OptionalBoolParamWithDefault(args, "ignore_case", false)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		// 0 is a valid number of context lines, so it cannot stand for the default
+		contextLines := 2
+		if v, ok, err := OptionalParamOK[float64](args, "context_lines"); err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		} else if ok {
+			contextLines = int(v)
+		}
+		maxResults, err := OptionalIntParamWithDefault(args, "max_results", 100)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		if contextLines < 0 || contextLines > 10 {
+			return utils.NewToolResultError("context_lines must be between 0 and 10"), nil, nil
+		}
+		if maxResults < 1 || maxResults > 500 {
+			return utils.NewToolResultError("max_results must be between 1 and 500"), nil, nil
+		}
+
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("invalid pattern: %s", err)), nil, nil
+		}
+		for _, glob := range append(append([]string{}, paths...), excludePaths...) {
+			if _, err := path.Match(glob, ""); err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("invalid glob pattern %q", glob)), nil, nil
+			}
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return utils.NewToolResultError("failed to get GitHub client"), nil, nil
+		}
+		rawClient, err := getRawClient(ctx)
+		if err != nil {
+			return utils.NewToolResultError("failed to get GitHub raw content client"), nil, nil
+		}
+
+		rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil, nil
+		}
+
+		tree, resp, err := client.Git.GetTree(ctx, owner, repo, rawOpts.SHA, true)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get repository tree",
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		result := &GrepResult{
+			SHA:               rawOpts.SHA,
+			Matches:           []GrepMatch{},
+			IncompleteResults: tree.GetTruncated(),
+		}
+
+		var files []*github.TreeEntry
+		for _, entry := range tree.Entries {
+			// Symbolic links are blobs too, but their content is the path they point to
+			if entry.GetType() != "blob" || entry.GetMode() == "120000" {
+				continue
+			}
+			if !matchesAnyGlob(paths, entry.GetPath(), true) || matchesAnyGlob(excludePaths, entry.GetPath(), false) {
+				continue
+			}
+			if entry.GetSize() > grepMaxFileSize {
+				result.SkippedFiles = append(result.SkippedFiles, entry.GetPath())
+				continue
+			}
+			if len(files) == grepMaxFiles {
+				result.IncompleteResults = true
+				break
+			}
+			files = append(files, entry)
+		}
+
+		// Files are downloaded concurrently in the order of the tree, and their matches are gathered in that order.
+		// Once the files searched from the start of the tree hold more than max_results matches, which tells that
+		// the results are truncated, the remaining downloads are cancelled.
+		searchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		fileMatches := make([][]GrepMatch, len(files))
+		searched := make([]bool, len(files))
+		done := make([]bool, len(files))
+		var mu sync.Mutex
+		// The files before next are done, and hold found matches
+		next, found := 0, 0
+		var wg sync.WaitGroup
+		semaphore := make(chan struct{}, grepConcurrency)
+		for i, entry := range files {
+			select {
+			case semaphore <- struct{}{}:
+			case <-searchCtx.Done():
+			}
+			if searchCtx.Err() != nil {
+				break
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				content, ok := fetchGrepFile(searchCtx, rawClient, owner, repo, entry.GetPath(), rawOpts.SHA)
+				var matches []GrepMatch
+				if ok {
+					matches = grepContent(re, entry.GetPath(), content, contextLines)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				searched[i] = ok
+				fileMatches[i] = matches
+				done[i] = true
+				for next < len(files) && done[next] {
+					found += len(fileMatches[next])
+					next++
+				}
+				if found > maxResults {
+					cancel()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for i, entry := range files {
+			if len(result.Matches) == maxResults && len(fileMatches[i]) > 0 {
+				// The files after the last match kept were not needed, so they are neither searched nor skipped
+				result.Truncated = true
+				break
+			}
+			if !searched[i] {
+				result.SkippedFiles = append(result.SkippedFiles, entry.GetPath())
+				continue
+			}
+			result.FilesSearched++
+			for _, match := range fileMatches[i] {
+				if len(result.Matches) == maxResults {
+					result.Truncated = true
+					break
+				}
+				result.Matches = append(result.Matches, match)
+			}
+			if result.Truncated {
+				break
+			}
+		}
+
+		r, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return utils.NewToolResultText(string(r)), result, nil
+	})
+
+	return tool, handler
+}
+
+// fetchGrepFile downloads a file to search, reporting false if it could not be downloaded or is larger than the
+// size limit.
+func fetchGrepFile(ctx context.Context, rawClient *raw.Client, owner, repo, filePath, sha string) ([]byte, bool) {
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, filePath, &raw.ContentOpts{SHA: sha})
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, grepMaxFileSize+1))
+	if err != nil || len(content) > grepMaxFileSize {
+		return nil, false
+	}
+	return content, true
+}
+
+// grepContent returns the lines of a text file matching re, with contextLines lines around them. Binary files
+// have no matches.
+func grepContent(re *regexp.Regexp, filePath string, content []byte, contextLines int) []GrepMatch {
+	if bytes.IndexByte(content[:min(len(content), grepBinarySniffLength)], 0) != -1 {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+
+	var matches []GrepMatch
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		match := GrepMatch{
+			Path: filePath,
+			Line: i + 1,
+			Text: truncateGrepLine(line),
+		}
+		for _, before := range lines[max(0, i-contextLines):i] {
+			match.Before = append(match.Before, truncateGrepLine(before))
+		}
+		for _, after := range lines[i+1 : min(len(lines), i+1+contextLines)] {
+			match.After = append(match.After, truncateGrepLine(after))
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// truncateGrepLine cuts long lines, such as those of minified files, at a rune boundary.
+func truncateGrepLine(line string) string {
+	if len(line) <= grepMaxLineLength {
+		return line
+	}
+	end := grepMaxLineLength
+	for end > 0 && !utf8.RuneStart(line[end]) {
+		end--
+	}
+	return line[:end] + "..."
+}
+
+// matchesAnyGlob reports whether filePath matches any of the glob patterns, or empty if there are none.
+func matchesAnyGlob(globs []string, filePath string, empty bool) bool {
+	if len(globs) == 0 {
+		return empty
+	}
+	for _, glob := range globs {
+		if matchGlob(glob, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches a slash-separated path against a glob pattern, where "**" matches any number of directories.
+// Patterns without a slash match the name of the file in any directory.
+func matchGlob(glob, filePath string) bool {
+	glob = strings.TrimPrefix(glob, "/")
+	if !strings.Contains(glob, "/") {
+		ok, _ := path.Match(glob, path.Base(filePath))
+		return ok
+	}
+	return matchGlobSegments(strings.Split(glob, "/"), strings.Split(filePath, "/"))
+}
+
+func matchGlobSegments(globSegments, pathSegments []string) bool {
+	for len(globSegments) > 0 {
+		if globSegments[0] == "**" {
+			for i := 0; i <= len(pathSegments); i++ {
+				if matchGlobSegments(globSegments[1:], pathSegments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(pathSegments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(globSegments[0], pathSegments[0]); !ok {
+			return false
+		}
+		globSegments, pathSegments = globSegments[1:], pathSegments[1:]
+	}
+	return len(pathSegments) == 0
+}
@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/raw"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GrepRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	mockRawClient := raw.NewClient(mockClient, &url.URL{Scheme: "https", Host: "raw.githubusercontent.com", Path: "/"})
+	tool, _ := GrepRepository(stubGetClientFn(mockClient), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "grep_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, schema.Properties, "pattern")
+	assert.Contains(t, schema.Properties, "paths")
+	assert.Contains(t, schema.Properties, "exclude_paths")
+	assert.Contains(t, schema.Properties, "context_lines")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pattern"})
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	files := map[string]string{
+		"main.go":             "package main\n\nfunc main() {\n\t// TODO: parse flags\n\trun()\n}\n",
+		"pkg/util/util.go":    "package util\r\n\r\n// todo: remove\r\nfunc Helper() {}\r\n",
+		"vendor/lib/lib.go":   "package lib // TODO: vendored\n",
+		"README.md":           "# Project\n\nTODO: write docs\n",
+		"assets/logo.png":     "\x89PNG\x00\x00TODO",
+		"docs/big.txt":        "TODO",
+		"link":                "main.go",
+		"pkg/util/missing.go": "",
+	}
+	tree := &github.Tree{
+		SHA:       github.Ptr("abc123"),
+		Truncated: github.Ptr(false),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(len(files["README.md"]))},
+			{Path: github.Ptr("assets"), Type: github.Ptr("tree"), Mode: github.Ptr("040000")},
+			{Path: github.Ptr("assets/logo.png"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(10)},
+			{Path: github.Ptr("docs/big.txt"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(grepMaxFileSize + 1)},
+			{Path: github.Ptr("link"), Type: github.Ptr("blob"), Mode: github.Ptr("120000"), Size: github.Ptr(7)},
+			{Path: github.Ptr("main.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(len(files["main.go"]))},
+			{Path: github.Ptr("pkg/util/missing.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(10)},
+			{Path: github.Ptr("pkg/util/util.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(len(files["pkg/util/util.go"]))},
+			{Path: github.Ptr("vendor/lib/lib.go"), Type: github.Ptr("blob"), Mode: github.Ptr("100644"), Size: github.Ptr(len(files["vendor/lib/lib.go"]))},
+		},
+	}
+
+	mockedHTTPClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					assert.True(t, strings.HasSuffix(r.URL.Path, "/git/trees/abc123"))
+					assert.Equal(t, "1", r.URL.Query().Get("recursive"))
+					// TreeEntry marshals only the fields used to create trees, so sizes are written here
+					entries := make([]map[string]any, 0, len(tree.Entries))
+					for _, entry := range tree.Entries {
+						entries = append(entries, map[string]any{"path": entry.GetPath(), "type": entry.GetType(), "mode": entry.GetMode(), "size": entry.GetSize()})
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(map[string]any{"sha": tree.GetSHA(), "truncated": tree.GetTruncated(), "tree": entries})
+				}),
+			),
+			mock.WithRequestMatchHandler(
+				raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					filePath := strings.TrimPrefix(r.URL.Path, "/owner/repo/abc123/")
+					content, ok := files[filePath]
+					if !ok || content == "" {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = w.Write([]byte(content))
+				}),
+			),
+		)
+	}
+
+	tests := []struct {
+		name            string
+		args            map[string]any
+		expectedMatches []GrepMatch
+		expectedSkipped []string
+		expectedSearch  int
+		expectTruncated bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "all files",
+			args: map[string]any{"pattern": "TODO", "context_lines": float64(1)},
+			expectedMatches: []GrepMatch{
+				{Path: "README.md", Line: 3, Text: "TODO: write docs", Before: []string{""}},
+				{Path: "main.go", Line: 4, Text: "\t// TODO: parse flags", Before: []string{"func main() {"}, After: []string{"\trun()"}},
+				{Path: "vendor/lib/lib.go", Line: 1, Text: "package lib // TODO: vendored"},
+			},
+			expectedSkipped: []string{"docs/big.txt", "pkg/util/missing.go"},
+			expectedSearch:  5,
+		},
+		{
+			name: "path filters and ignore case",
+			args: map[string]any{"pattern": "todo", "ignore_case": true, "context_lines": float64(0), "paths": []any{"*.go"}, "exclude_paths": []any{"vendor/**"}},
+			expectedMatches: []GrepMatch{
+				{Path: "main.go", Line: 4, Text: "\t// TODO: parse flags"},
+				{Path: "pkg/util/util.go", Line: 3, Text: "// todo: remove"},
+			},
+			expectedSkipped: []string{"pkg/util/missing.go"},
+			expectedSearch:  2,
+		},
+		{
+			name: "max results",
+			args: map[string]any{"pattern": "TODO", "context_lines": float64(0), "paths": []any{"**/*.go", "*.md"}, "max_results": float64(1)},
+			expectedMatches: []GrepMatch{
+				{Path: "README.md", Line: 3, Text: "TODO: write docs"},
+			},
+			expectedSearch:  1,
+			expectTruncated: true,
+		},
+		{
+			name:           "invalid pattern",
+			args:           map[string]any{"pattern": "TODO("},
+			expectedErrMsg: "invalid pattern",
+		},
+		{
+			name:           "invalid glob",
+			args:           map[string]any{"pattern": "TODO", "paths": []any{"[a-"}},
+			expectedErrMsg: `invalid glob pattern "[a-"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mockedHTTPClient())
+			rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GrepRepository(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "sha": "abc123"}
+			for key, value := range tc.args {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned GrepResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, output, &returned)
+
+			assert.Equal(t, "abc123", returned.SHA)
+			assert.Equal(t, tc.expectedMatches, returned.Matches)
+			assert.Equal(t, tc.expectedSkipped, returned.SkippedFiles)
+			assert.Equal(t, tc.expectedSearch, returned.FilesSearched)
+			assert.Equal(t, tc.expectTruncated, returned.Truncated)
+			assert.False(t, returned.IncompleteResults)
+		})
+	}
+}
+
+func Test_GrepRepository_StopsAtMaxResults(t *testing.T) {
+	const fileCount = 50
+	entries := make([]map[string]any, 0, fileCount)
+	for i := range fileCount {
+		entries = append(entries, map[string]any{"path": fmt.Sprintf("file%02d.txt", i), "type": "blob", "mode": "100644", "size": 5})
+	}
+
+	var downloads atomic.Int32
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(map[string]any{"sha": "abc123", "truncated": false, "tree": entries})
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				downloads.Add(1)
+				// Only the first two files answer at once, the others wait for the search to be cancelled
+				if !strings.HasSuffix(r.URL.Path, "/file00.txt") && !strings.HasSuffix(r.URL.Path, "/file01.txt") {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(time.Second):
+					}
+				}
+				_, _ = w.Write([]byte("TODO\n"))
+			}),
+		),
+	))
+	rawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+	_, handler := GrepRepository(stubGetClientFn(client), stubGetRawClientFn(rawClient), translations.NullTranslationHelper)
+
+	args := map[string]any{"owner": "owner", "repo": "repo", "sha": "abc123", "pattern": "TODO", "context_lines": float64(0), "max_results": float64(1)}
+	request := createMCPRequest(args)
+	_, returned, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	assert.Equal(t, []GrepMatch{{Path: "file00.txt", Line: 1, Text: "TODO"}}, returned.Matches)
+	assert.True(t, returned.Truncated)
+	assert.Equal(t, 1, returned.FilesSearched)
+	assert.Empty(t, returned.SkippedFiles)
+	// Once the first two files hold more than max_results matches, no further file is downloaded
+	assert.LessOrEqual(t, int(downloads.Load()), grepConcurrency+1)
+}
+
+func Test_MatchGlob(t *testing.T) {
+	tests := []struct {
+		glob     string
+		path     string
+		expected bool
+	}{
+		{glob: "*.go", path: "main.go", expected: true},
+		{glob: "*.go", path: "pkg/github/grep.go", expected: true},
+		{glob: "*.go", path: "README.md", expected: false},
+		{glob: "src/*.ts", path: "src/index.ts", expected: true},
+		{glob: "src/*.ts", path: "src/lib/index.ts", expected: false},
+		{glob: "src/**/*.ts", path: "src/index.ts", expected: true},
+		{glob: "src/**/*.ts", path: "src/lib/deep/index.ts", expected: true},
+		{glob: "/src/**", path: "src/lib/index.ts", expected: true},
+		{glob: "vendor/**", path: "pkg/vendor/lib.go", expected: false},
+		{glob: "**/testdata/**", path: "pkg/sanitize/testdata/corpus.json", expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.glob+" "+tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchGlob(tc.glob, tc.path))
+		})
+	}
+}
+
+func Test_TruncateGrepLine(t *testing.T) {
+	line := strings.Repeat("a", grepMaxLineLength-1) + "é" + "tail"
+	truncated := truncateGrepLine(line)
+	assert.Equal(t, strings.Repeat("a", grepMaxLineLength-1)+"...", truncated)
+	assert.Equal(t, "short", truncateGrepLine("short"))
+}
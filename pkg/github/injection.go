@@ -0,0 +1,229 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// InjectionWarningsKey is the key of the warnings added to tool results by InjectionDetectionMiddleware.
+const InjectionWarningsKey = "injection_warnings"
+
+// InjectionWarning describes text of a tool result flagged as a possible prompt injection.
+type InjectionWarning struct {
+	Kind sanitize.InjectionKind `json:"kind"`
+	// Path is the path of the flagged string in JSON results, such as "items[2].body", and empty otherwise.
+	Path  string  `json:"path,omitempty"`
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+}
+
+// InjectionDetectionMiddleware scans the results of tool calls, such as titles, bodies, comments and file
+// contents, for possible prompt injections. Flagged text is wrapped in markers and described by an
+// injection_warnings array, added to JSON object results or as an extra text content otherwise. The strings of
+// the structured content are annotated in place, so that it still matches the output schema of the tool.
+func InjectionDetectionMiddleware(detector *sanitize.InjectionDetector) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil || method != "tools/call" {
+				return result, err
+			}
+
+			callToolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || callToolResult.IsError {
+				return result, nil
+			}
+
+			annotated, warnings := annotateInjections(detector, callToolResult.Content)
+			structured, structuredWarnings := annotateStructuredContent(detector, callToolResult.StructuredContent)
+			if len(warnings) == 0 {
+				warnings = structuredWarnings
+			}
+			if len(warnings) == 0 {
+				return result, nil
+			}
+
+			annotatedResult := &mcp.CallToolResult{
+				Meta:              callToolResult.Meta,
+				Content:           annotated,
+				StructuredContent: structured,
+			}
+
+			// Single JSON objects carry their warnings, other results get them as an extra content
+			if len(annotated) == 1 {
+				if text, ok := annotated[0].(*mcp.TextContent); ok {
+					if withWarnings, ok := addInjectionWarnings(text.Text, warnings); ok {
+						annotatedResult.Content = []mcp.Content{&mcp.TextContent{Text: withWarnings, Meta: text.Meta, Annotations: text.Annotations}}
+						return annotatedResult, nil
+					}
+				}
+			}
+
+			r, err := json.Marshal(map[string]any{InjectionWarningsKey: warnings})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal injection warnings: %w", err)
+			}
+			annotatedResult.Content = append(annotated, &mcp.TextContent{Text: string(r)})
+			return annotatedResult, nil
+		}
+	}
+}
+
+// annotateInjections annotates the text and text resources of content, returning new content when text was
+// flagged.
+func annotateInjections(detector *sanitize.InjectionDetector, content []mcp.Content) ([]mcp.Content, []InjectionWarning) {
+	var warnings []InjectionWarning
+	annotated := make([]mcp.Content, 0, len(content))
+	for _, c := range content {
+		switch c := c.(type) {
+		case *mcp.TextContent:
+			text, textWarnings := annotateInjectionText(detector, c.Text)
+			if len(textWarnings) == 0 {
+				annotated = append(annotated, c)
+				continue
+			}
+			warnings = append(warnings, textWarnings...)
+			annotated = append(annotated, &mcp.TextContent{Text: text, Meta: c.Meta, Annotations: c.Annotations})
+		case *mcp.EmbeddedResource:
+			if c.Resource == nil || c.Resource.Text == "" {
+				annotated = append(annotated, c)
+				continue
+			}
+			text, textWarnings := annotateInjectionString(detector, c.Resource.Text, "")
+			if len(textWarnings) == 0 {
+				annotated = append(annotated, c)
+				continue
+			}
+			warnings = append(warnings, textWarnings...)
+			resource := *c.Resource
+			resource.Text = text
+			annotated = append(annotated, &mcp.EmbeddedResource{Resource: &resource, Meta: c.Meta, Annotations: c.Annotations})
+		default:
+			annotated = append(annotated, c)
+		}
+	}
+	return annotated, warnings
+}
+
+// annotateStructuredContent annotates the strings of structured content, returning it unchanged when no text
+// was flagged.
+func annotateStructuredContent(detector *sanitize.InjectionDetector, structured any) (any, []InjectionWarning) {
+	if structured == nil {
+		return nil, nil
+	}
+	r, err := json.Marshal(structured)
+	if err != nil {
+		return structured, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(r))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return structured, nil
+	}
+
+	var warnings []InjectionWarning
+	v = annotateInjectionValue(detector, v, "", &warnings)
+	if len(warnings) == 0 {
+		return structured, nil
+	}
+	return v, warnings
+}
+
+// annotateInjectionText annotates each string of JSON text, or the whole text otherwise.
+func annotateInjectionText(detector *sanitize.InjectionDetector, text string) (string, []InjectionWarning) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(text)))
+	// Keep numbers, such as IDs, as they are
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return annotateInjectionString(detector, text, "")
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+	default:
+		return annotateInjectionString(detector, text, "")
+	}
+
+	var warnings []InjectionWarning
+	v = annotateInjectionValue(detector, v, "", &warnings)
+	if len(warnings) == 0 {
+		return text, nil
+	}
+	r, err := json.Marshal(v)
+	if err != nil {
+		return annotateInjectionString(detector, text, "")
+	}
+	return string(r), warnings
+}
+
+func annotateInjectionValue(detector *sanitize.InjectionDetector, v any, path string, warnings *[]InjectionWarning) any {
+	switch v := v.(type) {
+	case map[string]any:
+		// Walk the fields in order, so that warnings are listed in a stable order
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			v[key] = annotateInjectionValue(detector, v[key], fieldPath, warnings)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = annotateInjectionValue(detector, item, path+"["+strconv.Itoa(i)+"]", warnings)
+		}
+		return v
+	case string:
+		annotated, stringWarnings := annotateInjectionString(detector, v, path)
+		*warnings = append(*warnings, stringWarnings...)
+		return annotated
+	default:
+		return v
+	}
+}
+
+func annotateInjectionString(detector *sanitize.InjectionDetector, text, path string) (string, []InjectionWarning) {
+	annotated, findings := detector.Annotate(text)
+	if len(findings) == 0 {
+		return text, nil
+	}
+	warnings := make([]InjectionWarning, 0, len(findings))
+	for _, finding := range findings {
+		warnings = append(warnings, InjectionWarning{
+			Kind:  finding.Kind,
+			Path:  path,
+			Text:  finding.Text,
+			Score: finding.Score,
+		})
+	}
+	return annotated, warnings
+}
+
+// addInjectionWarnings adds the warnings to a JSON object, reporting false if text is not a JSON object.
+func addInjectionWarnings(text string, warnings []InjectionWarning) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(text)))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil || object == nil {
+		return "", false
+	}
+	object[InjectionWarningsKey] = warnings
+	r, err := json.Marshal(object)
+	if err != nil {
+		return "", false
+	}
+	return string(r), true
+}
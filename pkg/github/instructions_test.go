@@ -4,6 +4,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGenerateInstructions(t *testing.T) {
@@ -184,3 +187,30 @@ func TestGetToolsetInstructions(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateInstructionsSnapshots(t *testing.T) {
+	t.Setenv("DISABLE_INSTRUCTIONS", "false")
+
+	// Instructions are snapshotted for every toolset on its own, as well as for the
+	// "default" and "all" keywords, expanded the same way the server expands them.
+	combinations := map[string][]string{
+		ToolsetMetadataDefault.ID: AddDefaultToolset([]string{ToolsetMetadataDefault.ID}),
+		ToolsetMetadataAll.ID:     {ToolsetMetadataAll.ID},
+	}
+	for _, toolset := range AvailableTools() {
+		combinations[toolset.ID] = []string{toolset.ID}
+	}
+
+	for name, enabledToolsets := range combinations {
+		t.Run(name, func(t *testing.T) {
+			snapshot := struct {
+				Toolsets     []string `json:"toolsets"`
+				Instructions string   `json:"instructions"`
+			}{
+				Toolsets:     enabledToolsets,
+				Instructions: GenerateInstructions(enabledToolsets),
+			}
+			require.NoError(t, toolsnaps.TestArtifact(toolsnaps.KindInstructions, name, snapshot))
+		})
+	}
+}
@@ -36,7 +36,7 @@ type DependencyRequest struct {
 }
 
 // ListBlockedBy creates a tool to list issues that a given issue is blocked by
-func ListBlockedBy(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListBlockedBy(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []IssueDependency]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -64,9 +64,10 @@ func ListBlockedBy(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_ISSUE_DEPENDENCIES_LIST_BLOCKED_BY_TITLE", "List blocking issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFieldProjection(schema),
+			OutputSchema: ProjectedOutputSchema[[]IssueDependency](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []IssueDependency, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -92,13 +93,12 @@ func ListBlockedBy(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
 			}
 
-			result, err := listIssueDependencies(ctx, client, owner, repo, issueNumber, "blocked_by", pagination)
-			return result, nil, err
+			return listIssueDependencies(ctx, client, owner, repo, issueNumber, "blocked_by", pagination)
 		}
 }
 
 // ListBlocking creates a tool to list issues that a given issue is blocking
-func ListBlocking(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListBlocking(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []IssueDependency]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -126,9 +126,10 @@ func ListBlocking(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_ISSUE_DEPENDENCIES_LIST_BLOCKING_TITLE", "List blocked issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithFieldProjection(schema),
+			OutputSchema: ProjectedOutputSchema[[]IssueDependency](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []IssueDependency, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -154,8 +155,7 @@ func ListBlocking(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
 			}
 
-			result, err := listIssueDependencies(ctx, client, owner, repo, issueNumber, "blocking", pagination)
-			return result, nil, err
+			return listIssueDependencies(ctx, client, owner, repo, issueNumber, "blocking", pagination)
 		}
 }
 
@@ -340,7 +340,7 @@ func RemoveBlockedBy(getClient GetClientFn, t translations.TranslationHelperFunc
 }
 
 // listIssueDependencies fetches dependencies for an issue
-func listIssueDependencies(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, dependencyType string, pagination PaginationParams) (*mcp.CallToolResult, error) {
+func listIssueDependencies(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, dependencyType string, pagination PaginationParams) (*mcp.CallToolResult, []IssueDependency, error) {
 	url := fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/%s", owner, repo, issueNumber, dependencyType)
 
 	// Add pagination parameters if provided
@@ -355,7 +355,7 @@ func listIssueDependencies(ctx context.Context, client *github.Client, owner, re
 
 	req, err := client.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	var deps IssueDependenciesResponse
@@ -368,23 +368,23 @@ func listIssueDependencies(ctx context.Context, client *github.Client, owner, re
 			fmt.Sprintf("failed to list %s dependencies", dependencyType),
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to list dependencies: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to list dependencies: %s", string(body))), nil, nil
 	}
 
 	r, err := json.Marshal(deps.Dependencies)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), deps.Dependencies, nil
 }
 
 // addIssueDependency adds a blocked-by dependency
@@ -11,7 +11,6 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/lockdown"
-	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/go-viper/mapstructure/v2"
@@ -214,7 +213,7 @@ func fragmentToIssue(fragment IssueFragment) *github.Issue {
 
 	return &github.Issue{
 		Number:    github.Ptr(int(fragment.Number)),
-		Title:     github.Ptr(sanitize.Sanitize(string(fragment.Title))),
+		Title:     github.Ptr(string(fragment.Title)),
 		CreatedAt: &github.Timestamp{Time: fragment.CreatedAt.Time},
 		UpdatedAt: &github.Timestamp{Time: fragment.UpdatedAt.Time},
 		User: &github.User{
@@ -222,7 +221,7 @@ func fragmentToIssue(fragment IssueFragment) *github.Issue {
 		},
 		State:    github.Ptr(string(fragment.State)),
 		ID:       github.Ptr(fragment.DatabaseID),
-		Body:     github.Ptr(sanitize.Sanitize(string(fragment.Body))),
+		Body:     github.Ptr(string(fragment.Body)),
 		Labels:   foundLabels,
 		Comments: github.Ptr(int(fragment.Comments.TotalCount)),
 	}
@@ -268,7 +267,13 @@ Options are:
 				Title:        t("TOOL_ISSUE_READ_USER_TITLE", "Get issue details"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema: WithFieldProjection(schema),
+			OutputSchema: AlternativeOutputSchema(
+				ProjectedOutputSchema[*github.Issue](),
+				ProjectedOutputSchema[[]*github.IssueComment](),
+				ProjectedOutputSchema[[]*github.SubIssue](),
+				ProjectedOutputSchema[*LabelsResult](),
+			),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			method, err := RequiredParam[string](args, "method")
@@ -306,73 +311,66 @@ Options are:
 
 			switch method {
 			case "get":
-				result, err := GetIssue(ctx, client, cache, owner, repo, issueNumber, flags)
-				return result, nil, err
+				result, issue, err := GetIssue(ctx, client, cache, owner, repo, issueNumber, flags)
+				return result, issue, err
 			case "get_comments":
-				result, err := GetIssueComments(ctx, client, cache, owner, repo, issueNumber, pagination, flags)
-				return result, nil, err
+				result, comments, err := GetIssueComments(ctx, client, cache, owner, repo, issueNumber, pagination, flags)
+				return result, comments, err
 			case "get_sub_issues":
-				result, err := GetSubIssues(ctx, client, cache, owner, repo, issueNumber, pagination, flags)
-				return result, nil, err
+				result, subIssues, err := GetSubIssues(ctx, client, cache, owner, repo, issueNumber, pagination, flags)
+				return result, subIssues, err
 			case "get_labels":
-				result, err := GetIssueLabels(ctx, gqlClient, owner, repo, issueNumber)
-				return result, nil, err
+				result, labels, err := GetIssueLabels(ctx, gqlClient, owner, repo, issueNumber)
+				return result, labels, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
 		}
 }
 
-func GetIssue(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, flags FeatureFlags) (*mcp.CallToolResult, error) {
+func GetIssue(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, flags FeatureFlags) (*mcp.CallToolResult, any, error) {
 	issue, resp, err := client.Issues.Get(ctx, owner, repo, issueNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue: %w", err)
+		return nil, nil, fmt.Errorf("failed to get issue: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil, nil
 	}
 
 	if flags.LockdownMode {
 		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
+			return nil, nil, fmt.Errorf("lockdown cache is not configured")
 		}
 		login := issue.GetUser().GetLogin()
 		if login != "" {
 			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
 			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 			}
 			if !isSafeContent {
-				return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil
+				if flags.LockdownRedact {
+					return redactedResult(issue, "title", "body")
+				}
+				return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil, nil
 			}
 		}
 	}
 
-	// Sanitize title/body on response
-	if issue != nil {
-		if issue.Title != nil {
-			issue.Title = github.Ptr(sanitize.Sanitize(*issue.Title))
-		}
-		if issue.Body != nil {
-			issue.Body = github.Ptr(sanitize.Sanitize(*issue.Body))
-		}
-	}
-
 	r, err := json.Marshal(issue)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal issue: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal issue: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), issue, nil
 }
 
-func GetIssueComments(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, flags FeatureFlags) (*mcp.CallToolResult, error) {
+func GetIssueComments(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, flags FeatureFlags) (*mcp.CallToolResult, any, error) {
 	opts := &github.IssueListCommentsOptions{
 		ListOptions: github.ListOptions{
 			Page:    pagination.Page,
@@ -382,51 +380,36 @@ func GetIssueComments(ctx context.Context, client *github.Client, cache *lockdow
 
 	comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get issue comments: %w", err)
+		return nil, nil, fmt.Errorf("failed to get issue comments: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil, nil
 	}
+	var result any = comments
 	if flags.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredComments := make([]*github.IssueComment, 0, len(comments))
-		for _, comment := range comments {
-			user := comment.User
-			if user == nil {
-				continue
-			}
-			login := user.GetLogin()
-			if login == "" {
-				continue
-			}
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if isSafeContent {
-				filteredComments = append(filteredComments, comment)
-			}
+		result, err = lockdownContent(ctx, cache, flags, comments, func(comment *github.IssueComment) (string, string, string) {
+			return comment.GetUser().GetLogin(), owner, repo
+		}, "body")
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 		}
-		comments = filteredComments
 	}
 
-	r, err := json.Marshal(comments)
+	r, err := json.Marshal(result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), result, nil
 }
 
-func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, featureFlags FeatureFlags) (*mcp.CallToolResult, error) {
+func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner string, repo string, issueNumber int, pagination PaginationParams, featureFlags FeatureFlags) (*mcp.CallToolResult, any, error) {
 	opts := &github.IssueListOptions{
 		ListOptions: github.ListOptions{
 			Page:    pagination.Page,
@@ -440,7 +423,7 @@ func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.Re
 			"failed to list sub-issues",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 
 	defer func() { _ = resp.Body.Close() }()
@@ -448,45 +431,30 @@ func GetSubIssues(ctx context.Context, client *github.Client, cache *lockdown.Re
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to list sub-issues: %s", string(body))), nil, nil
 	}
 
+	var result any = subIssues
 	if featureFlags.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredSubIssues := make([]*github.SubIssue, 0, len(subIssues))
-		for _, subIssue := range subIssues {
-			user := subIssue.User
-			if user == nil {
-				continue
-			}
-			login := user.GetLogin()
-			if login == "" {
-				continue
-			}
-			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if isSafeContent {
-				filteredSubIssues = append(filteredSubIssues, subIssue)
-			}
+		result, err = lockdownContent(ctx, cache, featureFlags, subIssues, func(subIssue *github.SubIssue) (string, string, string) {
+			return subIssue.User.GetLogin(), owner, repo
+		}, "title", "body")
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 		}
-		subIssues = filteredSubIssues
 	}
 
-	r, err := json.Marshal(subIssues)
+	r, err := json.Marshal(result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), result, nil
 }
 
-func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
+func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, issueNumber int) (*mcp.CallToolResult, *LabelsResult, error) {
 	// Get current labels on the issue using GraphQL
 	var query struct {
 		Repository struct {
@@ -511,36 +479,34 @@ func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string,
 	}
 
 	if err := client.Query(ctx, &query, vars); err != nil {
-		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to get issue labels", err), nil
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to get issue labels", err), nil, nil
 	}
 
 	// Extract label information
-	issueLabels := make([]map[string]any, len(query.Repository.Issue.Labels.Nodes))
+	response := &LabelsResult{
+		Labels:     make([]MinimalLabel, len(query.Repository.Issue.Labels.Nodes)),
+		TotalCount: int(query.Repository.Issue.Labels.TotalCount),
+	}
 	for i, label := range query.Repository.Issue.Labels.Nodes {
-		issueLabels[i] = map[string]any{
-			"id":          fmt.Sprintf("%v", label.ID),
-			"name":        string(label.Name),
-			"color":       string(label.Color),
-			"description": string(label.Description),
+		response.Labels[i] = MinimalLabel{
+			ID:          fmt.Sprintf("%v", label.ID),
+			Name:        string(label.Name),
+			Color:       string(label.Color),
+			Description: string(label.Description),
 		}
 	}
 
-	response := map[string]any{
-		"labels":     issueLabels,
-		"totalCount": int(query.Repository.Issue.Labels.TotalCount),
-	}
-
 	out, err := json.Marshal(response)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(out)), nil
+	return utils.NewToolResultText(string(out)), response, nil
 
 }
 
 // ListIssueTypes creates a tool to list defined issue types for an organization. This can be used to understand supported issue type values for creating or updating issues.
-func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.IssueType]) {
 	return mcp.Tool{
 			Name:        "list_issue_types",
 			Description: t("TOOL_LIST_ISSUE_TYPES_FOR_ORG", "List supported issue types for repository owner (organization)."),
@@ -548,7 +514,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				Title:        t("TOOL_LIST_ISSUE_TYPES_USER_TITLE", "List available issue types"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner": {
@@ -557,9 +523,10 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 					},
 				},
 				Required: []string{"owner"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[[]*github.IssueType](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.IssueType, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -588,7 +555,7 @@ func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return utils.NewToolResultErrorFromErr("failed to marshal issue types", err), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), issueTypes, nil
 		}
 }
 
@@ -899,7 +866,7 @@ func ReprioritizeSubIssue(ctx context.Context, client *github.Client, owner stri
 }
 
 // SearchIssues creates a tool to search for issues.
-func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func SearchIssues(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -949,11 +916,11 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_SEARCH_ISSUES_USER_TITLE", "Search issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithWrappedListFieldProjection(schema, "items", "number", "title"),
+			OutputSchema: ProjectedOutputSchema[*github.IssuesSearchResult](),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			result, err := searchHandler(ctx, getClient, args, "issue", "failed to search issues")
-			return result, nil, err
+			return searchHandler(ctx, getClient, cache, flags, args, "issue", "failed to search issues")
 		}
 }
 
@@ -1312,8 +1279,16 @@ func UpdateIssue(ctx context.Context, client *github.Client, gqlClient *githubv4
 	return utils.NewToolResultText(string(r)), nil
 }
 
+// IssuesPage describes the output of list_issues. In lockdown redaction mode, redacted issues are marked with
+// "redacted": true.
+type IssuesPage struct {
+	Issues     []*github.Issue `json:"issues"`
+	PageInfo   CursorPageInfo  `json:"pageInfo"`
+	TotalCount int             `json:"totalCount"`
+}
+
 // ListIssues creates a tool to list and filter repository issues
-func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListIssues(getGQLClient GetGQLClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, map[string]any]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -1363,9 +1338,10 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_ISSUES_USER_TITLE", "List issues"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithResultFormat(WithWrappedListFieldProjection(schema, "issues", "number", "title")),
+			OutputSchema: ProjectedOutputSchema[*IssuesPage](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, map[string]any, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1458,6 +1434,11 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				paginationParams.First = &defaultFirst
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getGQLClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
@@ -1517,14 +1498,25 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				totalCount = fragment.TotalCount
 			}
 
+			var issueList any = issues
+			if flags.LockdownMode {
+				issueList, err = lockdownContent(ctx, cache, flags, issues, func(issue *github.Issue) (string, string, string) {
+					return issue.GetUser().GetLogin(), owner, repo
+				}, "title", "body")
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+			}
+
 			// Create response with issues
-			response := map[string]interface{}{
-				"issues": issues,
-				"pageInfo": map[string]interface{}{
-					"hasNextPage":     pageInfo.HasNextPage,
-					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
+			// A map, as issues redacted by lockdown mode are not *github.Issue; see IssuesPage
+			response := map[string]any{
+				"issues": issueList,
+				"pageInfo": CursorPageInfo{
+					HasNextPage:     bool(pageInfo.HasNextPage),
+					HasPreviousPage: bool(pageInfo.HasPreviousPage),
+					StartCursor:     string(pageInfo.StartCursor),
+					EndCursor:       string(pageInfo.EndCursor),
 				},
 				"totalCount": totalCount,
 			}
@@ -1532,7 +1524,12 @@ func ListIssues(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal issues: %w", err)
 			}
-			return utils.NewToolResultText(string(out)), nil, nil
+			result, err := FormatListResult(out, format, "issues", IssueColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, response, nil
 		}
 }
 
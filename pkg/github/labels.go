@@ -14,8 +14,22 @@ import (
 	"github.com/shurcooL/githubv4"
 )
 
+// MinimalLabel is the output type for repository labels.
+type MinimalLabel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// LabelsResult is the output of list_label and of the get_labels method of issue_read.
+type LabelsResult struct {
+	Labels     []MinimalLabel `json:"labels"`
+	TotalCount int            `json:"totalCount"`
+}
+
 // GetLabel retrieves a specific label by name from a GitHub repository
-func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalLabel]) {
 	tool := mcp.Tool{
 		Name:        "get_label",
 		Description: t("TOOL_GET_LABEL_DESCRIPTION", "Get a specific label from a repository."),
@@ -23,7 +37,7 @@ func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc)
 			Title:        t("TOOL_GET_LABEL_TITLE", "Get a specific label from a repository."),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -40,10 +54,11 @@ func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc)
 				},
 			},
 			Required: []string{"owner", "repo", "name"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*MinimalLabel](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *MinimalLabel](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *MinimalLabel, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -89,11 +104,11 @@ func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc)
 			return utils.NewToolResultError(fmt.Sprintf("label '%s' not found in %s/%s", name, owner, repo)), nil, nil
 		}
 
-		label := map[string]any{
-			"id":          fmt.Sprintf("%v", query.Repository.Label.ID),
-			"name":        string(query.Repository.Label.Name),
-			"color":       string(query.Repository.Label.Color),
-			"description": string(query.Repository.Label.Description),
+		label := &MinimalLabel{
+			ID:          fmt.Sprintf("%v", query.Repository.Label.ID),
+			Name:        string(query.Repository.Label.Name),
+			Color:       string(query.Repository.Label.Color),
+			Description: string(query.Repository.Label.Description),
 		}
 
 		out, err := json.Marshal(label)
@@ -101,14 +116,14 @@ func GetLabel(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc)
 			return nil, nil, fmt.Errorf("failed to marshal label: %w", err)
 		}
 
-		return utils.NewToolResultText(string(out)), nil, nil
+		return utils.NewToolResultText(string(out)), label, nil
 	})
 
 	return tool, handler
 }
 
 // ListLabels lists labels from a repository
-func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *LabelsResult]) {
 	tool := mcp.Tool{
 		Name:        "list_label",
 		Description: t("TOOL_LIST_LABEL_DESCRIPTION", "List labels from a repository"),
@@ -116,7 +131,7 @@ func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			Title:        t("TOOL_LIST_LABEL_DESCRIPTION", "List labels from a repository."),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -129,10 +144,11 @@ func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		}, "labels", "name"),
+		OutputSchema: ProjectedOutputSchema[*LabelsResult](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *LabelsResult](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *LabelsResult, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -171,19 +187,19 @@ func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "Failed to list labels", err), nil, nil
 		}
 
-		labels := make([]map[string]any, len(query.Repository.Labels.Nodes))
+		labels := make([]MinimalLabel, len(query.Repository.Labels.Nodes))
 		for i, labelNode := range query.Repository.Labels.Nodes {
-			labels[i] = map[string]any{
-				"id":          fmt.Sprintf("%v", labelNode.ID),
-				"name":        string(labelNode.Name),
-				"color":       string(labelNode.Color),
-				"description": string(labelNode.Description),
+			labels[i] = MinimalLabel{
+				ID:          fmt.Sprintf("%v", labelNode.ID),
+				Name:        string(labelNode.Name),
+				Color:       string(labelNode.Color),
+				Description: string(labelNode.Description),
 			}
 		}
 
-		response := map[string]any{
-			"labels":     labels,
-			"totalCount": int(query.Repository.Labels.TotalCount),
+		response := &LabelsResult{
+			Labels:     labels,
+			TotalCount: int(query.Repository.Labels.TotalCount),
 		}
 
 		out, err := json.Marshal(response)
@@ -191,7 +207,7 @@ func ListLabels(getGQLClient GetGQLClientFn, t translations.TranslationHelperFun
 			return nil, nil, fmt.Errorf("failed to marshal labels: %w", err)
 		}
 
-		return utils.NewToolResultText(string(out)), nil, nil
+		return utils.NewToolResultText(string(out)), response, nil
 	})
 
 	return tool, handler
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RedactedContentPlaceholder replaces the user-authored text of content redacted by lockdown mode.
+const RedactedContentPlaceholder = "[redacted by lockdown mode: the author is not trusted]"
+
+// safeContent reports for each item whether its author is safe to show in lockdown mode, as decided by
+// RepoAccessCache.IsSafeContent for the repository of the item. Items without an author are not safe.
+func safeContent[T any](ctx context.Context, cache *lockdown.RepoAccessCache, items []T, author func(T) (login, owner, repo string)) ([]bool, error) {
+	if cache == nil {
+		return nil, fmt.Errorf("lockdown cache is not configured")
+	}
+
+	// Fetch the permissions of all the authors of a repository at once rather than one query per author
+	logins := make(map[string][]string)
+	for _, item := range items {
+		login, owner, repo := author(item)
+		if login != "" {
+			logins[owner+"/"+repo] = append(logins[owner+"/"+repo], login)
+		}
+	}
+	for repository, repositoryLogins := range logins {
+		owner, repo, _ := strings.Cut(repository, "/")
+		// Users that failed to be prefetched are queried one by one by IsSafeContent
+		_ = cache.Prefetch(ctx, owner, repo, repositoryLogins)
+	}
+
+	safe := make([]bool, len(items))
+	for i, item := range items {
+		login, owner, repo := author(item)
+		if login == "" {
+			continue
+		}
+		isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		safe[i] = isSafeContent
+	}
+	return safe, nil
+}
+
+// lockdownContent applies lockdown mode to a list of items. Items whose author is not safe are dropped, and the
+// safe items are returned as a []T. In redaction mode, all items are returned as a []any instead, with the
+// unsafe items redacted by redactContent.
+func lockdownContent[T any](ctx context.Context, cache *lockdown.RepoAccessCache, flags FeatureFlags, items []T, author func(T) (login, owner, repo string), fields ...string) (any, error) {
+	safe, err := safeContent(ctx, cache, items, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if !flags.LockdownRedact {
+		filtered := make([]T, 0, len(items))
+		for i, item := range items {
+			if safe[i] {
+				filtered = append(filtered, item)
+			}
+		}
+		return filtered, nil
+	}
+
+	redacted := make([]any, 0, len(items))
+	for i, item := range items {
+		if safe[i] {
+			redacted = append(redacted, item)
+			continue
+		}
+		r, err := redactContent(item, fields...)
+		if err != nil {
+			return nil, err
+		}
+		redacted = append(redacted, r)
+	}
+	return redacted, nil
+}
+
+// redactContent returns item as a JSON object in which the given user-authored fields, with dots for nested
+// fields, are replaced by a placeholder, and which is marked with "redacted": true. The other fields, such as the
+// number, state, labels, timestamps and author login, are kept so that the item can still be triaged.
+func redactContent(item any, fields ...string) (map[string]any, error) {
+	r, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(r))
+	// Keep numbers, such as IDs, as they are
+	decoder.UseNumber()
+	var redacted map[string]any
+	if err := decoder.Decode(&redacted); err != nil {
+		return nil, fmt.Errorf("failed to decode content: %w", err)
+	}
+
+	for _, field := range fields {
+		object := redacted
+		path := strings.Split(field, ".")
+		for _, key := range path[:len(path)-1] {
+			object, _ = object[key].(map[string]any)
+		}
+		if _, ok := object[path[len(path)-1]]; ok {
+			object[path[len(path)-1]] = RedactedContentPlaceholder
+		}
+	}
+	redacted["redacted"] = true
+
+	return redacted, nil
+}
+
+// repositoryFromAPIURL returns the owner and name of the repository of a REST API URL, such as the
+// repository_url of search results: https://api.github.com/repos/owner/repo.
+func repositoryFromAPIURL(apiURL string) (owner, repo string) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return "", ""
+	}
+	_, path, found := strings.Cut(u.Path, "/repos/")
+	if !found {
+		return "", ""
+	}
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	return parts[0], parts[1]
+}
+
+// redactedResult returns the result of a tool returning a single item that is redacted by lockdown mode.
+func redactedResult(item any, fields ...string) (*mcp.CallToolResult, any, error) {
+	redacted, err := redactContent(item, fields...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := json.Marshal(redacted)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return utils.NewToolResultText(string(r)), redacted, nil
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RedactContent(t *testing.T) {
+	thread := &github.Notification{
+		ID: github.Ptr("123"),
+		Subject: &github.NotificationSubject{
+			Title: github.Ptr("Untrusted title"),
+			Type:  github.Ptr("Issue"),
+		},
+	}
+
+	redacted, err := redactContent(thread, "subject.title", "subject.missing", "missing.title")
+	require.NoError(t, err)
+
+	assert.Equal(t, "123", redacted["id"])
+	assert.Equal(t, true, redacted["redacted"])
+	subject := redacted["subject"].(map[string]any)
+	assert.Equal(t, RedactedContentPlaceholder, subject["title"])
+	assert.Equal(t, "Issue", subject["type"])
+	assert.NotContains(t, subject, "missing")
+	assert.NotContains(t, redacted, "missing")
+}
+
+func Test_RedactContent_KeepsNumbers(t *testing.T) {
+	comment := &github.IssueComment{
+		ID:   github.Ptr(int64(9007199254740993)),
+		Body: github.Ptr("Untrusted comment"),
+	}
+
+	redacted, err := redactContent(comment, "body")
+	require.NoError(t, err)
+
+	r, err := json.Marshal(redacted)
+	require.NoError(t, err)
+	assert.Contains(t, string(r), `"id":9007199254740993`)
+	assert.Equal(t, RedactedContentPlaceholder, redacted["body"])
+}
+
+func Test_LockdownContent(t *testing.T) {
+	comments := []*github.IssueComment{
+		{ID: github.Ptr(int64(1)), Body: github.Ptr("Maintainer comment"), User: &github.User{Login: github.Ptr("maintainer")}},
+		{ID: github.Ptr(int64(2)), Body: github.Ptr("External comment"), User: &github.User{Login: github.Ptr("testuser")}},
+		{ID: github.Ptr(int64(3)), Body: github.Ptr("Comment without an author")},
+	}
+	author := func(comment *github.IssueComment) (string, string, string) {
+		return comment.GetUser().GetLogin(), "owner", "repo"
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		result, err := lockdownContent(context.Background(), repoAccessCache, FeatureFlags{LockdownMode: true}, comments, author, "body")
+		require.NoError(t, err)
+
+		filtered, ok := result.([]*github.IssueComment)
+		require.True(t, ok)
+		require.Len(t, filtered, 1)
+		assert.Equal(t, int64(1), filtered[0].GetID())
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		result, err := lockdownContent(context.Background(), repoAccessCache, FeatureFlags{LockdownMode: true, LockdownRedact: true}, comments, author, "body")
+		require.NoError(t, err)
+
+		items, ok := result.([]any)
+		require.True(t, ok)
+		require.Len(t, items, 3)
+		assert.Equal(t, comments[0], items[0])
+		for _, item := range items[1:] {
+			redacted := item.(map[string]any)
+			assert.Equal(t, RedactedContentPlaceholder, redacted["body"])
+			assert.Equal(t, true, redacted["redacted"])
+		}
+	})
+
+	t.Run("cache not configured", func(t *testing.T) {
+		_, err := lockdownContent(context.Background(), nil, FeatureFlags{LockdownMode: true}, comments, author, "body")
+		require.EqualError(t, err, "lockdown cache is not configured")
+	})
+}
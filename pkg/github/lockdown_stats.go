@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LockdownCacheStats creates a debug tool to get the activity counters of the repository access cache used by
+// lockdown mode.
+func LockdownCacheStats(cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, lockdown.CacheStats]) {
+	return mcp.Tool{
+			Name:        "get_lockdown_cache_stats",
+			Description: t("TOOL_GET_LOCKDOWN_CACHE_STATS_DESCRIPTION", "Get the hit, miss and eviction counts of the repository access cache used by lockdown mode, for debugging"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_LOCKDOWN_CACHE_STATS_USER_TITLE", "Get lockdown cache stats"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			},
+			OutputSchema: OutputSchema[lockdown.CacheStats](),
+		},
+		func(_ context.Context, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, lockdown.CacheStats, error) {
+			if cache == nil {
+				return nil, lockdown.CacheStats{}, fmt.Errorf("lockdown cache is not configured")
+			}
+
+			stats := cache.Stats()
+			r, err := json.Marshal(stats)
+			if err != nil {
+				return nil, lockdown.CacheStats{}, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), stats, nil
+		}
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LockdownCacheStats(t *testing.T) {
+	tool, _ := LockdownCacheStats(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_lockdown_cache_stats", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	cache := stubRepoAccessCache(githubv4.NewClient(newRepoAccessHTTPClient()), 0)
+	ctx := context.Background()
+	for range 3 {
+		_, err := cache.IsSafeContent(ctx, "maintainer", "owner", "repo")
+		require.NoError(t, err)
+	}
+
+	_, handler := LockdownCacheStats(cache, translations.NullTranslationHelper)
+	request := createMCPRequest(map[string]any{})
+	result, stats, err := handler(ctx, &request, map[string]any{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, lockdown.CacheStats{Hits: 2, Misses: 1, Entries: 1}, stats)
+
+	var returned lockdown.CacheStats
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, stats, returned)
+}
@@ -86,6 +86,34 @@ type MinimalCommit struct {
 	Committer *MinimalUser        `json:"committer,omitempty"`
 	Stats     *MinimalCommitStats `json:"stats,omitempty"`
 	Files     []MinimalCommitFile `json:"files,omitempty"`
+	// Redacted is set when lockdown mode redacted the commit message.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// CompareRefsResult is the output of compare_refs.
+type CompareRefsResult struct {
+	Base string `json:"base"`
+	Head string `json:"head"`
+	// Status is ahead, behind, diverged or identical, comparing head with base.
+	Status       string `json:"status"`
+	AheadBy      int    `json:"ahead_by"`
+	BehindBy     int    `json:"behind_by"`
+	TotalCommits int    `json:"total_commits"`
+	MergeBaseSHA string `json:"merge_base_sha,omitempty"`
+	HTMLURL      string `json:"html_url,omitempty"`
+	// Commits are the commits of the requested page, oldest first.
+	Commits []MinimalCommit     `json:"commits"`
+	Files   []MinimalCommitFile `json:"files"`
+	Stats   *MinimalCommitStats `json:"stats,omitempty"`
+	Diff    *CompareDiff        `json:"diff,omitempty"`
+}
+
+// CompareDiff is a page of the unified diff of compare_refs.
+type CompareDiff struct {
+	Text       string `json:"text"`
+	Page       int    `json:"page"`
+	TotalPages int    `json:"total_pages"`
+	TotalLines int    `json:"total_lines"`
 }
 
 // MinimalRelease is the trimmed output type for release objects.
@@ -108,6 +136,160 @@ type MinimalBranch struct {
 	Protected bool   `json:"protected"`
 }
 
+// BranchRulesResult is the output of get_branch_rules: the requirements that apply to a branch, combining its
+// classic branch protection and the rulesets that target it, and the rulesets they come from.
+type BranchRulesResult struct {
+	Branch string `json:"branch"`
+	// Protected is whether the branch has classic branch protection.
+	Protected                      bool     `json:"protected"`
+	PullRequestRequired            bool     `json:"pull_request_required"`
+	RequiredApprovingReviewCount   int      `json:"required_approving_review_count"`
+	RequireCodeOwnerReview         bool     `json:"require_code_owner_review"`
+	DismissStaleReviews            bool     `json:"dismiss_stale_reviews"`
+	RequireLastPushApproval        bool     `json:"require_last_push_approval"`
+	RequiredReviewThreadResolution bool     `json:"required_review_thread_resolution"`
+	RequiredStatusChecks           []string `json:"required_status_checks"`
+	// StrictStatusChecks is whether the branch must be up to date with the base branch before merging.
+	StrictStatusChecks    bool `json:"strict_status_checks"`
+	RequiredLinearHistory bool `json:"required_linear_history"`
+	RequiredSignatures    bool `json:"required_signatures"`
+	MergeQueueRequired    bool `json:"merge_queue_required"`
+	// AllowForcePushes and AllowDeletions are omitted when they are unknown, as classic protection could not be
+	// read and no ruleset forbids them.
+	AllowForcePushes *bool                  `json:"allow_force_pushes,omitempty"`
+	AllowDeletions   *bool                  `json:"allow_deletions,omitempty"`
+	EnforceAdmins    bool                   `json:"enforce_admins"`
+	Rulesets         []MinimalBranchRuleset `json:"rulesets"`
+	// Notes explain requirements that could not be read, such as classic protection without admin access.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// MinimalBranchRuleset is a ruleset applying to a branch, with the types of its rules.
+type MinimalBranchRuleset struct {
+	ID         int64    `json:"id"`
+	Source     string   `json:"source"`
+	SourceType string   `json:"source_type"`
+	Rules      []string `json:"rules"`
+}
+
+// RepositoryDetails is the output of get_repository: the metadata and settings of a single repository.
+type RepositoryDetails struct {
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description,omitempty"`
+	Homepage      string   `json:"homepage,omitempty"`
+	HTMLURL       string   `json:"html_url"`
+	Visibility    string   `json:"visibility"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Parent        string   `json:"parent,omitempty"`
+	IsTemplate    bool     `json:"is_template"`
+	Archived      bool     `json:"archived"`
+	DefaultBranch string   `json:"default_branch"`
+	Topics        []string `json:"topics"`
+	// Languages maps each language used in the repository to the number of bytes of code written in it.
+	Languages  map[string]int  `json:"languages,omitempty"`
+	License    *MinimalLicense `json:"license,omitempty"`
+	Stars      int             `json:"stargazers_count"`
+	Forks      int             `json:"forks_count"`
+	Watchers   int             `json:"subscribers_count"`
+	OpenIssues int             `json:"open_issues_count"`
+	CreatedAt  string          `json:"created_at,omitempty"`
+	UpdatedAt  string          `json:"updated_at,omitempty"`
+	PushedAt   string          `json:"pushed_at,omitempty"`
+
+	HasIssues      bool `json:"has_issues"`
+	HasProjects    bool `json:"has_projects"`
+	HasWiki        bool `json:"has_wiki"`
+	HasDiscussions bool `json:"has_discussions"`
+
+	AllowMergeCommit         bool `json:"allow_merge_commit"`
+	AllowSquashMerge         bool `json:"allow_squash_merge"`
+	AllowRebaseMerge         bool `json:"allow_rebase_merge"`
+	AllowAutoMerge           bool `json:"allow_auto_merge"`
+	AllowUpdateBranch        bool `json:"allow_update_branch"`
+	DeleteBranchOnMerge      bool `json:"delete_branch_on_merge"`
+	WebCommitSignoffRequired bool `json:"web_commit_signoff_required"`
+
+	// CommunityProfile is only available for public repositories.
+	CommunityProfile *MinimalCommunityProfile `json:"community_profile,omitempty"`
+}
+
+// MinimalLicense is the license of a repository.
+type MinimalLicense struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id,omitempty"`
+}
+
+// MinimalCommunityProfile is the community health of a repository: how many of the recommended community
+// files it has, and which.
+type MinimalCommunityProfile struct {
+	HealthPercentage int `json:"health_percentage"`
+	// Files maps each community file present, such as readme or contributing, to its URL.
+	Files   map[string]string `json:"files"`
+	Missing []string          `json:"missing"`
+}
+
+// FileBlameResult is the output of get_file_blame: the ranges of lines of a file, each with the commit
+// that last changed them.
+type FileBlameResult struct {
+	Path string `json:"path"`
+	Ref  string `json:"ref"`
+	// SHA is the commit the blame was computed at.
+	SHA    string       `json:"sha"`
+	Ranges []BlameRange `json:"ranges"`
+}
+
+// BlameRange is a range of lines of a file last changed by the same commit.
+type BlameRange struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+	// Age is the recency of the change, from 1 (newest) to 10 (oldest), relative to the rest of the file.
+	Age             int    `json:"age"`
+	SHA             string `json:"sha"`
+	Author          string `json:"author"`
+	AuthorEmail     string `json:"author_email,omitempty"`
+	AuthorLogin     string `json:"author_login,omitempty"`
+	Date            string `json:"date,omitempty"`
+	MessageHeadline string `json:"message_headline"`
+	// PullRequest is the number of the pull request the commit is associated with, if any.
+	PullRequest int `json:"pull_request,omitempty"`
+	// Redacted is set when lockdown mode redacted the message headline.
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// FileContentsOutput is the structured output of get_file_contents. The content of a file is returned
+// as an embedded resource, so only its metadata is included here.
+type FileContentsOutput struct {
+	Type     string              `json:"type"`
+	Path     string              `json:"path"`
+	SHA      string              `json:"sha,omitempty"`
+	URI      string              `json:"uri,omitempty"`
+	MIMEType string              `json:"mime_type,omitempty"`
+	Size     int                 `json:"size,omitempty"`
+	Entries  []FileContentsEntry `json:"entries,omitempty"`
+	// TotalLines is the number of lines of a text file, unless it was read by bytes.
+	TotalLines int `json:"total_lines,omitempty"`
+	// StartLine and EndLine are the lines returned when a text file was read by lines.
+	StartLine int `json:"start_line,omitempty"`
+	EndLine   int `json:"end_line,omitempty"`
+	// ByteOffset and TotalSize are the offset of the returned bytes and the size of the file when it was read by
+	// bytes.
+	ByteOffset int `json:"byte_offset,omitempty"`
+	TotalSize  int `json:"total_size,omitempty"`
+}
+
+// FileContentsEntry is an entry of a directory listed by get_file_contents.
+type FileContentsEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size,omitempty"`
+}
+
 // MinimalResponse represents a minimal response for all CRUD operations.
 // Success is implicit in the HTTP response status, and all other information
 // can be derived from the URL or fetched separately if needed.
@@ -116,6 +298,14 @@ type MinimalResponse struct {
 	URL string `json:"url"`
 }
 
+// CursorPageInfo is the pagination information of results listed through GraphQL cursors.
+type CursorPageInfo struct {
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+}
+
 type MinimalProject struct {
 	ID               *int64            `json:"id,omitempty"`
 	NodeID           *string           `json:"node_id,omitempty"`
@@ -171,6 +361,134 @@ func convertToMinimalUser(user *github.User) *MinimalUser {
 	}
 }
 
+// Columns of the tables that list results are rendered as in the markdown and CSV formats, for each type of item.
+var (
+	IssueColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "title", Path: "title"},
+		{Header: "state", Path: "state"},
+		{Header: "author", Path: "user.login"},
+		{Header: "labels", Path: "labels.name"},
+		{Header: "comments", Path: "comments"},
+		{Header: "updated_at", Path: "updated_at"},
+	}
+
+	PullRequestColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "title", Path: "title"},
+		{Header: "state", Path: "state"},
+		{Header: "draft", Path: "draft"},
+		{Header: "author", Path: "user.login"},
+		{Header: "head", Path: "head.ref"},
+		{Header: "base", Path: "base.ref"},
+		{Header: "updated_at", Path: "updated_at"},
+	}
+
+	MinimalCommitColumns = []Column{
+		{Header: "sha", Path: "sha"},
+		{Header: "author", Path: "author.login"},
+		{Header: "date", Path: "commit.author.date"},
+		{Header: "message", Path: "commit.message"},
+	}
+
+	MinimalBranchColumns = []Column{
+		{Header: "name", Path: "name"},
+		{Header: "sha", Path: "sha"},
+		{Header: "protected", Path: "protected"},
+	}
+
+	ReleaseColumns = []Column{
+		{Header: "tag", Path: "tag_name"},
+		{Header: "name", Path: "name"},
+		{Header: "draft", Path: "draft"},
+		{Header: "prerelease", Path: "prerelease"},
+		{Header: "author", Path: "author.login"},
+		{Header: "published_at", Path: "published_at"},
+	}
+
+	WorkflowColumns = []Column{
+		{Header: "id", Path: "id"},
+		{Header: "name", Path: "name"},
+		{Header: "path", Path: "path"},
+		{Header: "state", Path: "state"},
+	}
+
+	WorkflowRunColumns = []Column{
+		{Header: "id", Path: "id"},
+		{Header: "name", Path: "name"},
+		{Header: "run_number", Path: "run_number"},
+		{Header: "event", Path: "event"},
+		{Header: "status", Path: "status"},
+		{Header: "conclusion", Path: "conclusion"},
+		{Header: "branch", Path: "head_branch"},
+		{Header: "sha", Path: "head_sha"},
+		{Header: "created_at", Path: "created_at"},
+	}
+
+	WorkflowJobColumns = []Column{
+		{Header: "id", Path: "id"},
+		{Header: "name", Path: "name"},
+		{Header: "status", Path: "status"},
+		{Header: "conclusion", Path: "conclusion"},
+		{Header: "started_at", Path: "started_at"},
+		{Header: "completed_at", Path: "completed_at"},
+	}
+
+	DependabotAlertColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "state", Path: "state"},
+		{Header: "severity", Path: "security_advisory.severity"},
+		{Header: "package", Path: "dependency.package.name"},
+		{Header: "ecosystem", Path: "dependency.package.ecosystem"},
+		{Header: "summary", Path: "security_advisory.summary"},
+		{Header: "created_at", Path: "created_at"},
+	}
+
+	CodeScanningAlertColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "state", Path: "state"},
+		{Header: "severity", Path: "rule.security_severity_level"},
+		{Header: "rule", Path: "rule.id"},
+		{Header: "tool", Path: "tool.name"},
+		{Header: "path", Path: "most_recent_instance.location.path"},
+		{Header: "created_at", Path: "created_at"},
+	}
+
+	SecretScanningAlertColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "state", Path: "state"},
+		{Header: "secret_type", Path: "secret_type_display_name"},
+		{Header: "resolution", Path: "resolution"},
+		{Header: "created_at", Path: "created_at"},
+	}
+
+	ProjectItemColumns = []Column{
+		{Header: "id", Path: "id"},
+		{Header: "content_type", Path: "content_type"},
+		{Header: "creator", Path: "creator.login"},
+		{Header: "item_url", Path: "item_url"},
+		{Header: "updated_at", Path: "updated_at"},
+	}
+
+	NotificationColumns = []Column{
+		{Header: "id", Path: "id"},
+		{Header: "repository", Path: "repository.full_name"},
+		{Header: "type", Path: "subject.type"},
+		{Header: "title", Path: "subject.title"},
+		{Header: "reason", Path: "reason"},
+		{Header: "unread", Path: "unread"},
+		{Header: "updated_at", Path: "updated_at"},
+	}
+
+	DiscussionColumns = []Column{
+		{Header: "number", Path: "number"},
+		{Header: "title", Path: "title"},
+		{Header: "category", Path: "category.name"},
+		{Header: "author", Path: "user.login"},
+		{Header: "updated_at", Path: "updated_at"},
+	}
+)
+
 // convertToMinimalCommit converts a GitHub API RepositoryCommit to MinimalCommit
 func convertToMinimalCommit(commit *github.RepositoryCommit, includeDiffs bool) MinimalCommit {
 	minimalCommit := MinimalCommit{
@@ -258,3 +576,100 @@ func convertToMinimalBranch(branch *github.Branch) MinimalBranch {
 		Protected: branch.GetProtected(),
 	}
 }
+
+// convertToRepositoryDetails converts a GitHub API Repository to RepositoryDetails. Languages and the
+// community profile come from separate endpoints and are left for the caller to fill in.
+func convertToRepositoryDetails(repo *github.Repository) *RepositoryDetails {
+	details := &RepositoryDetails{
+		ID:                       repo.GetID(),
+		Name:                     repo.GetName(),
+		FullName:                 repo.GetFullName(),
+		Description:              repo.GetDescription(),
+		Homepage:                 repo.GetHomepage(),
+		HTMLURL:                  repo.GetHTMLURL(),
+		Visibility:               repo.GetVisibility(),
+		Private:                  repo.GetPrivate(),
+		Fork:                     repo.GetFork(),
+		Parent:                   repo.GetParent().GetFullName(),
+		IsTemplate:               repo.GetIsTemplate(),
+		Archived:                 repo.GetArchived(),
+		DefaultBranch:            repo.GetDefaultBranch(),
+		Topics:                   repo.Topics,
+		Stars:                    repo.GetStargazersCount(),
+		Forks:                    repo.GetForksCount(),
+		Watchers:                 repo.GetSubscribersCount(),
+		OpenIssues:               repo.GetOpenIssuesCount(),
+		HasIssues:                repo.GetHasIssues(),
+		HasProjects:              repo.GetHasProjects(),
+		HasWiki:                  repo.GetHasWiki(),
+		HasDiscussions:           repo.GetHasDiscussions(),
+		AllowMergeCommit:         repo.GetAllowMergeCommit(),
+		AllowSquashMerge:         repo.GetAllowSquashMerge(),
+		AllowRebaseMerge:         repo.GetAllowRebaseMerge(),
+		AllowAutoMerge:           repo.GetAllowAutoMerge(),
+		AllowUpdateBranch:        repo.GetAllowUpdateBranch(),
+		DeleteBranchOnMerge:      repo.GetDeleteBranchOnMerge(),
+		WebCommitSignoffRequired: repo.GetWebCommitSignoffRequired(),
+	}
+
+	if details.Topics == nil {
+		details.Topics = []string{}
+	}
+	if details.Visibility == "" {
+		details.Visibility = "public"
+		if details.Private {
+			details.Visibility = "private"
+		}
+	}
+	if license := repo.GetLicense(); license != nil {
+		details.License = &MinimalLicense{
+			Key:    license.GetKey(),
+			Name:   license.GetName(),
+			SPDXID: license.GetSPDXID(),
+		}
+	}
+	if repo.CreatedAt != nil {
+		details.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.UpdatedAt != nil {
+		details.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.PushedAt != nil {
+		details.PushedAt = repo.PushedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return details
+}
+
+// convertToMinimalCommunityProfile converts GitHub API community health metrics to MinimalCommunityProfile.
+func convertToMinimalCommunityProfile(metrics *github.CommunityHealthMetrics) *MinimalCommunityProfile {
+	profile := &MinimalCommunityProfile{
+		HealthPercentage: metrics.GetHealthPercentage(),
+		Files:            map[string]string{},
+		Missing:          []string{},
+	}
+
+	files := metrics.GetFiles()
+	if files == nil {
+		files = &github.CommunityHealthFiles{}
+	}
+	for _, file := range []struct {
+		name   string
+		metric *github.Metric
+	}{
+		{"readme", files.Readme},
+		{"license", files.License},
+		{"contributing", files.Contributing},
+		{"code_of_conduct", files.CodeOfConduct},
+		{"issue_template", files.IssueTemplate},
+		{"pull_request_template", files.PullRequestTemplate},
+	} {
+		if file.metric == nil {
+			profile.Missing = append(profile.Missing, file.name)
+			continue
+		}
+		profile.Files[file.name] = file.metric.GetHTMLURL()
+	}
+
+	return profile
+}
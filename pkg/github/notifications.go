@@ -3,13 +3,16 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/go-github/v79/github"
@@ -23,8 +26,12 @@ const (
 	FilterOnlyParticipating = "only_participating"
 )
 
+// notificationSubjectConcurrency is the number of notification subjects fetched at the same time to find their
+// authors in lockdown mode.
+const notificationSubjectConcurrency = 8
+
 // ListNotifications creates a tool to list notifications for the current user.
-func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListNotifications(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	return mcp.Tool{
 			Name:        "list_notifications",
 			Description: t("TOOL_LIST_NOTIFICATIONS_DESCRIPTION", "Lists all GitHub notifications for the authenticated user, including unread notifications, mentions, review requests, assignments, and updates on issues or pull requests. Use this tool whenever the user asks what to work on next, requests a summary of their GitHub activity, wants to see pending reviews, or needs to check for new updates or tasks. This tool is the primary way to discover actionable items, reminders, and outstanding work on GitHub. Always call this tool when asked what to work on next, what is pending, or what needs attention in GitHub."),
@@ -32,7 +39,7 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				Title:        t("TOOL_LIST_NOTIFICATIONS_USER_TITLE", "List notifications"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithResultFormat(WithFieldProjection(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"filter": {
@@ -57,9 +64,15 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 						Description: "Optional repository name. If provided with owner, only notifications for this repository are listed.",
 					},
 				},
-			}),
+			}))),
+			OutputSchema: ProjectedOutputSchema[[]*github.Notification](),
 		},
 		mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, err
@@ -146,13 +159,26 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				return utils.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil, nil
 			}
 
+			var notificationList any = notifications
+			if flags.LockdownMode {
+				notificationList, err = lockdownNotifications(ctx, client, cache, flags, notifications)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+			}
+
 			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
+			r, err := json.Marshal(notificationList)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, err
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "", NotificationColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, notificationList, nil
 		})
 }
 
@@ -323,7 +349,7 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 }
 
 // GetNotificationDetails creates a tool to get details for a specific notification.
-func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetNotificationDetails(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	return mcp.Tool{
 			Name:        "get_notification_details",
 			Description: t("TOOL_GET_NOTIFICATION_DETAILS_DESCRIPTION", "Get detailed information for a specific GitHub notification, always call this tool when the user asks for details about a specific notification, if you don't know the ID list notifications first."),
@@ -331,7 +357,7 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				Title:        t("TOOL_GET_NOTIFICATION_DETAILS_USER_TITLE", "Get notification details"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"notificationID": {
@@ -340,7 +366,8 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 					},
 				},
 				Required: []string{"notificationID"},
-			},
+			}),
+			OutputSchema: ProjectedOutputSchema[*github.Notification](),
 		},
 		mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			client, err := getClient(ctx)
@@ -371,15 +398,120 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				return utils.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil, nil
 			}
 
+			if flags.LockdownMode {
+				if cache == nil {
+					return nil, nil, fmt.Errorf("lockdown cache is not configured")
+				}
+				login, err := notificationSubjectAuthor(ctx, client, thread)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get notification subject", err), nil, nil
+				}
+				safe, err := safeContent(ctx, cache, []*github.Notification{thread}, func(thread *github.Notification) (string, string, string) {
+					return login, thread.GetRepository().GetOwner().GetLogin(), thread.GetRepository().GetName()
+				})
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+				if !safe[0] {
+					if flags.LockdownRedact {
+						return redactedResult(thread, "subject.title")
+					}
+					return utils.NewToolResultError("access to notification details is restricted by lockdown mode"), nil, nil
+				}
+			}
+
 			r, err := json.Marshal(thread)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, err
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), thread, nil
 		})
 }
 
+// lockdownNotifications applies lockdown mode to notifications, based on the author of their subject. As for
+// other content, notifications whose subject author is unknown are not safe.
+func lockdownNotifications(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, flags FeatureFlags, notifications []*github.Notification) (any, error) {
+	// Subjects are fetched concurrently, as each notification has its own
+	logins := make([]string, len(notifications))
+	errs := make([]error, len(notifications))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, notificationSubjectConcurrency)
+	for i, thread := range notifications {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			logins[i], errs[i] = notificationSubjectAuthor(ctx, client, thread)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to get notification subject: %w", err)
+	}
+
+	indexes := make([]int, len(notifications))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	safe, err := safeContent(ctx, cache, indexes, func(i int) (string, string, string) {
+		return logins[i], notifications[i].GetRepository().GetOwner().GetLogin(), notifications[i].GetRepository().GetName()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]any, 0, len(notifications))
+	for i, thread := range notifications {
+		switch {
+		case safe[i]:
+			filtered = append(filtered, thread)
+		case flags.LockdownRedact:
+			redacted, err := redactContent(thread, "subject.title")
+			if err != nil {
+				return nil, err
+			}
+			filtered = append(filtered, redacted)
+		}
+	}
+	return filtered, nil
+}
+
+// notificationSubjectAuthor returns the login of the author of the subject of a notification, such as an issue, a
+// pull request, a release or a commit. It returns an empty string if the author is unknown, because the subject
+// has no author or cannot be read, such as a deleted issue or a repository the token can no longer access.
+func notificationSubjectAuthor(ctx context.Context, client *github.Client, thread *github.Notification) (string, error) {
+	subjectURL := thread.GetSubject().GetURL()
+	if subjectURL == "" {
+		return "", nil
+	}
+
+	req, err := client.NewRequest(http.MethodGet, subjectURL, nil)
+	if err != nil {
+		return "", err
+	}
+	var subject struct {
+		User   *github.User `json:"user"`
+		Author *github.User `json:"author"`
+	}
+	resp, err := client.Do(ctx, req, &subject)
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+		_ = resp.Body.Close()
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if login := subject.User.GetLogin(); login != "" {
+		return login, nil
+	}
+	return subject.Author.GetLogin(), nil
+}
+
 // Enum values for ManageNotificationSubscription action
 const (
 	NotificationActionIgnore = "ignore"
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -18,7 +19,7 @@ import (
 func Test_ListNotifications(t *testing.T) {
 	// Verify tool definition and schema
 	mockClient := github.NewClient(nil)
-	tool, _ := ListNotifications(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListNotifications(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_notifications", tool.Name)
@@ -125,7 +126,7 @@ func Test_ListNotifications(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListNotifications(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ListNotifications(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 			request := createMCPRequest(tc.requestArgs)
 			result, _, err := handler(context.Background(), &request, tc.requestArgs)
 
@@ -703,7 +704,7 @@ func Test_MarkAllNotificationsRead(t *testing.T) {
 func Test_GetNotificationDetails(t *testing.T) {
 	// Verify tool definition and schema
 	mockClient := github.NewClient(nil)
-	tool, _ := GetNotificationDetails(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetNotificationDetails(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "get_notification_details", tool.Name)
@@ -757,7 +758,7 @@ func Test_GetNotificationDetails(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetNotificationDetails(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetNotificationDetails(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 			request := createMCPRequest(tc.requestArgs)
 			result, _, err := handler(context.Background(), &request, tc.requestArgs)
 
@@ -781,3 +782,155 @@ func Test_GetNotificationDetails(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListNotifications_Lockdown(t *testing.T) {
+	notification := func(id, title string, number int) *github.Notification {
+		return &github.Notification{
+			ID: github.Ptr(id),
+			Repository: &github.Repository{
+				Name:  github.Ptr("repo"),
+				Owner: &github.User{Login: github.Ptr("owner")},
+			},
+			Subject: &github.NotificationSubject{
+				Title: github.Ptr(title),
+				URL:   github.Ptr(fmt.Sprintf("https://api.github.com/repos/owner/repo/issues/%d", number)),
+				Type:  github.Ptr("Issue"),
+			},
+		}
+	}
+	notifications := []*github.Notification{
+		notification("1", "Maintainer issue", 1),
+		notification("2", "External issue", 2),
+		{ID: github.Ptr("3"), Subject: &github.NotificationSubject{Title: github.Ptr("Subject without an author")}},
+		notification("4", "Deleted issue", 4),
+	}
+	issueAuthors := map[string]string{"/repos/owner/repo/issues/1": "maintainer", "/repos/owner/repo/issues/2": "testuser"}
+	newClient := func() *github.Client {
+		return github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetNotifications, notifications),
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					author, ok := issueAuthors[r.URL.Path]
+					if !ok {
+						// A subject that cannot be read leaves its author unknown rather than failing the call
+						mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, &github.Issue{User: &github.User{Login: github.Ptr(author)}})(w, r)
+				}),
+			),
+		))
+	}
+
+	_, handler := ListNotifications(stubGetClientFn(newClient()), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+	request := createMCPRequest(map[string]any{})
+	result, _, err := handler(context.Background(), &request, map[string]any{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	// Notifications whose subject author is unknown are not safe, as for other content
+	require.Len(t, returned, 1)
+	assert.Equal(t, "1", returned[0]["id"])
+
+	// In redaction mode, the notifications of untrusted authors are kept with their subject title redacted
+	_, handler = ListNotifications(stubGetClientFn(newClient()), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true, "lockdown-redact": true}))
+	result, _, err = handler(context.Background(), &request, map[string]any{})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	returned = nil
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 4)
+	assert.Equal(t, "Maintainer issue", returned[0]["subject"].(map[string]any)["title"])
+	for _, redacted := range returned[1:] {
+		assert.Equal(t, true, redacted["redacted"])
+		assert.Equal(t, RedactedContentPlaceholder, redacted["subject"].(map[string]any)["title"])
+	}
+}
+
+func Test_GetNotificationDetails_Lockdown(t *testing.T) {
+	thread := &github.Notification{
+		ID: github.Ptr("123"),
+		Repository: &github.Repository{
+			Name:  github.Ptr("repo"),
+			Owner: &github.User{Login: github.Ptr("owner")},
+		},
+		Subject: &github.NotificationSubject{
+			Title: github.Ptr("Issue title"),
+			URL:   github.Ptr("https://api.github.com/repos/owner/repo/issues/1"),
+			Type:  github.Ptr("Issue"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		author      string
+		expectError bool
+	}{
+		{
+			name:   "author with push access",
+			author: "maintainer",
+		},
+		{
+			name:        "author lacks push access",
+			author:      "testuser",
+			expectError: true,
+		},
+		{
+			name:        "unknown author",
+			author:      "",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetNotificationsThreadsByThreadId, thread),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{Number: github.Ptr(1), User: &github.User{Login: github.Ptr(tc.author)}},
+				),
+			))
+			_, handler := GetNotificationDetails(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+			args := map[string]interface{}{"notificationID": "123"}
+			request := createMCPRequest(args)
+			result, _, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, "access to notification details is restricted by lockdown mode")
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Contains(t, getTextResult(t, result).Text, "Issue title")
+		})
+	}
+
+	t.Run("redacts subject title of untrusted author", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatch(mock.GetNotificationsThreadsByThreadId, thread),
+			mock.WithRequestMatch(
+				mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+				&github.Issue{Number: github.Ptr(1), User: &github.User{Login: github.Ptr("testuser")}},
+			),
+		))
+		_, handler := GetNotificationDetails(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true, "lockdown-redact": true}))
+
+		args := map[string]interface{}{"notificationID": "123"}
+		request := createMCPRequest(args)
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var details map[string]any
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &details))
+		assert.Equal(t, true, details["redacted"])
+		assert.Equal(t, RedactedContentPlaceholder, details["subject"].(map[string]any)["title"])
+	})
+}
@@ -24,7 +24,7 @@ const (
 	MaxProjectsPerPage       = 50
 )
 
-func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *ProjectsPage]) {
 	return mcp.Tool{
 			Name:        "list_projects",
 			Description: t("TOOL_LIST_PROJECTS_DESCRIPTION", `List Projects for a user or organization`),
@@ -32,7 +32,7 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				Title:        t("TOOL_LIST_PROJECTS_USER_TITLE", "List projects"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner_type": {
@@ -62,8 +62,9 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 					},
 				},
 				Required: []string{"owner_type", "owner"},
-			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			}, "projects", "number", "title"),
+			OutputSchema: ProjectedOutputSchema[*ProjectsPage](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *ProjectsPage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -122,9 +123,9 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				minimalProjects = append(minimalProjects, *convertToMinimalProject(project))
 			}
 
-			response := map[string]any{
-				"projects": minimalProjects,
-				"pageInfo": buildPageInfo(resp),
+			response := &ProjectsPage{
+				Projects: minimalProjects,
+				PageInfo: buildPageInfo(resp),
 			}
 
 			r, err := json.Marshal(response)
@@ -132,11 +133,11 @@ func ListProjects(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), response, nil
 		}
 }
 
-func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalProject]) {
 	return mcp.Tool{
 			Name:        "get_project",
 			Description: t("TOOL_GET_PROJECT_DESCRIPTION", "Get Project for a user or org"),
@@ -144,7 +145,7 @@ func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 				Title:        t("TOOL_GET_PROJECT_USER_TITLE", "Get project"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"project_number": {
@@ -162,8 +163,9 @@ func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 					},
 				},
 				Required: []string{"project_number", "owner_type", "owner"},
-			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			}),
+			OutputSchema: ProjectedOutputSchema[*MinimalProject](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *MinimalProject, error) {
 
 			projectNumber, err := RequiredInt(args, "project_number")
 			if err != nil {
@@ -216,11 +218,11 @@ func GetProject(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), minimalProject, nil
 		}
 }
 
-func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *ProjectFieldsPage]) {
 	return mcp.Tool{
 			Name:        "list_project_fields",
 			Description: t("TOOL_LIST_PROJECT_FIELDS_DESCRIPTION", "List Project fields for a user or org"),
@@ -228,7 +230,7 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 				Title:        t("TOOL_LIST_PROJECT_FIELDS_USER_TITLE", "List project fields"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithWrappedListFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner_type": {
@@ -258,8 +260,9 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 					},
 				},
 				Required: []string{"owner_type", "owner", "project_number"},
-			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			}, "fields", "id", "name"),
+			OutputSchema: ProjectedOutputSchema[*ProjectFieldsPage](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *ProjectFieldsPage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -307,9 +310,9 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			response := map[string]any{
-				"fields":   projectFields,
-				"pageInfo": buildPageInfo(resp),
+			response := &ProjectFieldsPage{
+				Fields:   projectFields,
+				PageInfo: buildPageInfo(resp),
 			}
 
 			r, err := json.Marshal(response)
@@ -317,11 +320,11 @@ func ListProjectFields(getClient GetClientFn, t translations.TranslationHelperFu
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), response, nil
 		}
 }
 
-func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.ProjectV2Field]) {
 	return mcp.Tool{
 			Name:        "get_project_field",
 			Description: t("TOOL_GET_PROJECT_FIELD_DESCRIPTION", "Get Project field for a user or org"),
@@ -329,7 +332,7 @@ func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc
 				Title:        t("TOOL_GET_PROJECT_FIELD_USER_TITLE", "Get project field"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner_type": {
@@ -351,8 +354,9 @@ func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc
 					},
 				},
 				Required: []string{"owner_type", "owner", "project_number", "field_id"},
-			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			}),
+			OutputSchema: ProjectedOutputSchema[*github.ProjectV2Field](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.ProjectV2Field, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -404,11 +408,11 @@ func GetProjectField(getClient GetClientFn, t translations.TranslationHelperFunc
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), projectField, nil
 		}
 }
 
-func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *ProjectItemsPage]) {
 	return mcp.Tool{
 			Name:        "list_project_items",
 			Description: t("TOOL_LIST_PROJECT_ITEMS_DESCRIPTION", `Search project items with advanced filtering`),
@@ -416,7 +420,7 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_PROJECT_ITEMS_USER_TITLE", "List project items"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: &jsonschema.Schema{
+			InputSchema: WithResultFormat(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"owner_type": {
@@ -457,8 +461,9 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 					},
 				},
 				Required: []string{"owner_type", "owner", "project_number"},
-			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			}),
+			OutputSchema: OutputSchema[*ProjectItemsPage](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *ProjectItemsPage, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -489,6 +494,11 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -525,9 +535,9 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			response := map[string]any{
-				"items":    projectItems,
-				"pageInfo": buildPageInfo(resp),
+			response := &ProjectItemsPage{
+				Items:    projectItems,
+				PageInfo: buildPageInfo(resp),
 			}
 
 			r, err := json.Marshal(response)
@@ -535,11 +545,16 @@ func ListProjectItems(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "items", ProjectItemColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, response, nil
 		}
 }
 
-func GetProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.ProjectV2Item]) {
 	return mcp.Tool{
 			Name:        "get_project_item",
 			Description: t("TOOL_GET_PROJECT_ITEM_DESCRIPTION", "Get a specific Project item for a user or org"),
@@ -577,7 +592,8 @@ func GetProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				},
 				Required: []string{"owner_type", "owner", "project_number", "item_id"},
 			},
-		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			OutputSchema: OutputSchema[*github.ProjectV2Item](),
+		}, func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.ProjectV2Item, error) {
 			owner, err := RequiredParam[string](args, "owner")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -636,7 +652,7 @@ func GetProjectItem(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), projectItem, nil
 		}
 }
 
@@ -940,6 +956,24 @@ type pageInfo struct {
 	PrevCursor      string `json:"prevCursor,omitempty"`
 }
 
+// ProjectsPage is a page of projects returned by list_projects.
+type ProjectsPage struct {
+	Projects []MinimalProject `json:"projects"`
+	PageInfo pageInfo         `json:"pageInfo"`
+}
+
+// ProjectFieldsPage is a page of project fields returned by list_project_fields.
+type ProjectFieldsPage struct {
+	Fields   []*github.ProjectV2Field `json:"fields"`
+	PageInfo pageInfo                 `json:"pageInfo"`
+}
+
+// ProjectItemsPage is a page of project items returned by list_project_items.
+type ProjectItemsPage struct {
+	Items    []*github.ProjectV2Item `json:"items"`
+	PageInfo pageInfo                `json:"pageInfo"`
+}
+
 func toNewProjectType(projType string) string {
 	switch strings.ToLower(projType) {
 	case "issue":
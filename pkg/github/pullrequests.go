@@ -15,7 +15,6 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/lockdown"
-	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 )
@@ -63,7 +62,16 @@ Possible options:
 				Title:        t("TOOL_GET_PULL_REQUEST_USER_TITLE", "Get details for a single pull request"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema: WithFieldProjection(schema),
+			OutputSchema: AlternativeOutputSchema(
+				ProjectedOutputSchema[*github.PullRequest](),
+				ProjectedOutputSchema[*PullRequestDiff](),
+				ProjectedOutputSchema[*github.CombinedStatus](),
+				ProjectedOutputSchema[[]*github.CommitFile](),
+				ProjectedOutputSchema[[]*github.PullRequestComment](),
+				ProjectedOutputSchema[[]*github.PullRequestReview](),
+				ProjectedOutputSchema[[]*github.IssueComment](),
+			),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			method, err := RequiredParam[string](args, "method")
@@ -95,87 +103,85 @@ Possible options:
 
 			switch method {
 			case "get":
-				result, err := GetPullRequest(ctx, client, cache, owner, repo, pullNumber, flags)
-				return result, nil, err
+				result, pr, err := GetPullRequest(ctx, client, cache, owner, repo, pullNumber, flags)
+				return result, pr, err
 			case "get_diff":
-				result, err := GetPullRequestDiff(ctx, client, owner, repo, pullNumber)
-				return result, nil, err
+				result, diff, err := GetPullRequestDiff(ctx, client, owner, repo, pullNumber)
+				return result, diff, err
 			case "get_status":
-				result, err := GetPullRequestStatus(ctx, client, owner, repo, pullNumber)
-				return result, nil, err
+				result, status, err := GetPullRequestStatus(ctx, client, owner, repo, pullNumber)
+				return result, status, err
 			case "get_files":
-				result, err := GetPullRequestFiles(ctx, client, owner, repo, pullNumber, pagination)
-				return result, nil, err
+				result, files, err := GetPullRequestFiles(ctx, client, owner, repo, pullNumber, pagination)
+				return result, files, err
 			case "get_review_comments":
-				result, err := GetPullRequestReviewComments(ctx, client, cache, owner, repo, pullNumber, pagination, flags)
-				return result, nil, err
+				result, comments, err := GetPullRequestReviewComments(ctx, client, cache, owner, repo, pullNumber, pagination, flags)
+				return result, comments, err
 			case "get_reviews":
-				result, err := GetPullRequestReviews(ctx, client, cache, owner, repo, pullNumber, flags)
-				return result, nil, err
+				result, reviews, err := GetPullRequestReviews(ctx, client, cache, owner, repo, pullNumber, flags)
+				return result, reviews, err
 			case "get_comments":
-				result, err := GetIssueComments(ctx, client, cache, owner, repo, pullNumber, pagination, flags)
-				return result, nil, err
+				result, comments, err := GetIssueComments(ctx, client, cache, owner, repo, pullNumber, pagination, flags)
+				return result, comments, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
 		}
 }
 
-func GetPullRequest(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, ff FeatureFlags) (*mcp.CallToolResult, error) {
+// PullRequestDiff is the structured output of the get_diff method of pull_request_read.
+type PullRequestDiff struct {
+	Diff string `json:"diff"`
+}
+
+func GetPullRequest(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, ff FeatureFlags) (*mcp.CallToolResult, any, error) {
 	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx,
 			"failed to get pull request",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
-	}
-
-	// sanitize title/body on response
-	if pr != nil {
-		if pr.Title != nil {
-			pr.Title = github.Ptr(sanitize.Sanitize(*pr.Title))
-		}
-		if pr.Body != nil {
-			pr.Body = github.Ptr(sanitize.Sanitize(*pr.Body))
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil, nil
 	}
 
 	if ff.LockdownMode {
 		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
+			return nil, nil, fmt.Errorf("lockdown cache is not configured")
 		}
 		login := pr.GetUser().GetLogin()
 		if login != "" {
 			isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
 			if err != nil {
-				return nil, fmt.Errorf("failed to check content removal: %w", err)
+				return nil, nil, fmt.Errorf("failed to check content removal: %w", err)
 			}
 
 			if !isSafeContent {
-				return utils.NewToolResultError("access to pull request is restricted by lockdown mode"), nil
+				if ff.LockdownRedact {
+					return redactedResult(pr, "title", "body")
+				}
+				return utils.NewToolResultError("access to pull request is restricted by lockdown mode"), nil, nil
 			}
 		}
 	}
 
 	r, err := json.Marshal(pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), pr, nil
 }
 
-func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, *PullRequestDiff, error) {
 	raw, resp, err := client.PullRequests.GetRaw(
 		ctx,
 		owner,
@@ -188,40 +194,40 @@ func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo
 			"failed to get pull request diff",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil, nil
 	}
 
 	defer func() { _ = resp.Body.Close() }()
 
 	// Return the raw response
-	return utils.NewToolResultText(string(raw)), nil
+	return utils.NewToolResultText(string(raw)), &PullRequestDiff{Diff: string(raw)}, nil
 }
 
-func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, *github.CombinedStatus, error) {
 	pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx,
 			"failed to get pull request",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil, nil
 	}
 
 	// Get combined status for the head SHA
@@ -231,27 +237,27 @@ func GetPullRequestStatus(ctx context.Context, client *github.Client, owner, rep
 			"failed to get combined status",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil, nil
 	}
 
 	r, err := json.Marshal(status)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), status, nil
 }
 
-func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
+func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo string, pullNumber int, pagination PaginationParams) (*mcp.CallToolResult, []*github.CommitFile, error) {
 	opts := &github.ListOptions{
 		PerPage: pagination.PerPage,
 		Page:    pagination.Page,
@@ -262,27 +268,27 @@ func GetPullRequestFiles(ctx context.Context, client *github.Client, owner, repo
 			"failed to get pull request files",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil, nil
 	}
 
 	r, err := json.Marshal(files)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), files, nil
 }
 
-func GetPullRequestReviewComments(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, pagination PaginationParams, ff FeatureFlags) (*mcp.CallToolResult, error) {
+func GetPullRequestReviewComments(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, pagination PaginationParams, ff FeatureFlags) (*mcp.CallToolResult, any, error) {
 	opts := &github.PullRequestListCommentsOptions{
 		ListOptions: github.ListOptions{
 			PerPage: pagination.PerPage,
@@ -296,92 +302,71 @@ func GetPullRequestReviewComments(ctx context.Context, client *github.Client, ca
 			"failed to get pull request review comments",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request review comments: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request review comments: %s", string(body))), nil, nil
 	}
 
+	var result any = comments
 	if ff.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredComments := make([]*github.PullRequestComment, 0, len(comments))
-		for _, comment := range comments {
-			user := comment.GetUser()
-			if user == nil {
-				continue
-			}
-			isSafeContent, err := cache.IsSafeContent(ctx, user.GetLogin(), owner, repo)
-			if err != nil {
-				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
-			}
-			if isSafeContent {
-				filteredComments = append(filteredComments, comment)
-			}
+		result, err = lockdownContent(ctx, cache, ff, comments, func(comment *github.PullRequestComment) (string, string, string) {
+			return comment.GetUser().GetLogin(), owner, repo
+		}, "body")
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 		}
-		comments = filteredComments
 	}
 
-	r, err := json.Marshal(comments)
+	r, err := json.Marshal(result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), result, nil
 }
 
-func GetPullRequestReviews(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, ff FeatureFlags) (*mcp.CallToolResult, error) {
+func GetPullRequestReviews(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, owner, repo string, pullNumber int, ff FeatureFlags) (*mcp.CallToolResult, any, error) {
 	reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx,
 			"failed to get pull request reviews",
 			resp,
 			err,
-		), nil
+		), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
 		}
-		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil, nil
 	}
 
+	var result any = reviews
 	if ff.LockdownMode {
-		if cache == nil {
-			return nil, fmt.Errorf("lockdown cache is not configured")
-		}
-		filteredReviews := make([]*github.PullRequestReview, 0, len(reviews))
-		for _, review := range reviews {
-			login := review.GetUser().GetLogin()
-			if login != "" {
-				isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-				if err != nil {
-					return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
-				}
-				if isSafeContent {
-					filteredReviews = append(filteredReviews, review)
-				}
-				reviews = filteredReviews
-			}
+		result, err = lockdownContent(ctx, cache, ff, reviews, func(review *github.PullRequestReview) (string, string, string) {
+			return review.GetUser().GetLogin(), owner, repo
+		}, "body")
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 		}
 	}
 
-	r, err := json.Marshal(reviews)
+	r, err := json.Marshal(result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), result, nil
 }
 
 // CreatePullRequest creates a tool to create a new pull request.
@@ -813,7 +798,7 @@ func UpdatePullRequest(getClient GetClientFn, getGQLClient GetGQLClientFn, t tra
 }
 
 // ListPullRequests creates a tool to list and filter repository pull requests.
-func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListPullRequests(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -860,7 +845,8 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				Title:        t("TOOL_LIST_PULL_REQUESTS_USER_TITLE", "List pull requests"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithResultFormat(WithFieldProjection(schema)),
+			OutputSchema: ProjectedOutputSchema[[]*github.PullRequest](),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			owner, err := RequiredParam[string](args, "owner")
@@ -908,6 +894,11 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			}
 
+			format, err := OptionalResultFormat(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
@@ -930,25 +921,27 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				return utils.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(bodyBytes))), nil, nil
 			}
 
-			// sanitize title/body on each PR
-			for _, pr := range prs {
-				if pr == nil {
-					continue
-				}
-				if pr.Title != nil {
-					pr.Title = github.Ptr(sanitize.Sanitize(*pr.Title))
-				}
-				if pr.Body != nil {
-					pr.Body = github.Ptr(sanitize.Sanitize(*pr.Body))
+			var prList any = prs
+			if flags.LockdownMode {
+				prList, err = lockdownContent(ctx, cache, flags, prs, func(pr *github.PullRequest) (string, string, string) {
+					return pr.GetUser().GetLogin(), owner, repo
+				}, "title", "body")
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 				}
 			}
 
-			r, err := json.Marshal(prs)
+			r, err := json.Marshal(prList)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			result, err := FormatListResult(r, format, "", PullRequestColumns)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result, prList, nil
 		}
 }
 
@@ -1058,7 +1051,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 }
 
 // SearchPullRequests creates a tool to search for pull requests.
-func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func SearchPullRequests(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -1108,11 +1101,11 @@ func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperF
 				Title:        t("TOOL_SEARCH_PULL_REQUESTS_USER_TITLE", "Search pull requests"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithWrappedListFieldProjection(schema, "items", "number", "title"),
+			OutputSchema: ProjectedOutputSchema[*github.IssuesSearchResult](),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
-			result, err := searchHandler(ctx, getClient, args, "pr", "failed to search pull requests")
-			return result, nil, err
+			return searchHandler(ctx, getClient, cache, flags, args, "pr", "failed to search pull requests")
 		}
 }
 
@@ -581,7 +581,7 @@ func Test_UpdatePullRequest_Draft(t *testing.T) {
 func Test_ListPullRequests(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListPullRequests(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "list_pull_requests", tool.Name)
@@ -675,7 +675,7 @@ func Test_ListPullRequests(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ListPullRequests(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -831,7 +831,7 @@ func Test_MergePullRequest(t *testing.T) {
 
 func Test_SearchPullRequests(t *testing.T) {
 	mockClient := github.NewClient(nil)
-	tool, _ := SearchPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := SearchPullRequests(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	assert.Equal(t, "search_pull_requests", tool.Name)
@@ -1097,7 +1097,7 @@ func Test_SearchPullRequests(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := SearchPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := SearchPullRequests(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -3127,3 +3127,57 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func Test_ListPullRequests_Lockdown(t *testing.T) {
+	mockPRs := []*github.PullRequest{
+		{Number: github.Ptr(1), Title: github.Ptr("Maintainer PR"), User: &github.User{Login: github.Ptr("maintainer")}},
+		{Number: github.Ptr(2), Title: github.Ptr("External PR"), User: &github.User{Login: github.Ptr("testuser")}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepo, mockPRs),
+	))
+	_, handler := ListPullRequests(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+	args := map[string]interface{}{"owner": "owner", "repo": "repo"}
+	request := createMCPRequest(args)
+	result, out, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	prs, ok := out.([]*github.PullRequest)
+	require.True(t, ok)
+	require.Len(t, prs, 1)
+	assert.Equal(t, 1, prs[0].GetNumber())
+}
+
+func Test_SearchPullRequests_Lockdown(t *testing.T) {
+	mockSearchResult := &github.IssuesSearchResult{
+		Total: github.Ptr(2),
+		Issues: []*github.Issue{
+			{
+				Number:        github.Ptr(1),
+				User:          &github.User{Login: github.Ptr("maintainer")},
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			},
+			{
+				Number:        github.Ptr(2),
+				User:          &github.User{Login: github.Ptr("testuser")},
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+			},
+		},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetSearchIssues, mockSearchResult),
+	))
+	_, handler := SearchPullRequests(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+	args := map[string]interface{}{"query": "fix"}
+	request := createMCPRequest(args)
+	_, out, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+
+	result, ok := out.(*github.IssuesSearchResult)
+	require.True(t, ok)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, 1, result.Issues[0].GetNumber())
+}
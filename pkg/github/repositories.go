@@ -1,24 +1,33 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/go-github/v79/github"
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
 )
 
-func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetCommit(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalCommit]) {
 	tool := mcp.Tool{
 		Name:        "get_commit",
 		Description: t("TOOL_GET_COMMITS_DESCRIPTION", "Get details for a commit from a GitHub repository"),
@@ -26,7 +35,7 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 			Title:        t("TOOL_GET_COMMITS_USER_TITLE", "Get commit details"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -48,10 +57,11 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 				},
 			},
 			Required: []string{"owner", "repo", "sha"},
-		}),
+		})),
+		OutputSchema: ProjectedOutputSchema[*MinimalCommit](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *MinimalCommit](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *MinimalCommit, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -100,22 +110,43 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 			return utils.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil, nil
 		}
 
+		// The commit message is authored by the commit author, which may not be a GitHub user
+		redacted := false
+		if flags.LockdownMode {
+			safe, err := safeContent(ctx, cache, []*github.RepositoryCommit{commit}, func(commit *github.RepositoryCommit) (string, string, string) {
+				return commit.GetAuthor().GetLogin(), owner, repo
+			})
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+			if !safe[0] {
+				if !flags.LockdownRedact {
+					return utils.NewToolResultError("access to commit is restricted by lockdown mode"), nil, nil
+				}
+				if commit.Commit != nil {
+					commit.Commit.Message = github.Ptr(RedactedContentPlaceholder)
+				}
+				redacted = true
+			}
+		}
+
 		// Convert to minimal commit
 		minimalCommit := convertToMinimalCommit(commit, includeDiff)
+		minimalCommit.Redacted = redacted
 
 		r, err := json.Marshal(minimalCommit)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), &minimalCommit, nil
 	})
 
 	return tool, handler
 }
 
 // ListCommits creates a tool to get commits of a branch in a repository.
-func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListCommits(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []MinimalCommit]) {
 	tool := mcp.Tool{
 		Name:        "list_commits",
 		Description: t("TOOL_LIST_COMMITS_DESCRIPTION", "Get list of commits of a branch in a GitHub repository. Returns at least 30 results per page by default, but can return more if specified using the perPage parameter (up to 100)."),
@@ -123,7 +154,7 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 			Title:        t("TOOL_LIST_COMMITS_USER_TITLE", "List commits"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithResultFormat(WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -144,10 +175,11 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 				},
 			},
 			Required: []string{"owner", "repo"},
-		}),
+		}))),
+		OutputSchema: ProjectedOutputSchema[[]MinimalCommit](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []MinimalCommit](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []MinimalCommit, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -182,6 +214,11 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 			},
 		}
 
+		format, err := OptionalResultFormat(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
 		client, err := getClient(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -204,10 +241,9 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 			return utils.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil, nil
 		}
 
-		// Convert to minimal commits
-		minimalCommits := make([]MinimalCommit, len(commits))
-		for i, commit := range commits {
-			minimalCommits[i] = convertToMinimalCommit(commit, false)
+		minimalCommits, err := lockdownCommits(ctx, cache, flags, commits, owner, repo)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
 		}
 
 		r, err := json.Marshal(minimalCommits)
@@ -215,14 +251,275 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		result, err := FormatListResult(r, format, "", MinimalCommitColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return result, minimalCommits, nil
+	})
+
+	return tool, handler
+}
+
+// CompareRefs creates a tool to compare two refs of a repository.
+func CompareRefs(getClient GetClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *CompareRefsResult]) {
+	tool := mcp.Tool{
+		Name:        "compare_refs",
+		Description: t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two branches, tags or commits of a GitHub repository, e.g. to find what changed since the last release. Returns how far head is ahead of and behind base, the commits and the changed files, and optionally the unified diff, by pages. Commits are paginated with page and perPage."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+			ReadOnlyHint: true,
+		},
+		InputSchema: WithFieldProjection(WithPagination(&jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"base": {
+					Type:        "string",
+					Description: "Branch, tag or commit SHA to compare from. Use 'user:branch' for a branch of a fork",
+				},
+				"head": {
+					Type:        "string",
+					Description: "Branch, tag or commit SHA to compare to. Use 'user:branch' for a branch of a fork",
+				},
+				"comparison": {
+					Type:        "string",
+					Description: "three_dot compares head with the merge base of base and head, showing the changes made on head since it diverged from base, as in pull requests. two_dot compares base and head directly",
+					Enum:        []any{"three_dot", "two_dot"},
+					Default:     json.RawMessage(`"three_dot"`),
+				},
+				"include_diff": {
+					Type:        "boolean",
+					Description: "Whether to include a page of the unified diff",
+					Default:     json.RawMessage(`false`),
+				},
+				"diff_page": {
+					Type:        "number",
+					Description: "Page of the diff to return, starting at 1",
+					Minimum:     jsonschema.Ptr(1.0),
+				},
+				"diff_per_page": {
+					Type:        "number",
+					Description: "Number of diff lines per page",
+					Default:     json.RawMessage(`500`),
+					Minimum:     jsonschema.Ptr(1.0),
+					Maximum:     jsonschema.Ptr(2000.0),
+				},
+			},
+			Required: []string{"owner", "repo", "base", "head"},
+		})),
+		OutputSchema: ProjectedOutputSchema[*CompareRefsResult](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *CompareRefsResult](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *CompareRefsResult, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		base, err := RequiredParam[string](args, "base")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		head, err := RequiredParam[string](args, "head")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		comparison, err := OptionalParam[string](args, "comparison")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		separator := "..."
+		switch comparison {
+		case "", "three_dot":
+		case "two_dot":
+			separator = ".."
+		default:
+			return utils.NewToolResultError(fmt.Sprintf("invalid comparison %q, must be three_dot or two_dot", comparison)), nil, nil
+		}
+		includeDiff, err := OptionalBoolParamWithDefault(args, "include_diff", false)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		diffPage, err := OptionalIntParamWithDefault(args, "diff_page", 1)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		diffPerPage, err := OptionalIntParamWithDefault(args, "diff_per_page", 500)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		if diffPage < 1 || diffPerPage < 1 || diffPerPage > 2000 {
+			return utils.NewToolResultError("diff_page must be at least 1 and diff_per_page between 1 and 2000"), nil, nil
+		}
+		pagination, err := OptionalPaginationParams(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		// The client only builds three-dot comparisons, so the request is built here for both
+		compareURL := fmt.Sprintf("repos/%v/%v/compare/%v%v%v", owner, repo, url.QueryEscape(base), separator, url.QueryEscape(head))
+		u := compareURL
+		query := url.Values{}
+		if pagination.Page > 0 {
+			query.Set("page", strconv.Itoa(pagination.Page))
+		}
+		if pagination.PerPage > 0 {
+			query.Set("per_page", strconv.Itoa(pagination.PerPage))
+		}
+		if len(query) > 0 {
+			u += "?" + query.Encode()
+		}
+
+		req, err := client.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		comp := new(github.CommitsComparison)
+		resp, err := client.Do(ctx, req, comp)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to compare %s%s%s", base, separator, head),
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		commits, err := lockdownCommits(ctx, cache, flags, comp.Commits, owner, repo)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+		}
+
+		result := &CompareRefsResult{
+			Base:         base,
+			Head:         head,
+			Status:       comp.GetStatus(),
+			AheadBy:      comp.GetAheadBy(),
+			BehindBy:     comp.GetBehindBy(),
+			TotalCommits: comp.GetTotalCommits(),
+			MergeBaseSHA: comp.GetMergeBaseCommit().GetSHA(),
+			HTMLURL:      comp.GetHTMLURL(),
+			Commits:      commits,
+			Files:        make([]MinimalCommitFile, 0, len(comp.Files)),
+		}
+		stats := &MinimalCommitStats{}
+		for _, file := range comp.Files {
+			result.Files = append(result.Files, MinimalCommitFile{
+				Filename:  file.GetFilename(),
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+			})
+			stats.Additions += file.GetAdditions()
+			stats.Deletions += file.GetDeletions()
+			stats.Total += file.GetChanges()
+		}
+		if len(comp.Files) > 0 {
+			result.Stats = stats
+		}
+
+		if includeDiff {
+			req, err := client.NewRequest(http.MethodGet, compareURL, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			req.Header.Set("Accept", "application/vnd.github.v3.diff")
+			var diff bytes.Buffer
+			diffResp, err := client.Do(ctx, req, &diff)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get diff of %s%s%s", base, separator, head),
+					diffResp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = diffResp.Body.Close() }()
+
+			result.Diff = paginateDiff(diff.String(), diffPage, diffPerPage)
+		}
+
+		r, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return utils.NewToolResultText(string(r)), result, nil
 	})
 
 	return tool, handler
 }
 
+// paginateDiff returns a page of perPage lines of a unified diff. Pages past the end have no text.
+func paginateDiff(diff string, page, perPage int) *CompareDiff {
+	lines := strings.SplitAfter(diff, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	start := min((page-1)*perPage, len(lines))
+	end := min(start+perPage, len(lines))
+	return &CompareDiff{
+		Text:       strings.Join(lines[start:end], ""),
+		Page:       page,
+		TotalPages: (len(lines) + perPage - 1) / perPage,
+		TotalLines: len(lines),
+	}
+}
+
+// lockdownCommits converts commits to minimal commits, filtering or redacting in lockdown mode the commits whose
+// author cannot push to the repository.
+func lockdownCommits(ctx context.Context, cache *lockdown.RepoAccessCache, flags FeatureFlags, commits []*github.RepositoryCommit, owner, repo string) ([]MinimalCommit, error) {
+	// Commit messages are authored by the commit author, which may not be a GitHub user
+	var safe []bool
+	if flags.LockdownMode {
+		var err error
+		safe, err = safeContent(ctx, cache, commits, func(commit *github.RepositoryCommit) (string, string, string) {
+			return commit.GetAuthor().GetLogin(), owner, repo
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	minimalCommits := make([]MinimalCommit, 0, len(commits))
+	for i, commit := range commits {
+		if safe != nil && !safe[i] {
+			if !flags.LockdownRedact {
+				continue
+			}
+			if commit.Commit != nil {
+				commit.Commit.Message = github.Ptr(RedactedContentPlaceholder)
+			}
+			minimalCommit := convertToMinimalCommit(commit, false)
+			minimalCommit.Redacted = true
+			minimalCommits = append(minimalCommits, minimalCommit)
+			continue
+		}
+		minimalCommits = append(minimalCommits, convertToMinimalCommit(commit, false))
+	}
+	return minimalCommits, nil
+}
+
 // ListBranches creates a tool to list branches in a GitHub repository.
-func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []MinimalBranch]) {
 	tool := mcp.Tool{
 		Name:        "list_branches",
 		Description: t("TOOL_LIST_BRANCHES_DESCRIPTION", "List branches in a GitHub repository"),
@@ -230,7 +527,7 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			Title:        t("TOOL_LIST_BRANCHES_USER_TITLE", "List branches"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithResultFormat(WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -243,10 +540,11 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				},
 			},
 			Required: []string{"owner", "repo"},
-		}),
+		}))),
+		OutputSchema: ProjectedOutputSchema[[]MinimalBranch](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []MinimalBranch](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []MinimalBranch, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -267,6 +565,11 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			},
 		}
 
+		format, err := OptionalResultFormat(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
 		client, err := getClient(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -301,7 +604,12 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		result, err := FormatListResult(r, format, "", MinimalBranchColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return result, minimalBranches, nil
 	})
 
 	return tool, handler
@@ -536,45 +844,33 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 	return tool, handler
 }
 
-// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
-func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+// GetRepository creates a tool to get the metadata and settings of a single GitHub repository.
+func GetRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *RepositoryDetails]) {
 	tool := mcp.Tool{
-		Name:        "get_file_contents",
-		Description: t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository"),
+		Name:        "get_repository",
+		Description: t("TOOL_GET_REPOSITORY_DESCRIPTION", "Get the details of a GitHub repository: description, homepage, visibility, default branch, topics, languages, license, enabled features, the merge strategies allowed for pull requests, and its community profile (which of a README, license, contributing guide, code of conduct and issue and pull request templates it has). The community profile is only available for public repositories."),
 		Annotations: &mcp.ToolAnnotations{
-			Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+			Title:        t("TOOL_GET_REPOSITORY_USER_TITLE", "Get repository details"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
 					Type:        "string",
-					Description: "Repository owner (username or organization)",
+					Description: "Repository owner",
 				},
 				"repo": {
 					Type:        "string",
 					Description: "Repository name",
 				},
-				"path": {
-					Type:        "string",
-					Description: "Path to file/directory (directories must end with a slash '/')",
-					Default:     json.RawMessage(`"/"`),
-				},
-				"ref": {
-					Type:        "string",
-					Description: "Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`",
-				},
-				"sha": {
-					Type:        "string",
-					Description: "Accepts optional commit SHA. If specified, it will be used instead of ref",
-				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*RepositoryDetails](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *RepositoryDetails](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *RepositoryDetails, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -583,78 +879,432 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
 		}
-		path, err := RequiredParam[string](args, "path")
+
+		client, err := getClient(ctx)
 		if err != nil {
-			return utils.NewToolResultError(err.Error()), nil, nil
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 		}
-		ref, err := OptionalParam[string](args, "ref")
+
+		repository, resp, err := client.Repositories.Get(ctx, owner, repo)
 		if err != nil {
-			return utils.NewToolResultError(err.Error()), nil, nil
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+				resp,
+				err,
+			), nil, nil
 		}
-		sha, err := OptionalParam[string](args, "sha")
+		defer func() { _ = resp.Body.Close() }()
+
+		result := convertToRepositoryDetails(repository)
+
+		languages, resp, err := client.Repositories.ListLanguages(ctx, owner, repo)
 		if err != nil {
-			return utils.NewToolResultError(err.Error()), nil, nil
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to list repository languages",
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+		result.Languages = languages
+		if result.Languages == nil {
+			result.Languages = map[string]int{}
 		}
 
-		client, err := getClient(ctx)
-		if err != nil {
-			return utils.NewToolResultError("failed to get GitHub client"), nil, nil
+		metrics, resp, err := client.Repositories.GetCommunityHealthMetrics(ctx, owner, repo)
+		switch {
+		case err == nil:
+			defer func() { _ = resp.Body.Close() }()
+			result.CommunityProfile = convertToMinimalCommunityProfile(metrics)
+		case resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound):
+			// The community profile is not available for private repositories
+		default:
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to get community profile",
+				resp,
+				err,
+			), nil, nil
 		}
 
-		rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+		r, err := json.Marshal(result)
 		if err != nil {
-			return utils.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil, nil
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		// If the path is (most likely) not to be a directory, we will
-		// first try to get the raw content from the GitHub raw content API.
-
-		var rawAPIResponseCode int
-		if path != "" && !strings.HasSuffix(path, "/") {
-			// First, get file info from Contents API to retrieve SHA
-			var fileSHA string
-			opts := &github.RepositoryContentGetOptions{Ref: ref}
-			fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
-			if respContents != nil {
-				defer func() { _ = respContents.Body.Close() }()
-			}
-			if err != nil {
-				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to get file SHA",
-					respContents,
-					err,
-				), nil, nil
-			}
-			if fileContent == nil || fileContent.SHA == nil {
-				return utils.NewToolResultError("file content SHA is nil, if a directory was requested, path parameters should end with a trailing slash '/'"), nil, nil
-			}
-			fileSHA = *fileContent.SHA
-
-			rawClient, err := getRawClient(ctx)
-			if err != nil {
-				return utils.NewToolResultError("failed to get GitHub raw content client"), nil, nil
-			}
-			resp, err := rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
-			if err != nil {
-				return utils.NewToolResultError("failed to get raw repository content"), nil, nil
-			}
-			defer func() {
-				_ = resp.Body.Close()
-			}()
+		return utils.NewToolResultText(string(r)), result, nil
+	})
 
-			if resp.StatusCode == http.StatusOK {
-				// If the raw content is found, return it directly
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return utils.NewToolResultError("failed to read response body"), nil, nil
-				}
-				contentType := resp.Header.Get("Content-Type")
+	return tool, handler
+}
 
-				var resourceURI string
-				switch {
-				case sha != "":
-					resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
-					if err != nil {
+// UpdateRepository creates a tool to update the description, topics, homepage, merge options and archive
+// state of a GitHub repository.
+func UpdateRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *RepositoryDetails]) {
+	tool := mcp.Tool{
+		Name:        "update_repository",
+		Description: t("TOOL_UPDATE_REPOSITORY_DESCRIPTION", "Update the settings of a GitHub repository: its description, homepage, topics, the merge strategies allowed for pull requests, and whether it is archived. Only the fields given are changed. Archiving makes the repository read-only, so it is applied after the other changes, and unarchiving before them."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:           t("TOOL_UPDATE_REPOSITORY_USER_TITLE", "Update repository settings"),
+			ReadOnlyHint:    false,
+			DestructiveHint: github.Ptr(true),
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"description": {
+					Type:        "string",
+					Description: "New repository description. An empty string clears it",
+				},
+				"homepage": {
+					Type:        "string",
+					Description: "New homepage URL. An empty string clears it",
+				},
+				"topics": {
+					Type:        "array",
+					Description: "Topics to set on the repository, replacing all existing topics. An empty array removes them all",
+					Items: &jsonschema.Schema{
+						Type: "string",
+					},
+				},
+				"allow_merge_commit": {
+					Type:        "boolean",
+					Description: "Allow merging pull requests with a merge commit",
+				},
+				"allow_squash_merge": {
+					Type:        "boolean",
+					Description: "Allow squash-merging pull requests",
+				},
+				"allow_rebase_merge": {
+					Type:        "boolean",
+					Description: "Allow rebase-merging pull requests",
+				},
+				"allow_auto_merge": {
+					Type:        "boolean",
+					Description: "Allow auto-merge to be enabled on pull requests",
+				},
+				"allow_update_branch": {
+					Type:        "boolean",
+					Description: "Always suggest updating pull request branches that are behind their base branch",
+				},
+				"delete_branch_on_merge": {
+					Type:        "boolean",
+					Description: "Automatically delete head branches after pull requests are merged",
+				},
+				"archived": {
+					Type:        "boolean",
+					Description: "Archive (true) or unarchive (false) the repository",
+				},
+			},
+			Required: []string{"owner", "repo"},
+		},
+		OutputSchema: OutputSchema[*RepositoryDetails](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *RepositoryDetails](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *RepositoryDetails, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		settings := &github.Repository{}
+		settingsChanged := false
+		for _, field := range []struct {
+			param string
+			value **string
+		}{
+			{"description", &settings.Description},
+			{"homepage", &settings.Homepage},
+		} {
+			value, ok, err := OptionalParamOK[string](args, field.param)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if ok {
+				*field.value = github.Ptr(value)
+				settingsChanged = true
+			}
+		}
+		for _, field := range []struct {
+			param string
+			value **bool
+		}{
+			{"allow_merge_commit", &settings.AllowMergeCommit},
+			{"allow_squash_merge", &settings.AllowSquashMerge},
+			{"allow_rebase_merge", &settings.AllowRebaseMerge},
+			{"allow_auto_merge", &settings.AllowAutoMerge},
+			{"allow_update_branch", &settings.AllowUpdateBranch},
+			{"delete_branch_on_merge", &settings.DeleteBranchOnMerge},
+		} {
+			value, ok, err := OptionalParamOK[bool](args, field.param)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if ok {
+				*field.value = github.Ptr(value)
+				settingsChanged = true
+			}
+		}
+
+		// Null topics are omitted topics, only an empty array removes them all
+		topicsSet := args["topics"] != nil
+		topics, err := OptionalStringArrayParam(args, "topics")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		archived, archivedSet, err := OptionalParamOK[bool](args, "archived")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		if !settingsChanged && !topicsSet && !archivedSet {
+			return utils.NewToolResultError("at least one setting to update must be provided"), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		var updated *github.Repository
+		edit := func(repository *github.Repository) *mcp.CallToolResult {
+			result, resp, err := client.Repositories.Edit(ctx, owner, repo, repository)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update repository: %s/%s", owner, repo),
+					resp,
+					err,
+				)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			updated = result
+			return nil
+		}
+
+		// An archived repository is read-only, so it is unarchived first and archived last
+		if archivedSet && !archived {
+			if errResult := edit(&github.Repository{Archived: github.Ptr(false)}); errResult != nil {
+				return errResult, nil, nil
+			}
+		}
+		if settingsChanged {
+			if errResult := edit(settings); errResult != nil {
+				return errResult, nil, nil
+			}
+		}
+		if topicsSet {
+			if topics == nil {
+				topics = []string{}
+			}
+			replaced, resp, err := client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to replace topics of repository: %s/%s", owner, repo),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if updated == nil {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get repository: %s/%s", owner, repo),
+						resp,
+						err,
+					), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				updated = repository
+			}
+			updated.Topics = replaced
+		}
+		if archivedSet && archived {
+			if errResult := edit(&github.Repository{Archived: github.Ptr(true)}); errResult != nil {
+				return errResult, nil, nil
+			}
+		}
+
+		result := convertToRepositoryDetails(updated)
+
+		r, err := json.Marshal(result)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return utils.NewToolResultText(string(r)), result, nil
+	})
+
+	return tool, handler
+}
+
+// GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
+func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *FileContentsOutput]) {
+	tool := mcp.Tool{
+		Name:        "get_file_contents",
+		Description: t("TOOL_GET_FILE_CONTENTS_DESCRIPTION", "Get the contents of a file or directory from a GitHub repository"),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_GET_FILE_CONTENTS_USER_TITLE", "Get file or directory contents"),
+			ReadOnlyHint: true,
+		},
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner (username or organization)",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"path": {
+					Type:        "string",
+					Description: "Path to file/directory (directories must end with a slash '/')",
+					Default:     json.RawMessage(`"/"`),
+				},
+				"ref": {
+					Type:        "string",
+					Description: "Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`",
+				},
+				"sha": {
+					Type:        "string",
+					Description: "Accepts optional commit SHA. If specified, it will be used instead of ref",
+				},
+				"start_line": {
+					Type:        "number",
+					Description: "First line of a text file to return, starting at 1. Use with end_line to read large files in parts, using the total_lines of the result",
+					Minimum:     jsonschema.Ptr(1.0),
+				},
+				"end_line": {
+					Type:        "number",
+					Description: "Last line of a text file to return, inclusive. Defaults to the last line",
+					Minimum:     jsonschema.Ptr(1.0),
+				},
+				"byte_offset": {
+					Type:        "number",
+					Description: "Offset of the first byte of a file to return, starting at 0. Use with byte_length to read large or binary files in parts, using the total_size of the result. Cannot be combined with start_line and end_line",
+					Minimum:     jsonschema.Ptr(0.0),
+				},
+				"byte_length": {
+					Type:        "number",
+					Description: "Number of bytes to return from byte_offset. Defaults to the rest of the file",
+					Minimum:     jsonschema.Ptr(1.0),
+				},
+			},
+			Required: []string{"owner", "repo"},
+		}),
+		OutputSchema: ProjectedOutputSchema[*FileContentsOutput](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *FileContentsOutput](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *FileContentsOutput, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		path, err := RequiredParam[string](args, "path")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		ref, err := OptionalParam[string](args, "ref")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		sha, err := OptionalParam[string](args, "sha")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		contentRange, err := optionalFileContentsRange(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		if contentRange.isSet() && (path == "" || strings.HasSuffix(path, "/")) {
+			return utils.NewToolResultError("start_line, end_line, byte_offset and byte_length only apply to files"), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return utils.NewToolResultError("failed to get GitHub client"), nil, nil
+		}
+
+		rawOpts, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil, nil
+		}
+
+		// If the path is (most likely) not to be a directory, we will
+		// first try to get the raw content from the GitHub raw content API.
+
+		var rawAPIResponseCode int
+		if path != "" && !strings.HasSuffix(path, "/") {
+			// First, get file info from Contents API to retrieve SHA
+			var fileSHA string
+			opts := &github.RepositoryContentGetOptions{Ref: ref}
+			fileContent, _, respContents, err := client.Repositories.GetContents(ctx, owner, repo, path, opts)
+			if respContents != nil {
+				defer func() { _ = respContents.Body.Close() }()
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to get file SHA",
+					respContents,
+					err,
+				), nil, nil
+			}
+			if fileContent == nil || fileContent.SHA == nil {
+				return utils.NewToolResultError("file content SHA is nil, if a directory was requested, path parameters should end with a trailing slash '/'"), nil, nil
+			}
+			fileSHA = *fileContent.SHA
+
+			rawClient, err := getRawClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError("failed to get GitHub raw content client"), nil, nil
+			}
+			var resp *http.Response
+			if contentRange.isByteRange() {
+				// Only the requested bytes are downloaded when the server honors the range
+				resp, err = rawClient.GetRawContentRange(ctx, owner, repo, path, rawOpts, int64(contentRange.byteOffset), int64(contentRange.byteLength))
+			} else {
+				resp, err = rawClient.GetRawContent(ctx, owner, repo, path, rawOpts)
+			}
+			if err != nil {
+				return utils.NewToolResultError("failed to get raw repository content"), nil, nil
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+
+			if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+				return utils.NewToolResultError(fmt.Sprintf("byte_offset %d is beyond the end of the file", contentRange.byteOffset)), nil, nil
+			}
+			if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusPartialContent && contentRange.isByteRange() {
+				// If the raw content is found, return it directly
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return utils.NewToolResultError("failed to read response body"), nil, nil
+				}
+				contentType := resp.Header.Get("Content-Type")
+
+				var resourceURI string
+				switch {
+				case sha != "":
+					resourceURI, err = url.JoinPath("repo://", owner, repo, "sha", sha, "contents", path)
+					if err != nil {
 						return nil, nil, fmt.Errorf("failed to create resource URI: %w", err)
 					}
 				case ref != "":
@@ -676,6 +1326,58 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 					strings.HasSuffix(contentType, "+json") ||
 					strings.HasSuffix(contentType, "+xml")
 
+				output := &FileContentsOutput{
+					Type:     "file",
+					Path:     path,
+					SHA:      fileSHA,
+					URI:      resourceURI,
+					MIMEType: contentType,
+				}
+
+				// part describes the returned part of the file in the result message
+				part := ""
+				switch {
+				case contentRange.isByteRange():
+					totalSize := len(body)
+					if resp.StatusCode == http.StatusPartialContent {
+						totalSize = contentRangeTotal(resp.Header.Get("Content-Range"))
+					} else {
+						// The server returned the whole file, so the range is applied here
+						if contentRange.byteOffset >= len(body) && contentRange.byteOffset > 0 {
+							return utils.NewToolResultError(fmt.Sprintf("byte_offset %d is beyond the end of the file (%d bytes)", contentRange.byteOffset, len(body))), nil, nil
+						}
+						end := len(body)
+						if contentRange.byteLength > 0 && contentRange.byteOffset+contentRange.byteLength < end {
+							end = contentRange.byteOffset + contentRange.byteLength
+						}
+						body = body[contentRange.byteOffset:end]
+					}
+					output.ByteOffset = contentRange.byteOffset
+					output.TotalSize = totalSize
+					part = fmt.Sprintf("bytes %d-%d of %d of ", contentRange.byteOffset, contentRange.byteOffset+len(body)-1, totalSize)
+				case isTextContent:
+					output.TotalLines = countLines(body)
+					if contentRange.isLineRange() {
+						startLine, endLine := contentRange.startLine, contentRange.endLine
+						if startLine == 0 {
+							startLine = 1
+						}
+						if startLine > output.TotalLines {
+							return utils.NewToolResultError(fmt.Sprintf("start_line %d is beyond the end of the file (%d lines)", startLine, output.TotalLines)), nil, nil
+						}
+						if endLine == 0 || endLine > output.TotalLines {
+							endLine = output.TotalLines
+						}
+						body = selectLines(body, startLine, endLine)
+						output.StartLine = startLine
+						output.EndLine = endLine
+						part = fmt.Sprintf("lines %d-%d of %d of ", startLine, endLine, output.TotalLines)
+					}
+				case contentRange.isLineRange():
+					return utils.NewToolResultError("start_line and end_line only apply to text files, use byte_offset and byte_length for binary files"), nil, nil
+				}
+				output.Size = len(body)
+
 				if isTextContent {
 					result := &mcp.ResourceContents{
 						URI:      resourceURI,
@@ -684,9 +1386,9 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 					}
 					// Include SHA in the result metadata
 					if fileSHA != "" {
-						return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded text file (SHA: %s)", fileSHA), result), nil, nil
+						return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded %stext file (SHA: %s)", part, fileSHA), result), output, nil
 					}
-					return utils.NewToolResultResource("successfully downloaded text file", result), nil, nil
+					return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded %stext file", part), result), output, nil
 				}
 
 				result := &mcp.ResourceContents{
@@ -696,9 +1398,9 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				}
 				// Include SHA in the result metadata
 				if fileSHA != "" {
-					return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded binary file (SHA: %s)", fileSHA), result), nil, nil
+					return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded %sbinary file (SHA: %s)", part, fileSHA), result), output, nil
 				}
-				return utils.NewToolResultResource("successfully downloaded binary file", result), nil, nil
+				return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded %sbinary file", part), result), output, nil
 			}
 			rawAPIResponseCode = resp.StatusCode
 		}
@@ -715,7 +1417,22 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 				if err != nil {
 					return utils.NewToolResultError("failed to marshal response"), nil, nil
 				}
-				return utils.NewToolResultText(string(r)), nil, nil
+
+				output := &FileContentsOutput{
+					Type:    "dir",
+					Path:    path,
+					Entries: make([]FileContentsEntry, 0, len(dirContent)),
+				}
+				for _, entry := range dirContent {
+					output.Entries = append(output.Entries, FileContentsEntry{
+						Name: entry.GetName(),
+						Path: entry.GetPath(),
+						Type: entry.GetType(),
+						SHA:  entry.GetSHA(),
+						Size: entry.GetSize(),
+					})
+				}
+				return utils.NewToolResultText(string(r)), output, nil
 			}
 		}
 
@@ -754,6 +1471,318 @@ func GetFileContents(getClient GetClientFn, getRawClient raw.GetRawClientFn, t t
 	return tool, handler
 }
 
+// fileContentsRange is the part of a file requested from get_file_contents, by lines or by bytes.
+type fileContentsRange struct {
+	startLine  int
+	endLine    int
+	byteOffset int
+	byteLength int
+}
+
+func optionalFileContentsRange(args map[string]any) (fileContentsRange, error) {
+	var r fileContentsRange
+	var err error
+	if r.startLine, err = OptionalIntParam(args, "start_line"); err != nil {
+		return r, err
+	}
+	if r.endLine, err = OptionalIntParam(args, "end_line"); err != nil {
+		return r, err
+	}
+	if r.byteOffset, err = OptionalIntParam(args, "byte_offset"); err != nil {
+		return r, err
+	}
+	if r.byteLength, err = OptionalIntParam(args, "byte_length"); err != nil {
+		return r, err
+	}
+
+	switch {
+	case r.startLine < 0 || r.endLine < 0 || r.byteOffset < 0 || r.byteLength < 0:
+		return r, fmt.Errorf("start_line, end_line, byte_offset and byte_length must not be negative")
+	case r.isLineRange() && r.isByteRange():
+		return r, fmt.Errorf("start_line and end_line cannot be combined with byte_offset and byte_length")
+	case r.endLine > 0 && r.startLine > r.endLine:
+		return r, fmt.Errorf("start_line %d is after end_line %d", r.startLine, r.endLine)
+	}
+	return r, nil
+}
+
+func (r fileContentsRange) isLineRange() bool {
+	return r.startLine > 0 || r.endLine > 0
+}
+
+func (r fileContentsRange) isByteRange() bool {
+	return r.byteOffset > 0 || r.byteLength > 0
+}
+
+func (r fileContentsRange) isSet() bool {
+	return r.isLineRange() || r.isByteRange()
+}
+
+// countLines returns the number of lines of text, counting a last line without a line break.
+func countLines(text []byte) int {
+	if len(text) == 0 {
+		return 0
+	}
+	lines := bytes.Count(text, []byte("\n"))
+	if text[len(text)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// selectLines returns the lines of text from start to end, inclusive and starting at 1, with their line breaks.
+// start must not exceed the number of lines.
+func selectLines(text []byte, start, end int) []byte {
+	offset := 0
+	for line := 1; line < start; line++ {
+		offset += bytes.IndexByte(text[offset:], '\n') + 1
+	}
+	stop := offset
+	for line := start; line <= end && stop < len(text); line++ {
+		i := bytes.IndexByte(text[stop:], '\n')
+		if i == -1 {
+			stop = len(text)
+			break
+		}
+		stop += i + 1
+	}
+	return text[offset:stop]
+}
+
+// contentRangeTotal returns the total size of a Content-Range header such as "bytes 0-99/1234", or 0 if unknown.
+func contentRangeTotal(contentRange string) int {
+	_, total, ok := strings.Cut(contentRange, "/")
+	if !ok {
+		return 0
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(total))
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// BlameRangeFragment is a range of lines in the blame of a file, with the commit that last changed them.
+type BlameRangeFragment struct {
+	StartingLine githubv4.Int
+	EndingLine   githubv4.Int
+	Age          githubv4.Int
+	Commit       struct {
+		OID             githubv4.GitObjectID `graphql:"oid"`
+		MessageHeadline githubv4.String
+		Author          struct {
+			Name  githubv4.String
+			Email githubv4.String
+			Date  githubv4.GitTimestamp
+			User  struct {
+				Login githubv4.String
+			}
+		}
+		AssociatedPullRequests struct {
+			Nodes []struct {
+				Number githubv4.Int
+			}
+		} `graphql:"associatedPullRequests(first: 1)"`
+	}
+}
+
+// GetFileBlameQuery is the query structure for fetching the blame of a file at a commit.
+type GetFileBlameQuery struct {
+	Repository struct {
+		Object struct {
+			Commit struct {
+				OID   githubv4.GitObjectID `graphql:"oid"`
+				Blame struct {
+					Ranges []BlameRangeFragment
+				} `graphql:"blame(path: $path)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(expression: $ref)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// GetFileBlame creates a tool to get the blame of a file in a GitHub repository.
+func GetFileBlame(getGQLClient GetGQLClientFn, cache *lockdown.RepoAccessCache, t translations.TranslationHelperFunc, flags FeatureFlags) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *FileBlameResult]) {
+	return mcp.Tool{
+			Name:        "get_file_blame",
+			Description: t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: the ranges of its lines, each with the commit that last changed them, its author, date and message headline, and the pull request it came from. Use start_line and end_line to blame only part of a large file."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithFieldProjection(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"path": {
+						Type:        "string",
+						Description: "Path to the file",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Branch name, tag name or commit SHA to blame the file at. Defaults to the head of the default branch",
+					},
+					"start_line": {
+						Type:        "number",
+						Description: "First line to blame, starting at 1",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"end_line": {
+						Type:        "number",
+						Description: "Last line to blame, inclusive. Defaults to the last line",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+				},
+				Required: []string{"owner", "repo", "path"},
+			}),
+			OutputSchema: ProjectedOutputSchema[*FileBlameResult](),
+		},
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *FileBlameResult, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			path, err := RequiredParam[string](args, "path")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			startLine, err := OptionalIntParam(args, "start_line")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			endLine, err := OptionalIntParam(args, "end_line")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			switch {
+			case startLine < 0 || endLine < 0:
+				return utils.NewToolResultError("start_line and end_line must not be negative"), nil, nil
+			case endLine > 0 && startLine > endLine:
+				return utils.NewToolResultError(fmt.Sprintf("start_line %d is after end_line %d", startLine, endLine)), nil, nil
+			}
+
+			// Ranges are clipped to the requested lines, so missing bounds cover the whole file
+			if startLine == 0 {
+				startLine = 1
+			}
+			if endLine == 0 {
+				endLine = math.MaxInt
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			vars := map[string]interface{}{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"ref":   githubv4.String(ref),
+				"path":  githubv4.String(strings.TrimPrefix(path, "/")),
+			}
+
+			var query GetFileBlameQuery
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			commit := query.Repository.Object.Commit
+			if commit.OID == "" {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get file blame: ref %s does not point to a commit", ref)), nil, nil
+			}
+
+			result := &FileBlameResult{
+				Path:   path,
+				Ref:    ref,
+				SHA:    string(commit.OID),
+				Ranges: []BlameRange{},
+			}
+			for _, fragment := range commit.Blame.Ranges {
+				first, last := int(fragment.StartingLine), int(fragment.EndingLine)
+				if last < startLine || first > endLine {
+					continue
+				}
+				blameRange := BlameRange{
+					StartLine:       max(first, startLine),
+					EndLine:         min(last, endLine),
+					Age:             int(fragment.Age),
+					SHA:             string(fragment.Commit.OID),
+					Author:          string(fragment.Commit.Author.Name),
+					AuthorEmail:     string(fragment.Commit.Author.Email),
+					AuthorLogin:     string(fragment.Commit.Author.User.Login),
+					MessageHeadline: string(fragment.Commit.MessageHeadline),
+				}
+				if !fragment.Commit.Author.Date.IsZero() {
+					blameRange.Date = fragment.Commit.Author.Date.Format("2006-01-02T15:04:05Z07:00")
+				}
+				if nodes := fragment.Commit.AssociatedPullRequests.Nodes; len(nodes) > 0 {
+					blameRange.PullRequest = int(nodes[0].Number)
+				}
+				result.Ranges = append(result.Ranges, blameRange)
+			}
+
+			if len(result.Ranges) == 0 && startLine > 1 {
+				return utils.NewToolResultError(fmt.Sprintf("start_line %d is beyond the end of the file", startLine)), nil, nil
+			}
+
+			if flags.LockdownMode {
+				result.Ranges, err = lockdownBlameRanges(ctx, cache, flags, result.Ranges, owner, repo)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+			}
+
+			out, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(out)), result, nil
+		}
+}
+
+// lockdownBlameRanges filters, or redacts the message headline of, the blame ranges whose commit author cannot
+// push to the repository, as lockdownCommits does for commits.
+func lockdownBlameRanges(ctx context.Context, cache *lockdown.RepoAccessCache, flags FeatureFlags, ranges []BlameRange, owner, repo string) ([]BlameRange, error) {
+	safe, err := safeContent(ctx, cache, ranges, func(blameRange BlameRange) (string, string, string) {
+		return blameRange.AuthorLogin, owner, repo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]BlameRange, 0, len(ranges))
+	for i, blameRange := range ranges {
+		if !safe[i] {
+			if !flags.LockdownRedact {
+				continue
+			}
+			blameRange.MessageHeadline = RedactedContentPlaceholder
+			blameRange.Redacted = true
+		}
+		filtered = append(filtered, blameRange)
+	}
+	return filtered, nil
+}
+
 // ForkRepository creates a tool to fork a repository.
 func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	tool := mcp.Tool{
@@ -942,97 +1971,360 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 			return utils.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil, nil
 		}
 
-		// Create a tree entry for the file deletion by setting SHA to nil
-		treeEntries := []*github.TreeEntry{
-			{
-				Path: github.Ptr(path),
-				Mode: github.Ptr("100644"), // Regular file mode
-				Type: github.Ptr("blob"),
-				SHA:  nil, // Setting SHA to nil deletes the file
-			},
+		// Create a tree entry for the file deletion by setting SHA to nil
+		treeEntries := []*github.TreeEntry{
+			{
+				Path: github.Ptr(path),
+				Mode: github.Ptr("100644"), // Regular file mode
+				Type: github.Ptr("blob"),
+				SHA:  nil, // Setting SHA to nil deletes the file
+			},
+		}
+
+		// Create a new tree with the deletion
+		newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to create tree",
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusCreated {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			return utils.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil, nil
+		}
+
+		// Create a new commit with the new tree
+		commit := github.Commit{
+			Message: github.Ptr(message),
+			Tree:    newTree,
+			Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+		}
+		newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to create commit",
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusCreated {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			return utils.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil, nil
+		}
+
+		// Update the branch reference to point to the new commit
+		ref.Object.SHA = newCommit.SHA
+		_, resp, err = client.Git.UpdateRef(ctx, owner, repo, *ref.Ref, github.UpdateRef{
+			SHA:   *newCommit.SHA,
+			Force: github.Ptr(false),
+		})
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				"failed to update reference",
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+			}
+			return utils.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil, nil
+		}
+
+		// Create a response similar to what the DeleteFile API would return
+		response := map[string]interface{}{
+			"commit":  newCommit,
+			"content": nil,
+		}
+
+		r, err := json.Marshal(response)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return utils.NewToolResultText(string(r)), nil, nil
+	})
+
+	return tool, handler
+}
+
+// GetBranchRules creates a tool to get the effective protection rules of a branch.
+func GetBranchRules(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *BranchRulesResult]) {
+	tool := mcp.Tool{
+		Name:        "get_branch_rules",
+		Description: t("TOOL_GET_BRANCH_RULES_DESCRIPTION", "Get the rules that apply to pushes and merges to a branch of a GitHub repository, combining its classic branch protection and the rulesets that target it: required reviews, required status checks, linear history, signed commits, and whether force pushes and deletions are allowed. Use it to find out why a push was rejected, or what a pull request needs before it can be merged."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_GET_BRANCH_RULES_USER_TITLE", "Get branch protection and rules"),
+			ReadOnlyHint: true,
+		},
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Branch name",
+				},
+			},
+			Required: []string{"owner", "repo", "branch"},
+		}),
+		OutputSchema: ProjectedOutputSchema[*BranchRulesResult](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *BranchRulesResult](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *BranchRulesResult, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		branch, err := RequiredParam[string](args, "branch")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 		}
 
-		// Create a new tree with the deletion
-		newTree, resp, err := client.Git.CreateTree(ctx, owner, repo, *baseCommit.Tree.SHA, treeEntries)
+		ghBranch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, branch, 1)
 		if err != nil {
 			return ghErrors.NewGitHubAPIErrorResponse(ctx,
-				"failed to create tree",
+				fmt.Sprintf("failed to get branch: %s", branch),
 				resp,
 				err,
 			), nil, nil
 		}
 		defer func() { _ = resp.Body.Close() }()
 
-		if resp.StatusCode != http.StatusCreated {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			return utils.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil, nil
+		result := &BranchRulesResult{
+			Branch:               ghBranch.GetName(),
+			Protected:            ghBranch.GetProtected(),
+			RequiredStatusChecks: []string{},
+			AllowForcePushes:     github.Ptr(true),
+			AllowDeletions:       github.Ptr(true),
+			Rulesets:             []MinimalBranchRuleset{},
 		}
 
-		// Create a new commit with the new tree
-		commit := github.Commit{
-			Message: github.Ptr(message),
-			Tree:    newTree,
-			Parents: []*github.Commit{{SHA: baseCommit.SHA}},
+		if result.Protected {
+			protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+			switch {
+			case err == nil:
+				defer func() { _ = resp.Body.Close() }()
+				applyBranchProtection(result, protection)
+			case resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound):
+				// Reading classic protection requires admin access, but its status checks are part of the branch
+				result.Notes = append(result.Notes, "classic branch protection could not be read, which requires admin access to the repository; only its required status checks are included, and whether it allows force pushes and deletions is unknown")
+				result.AllowForcePushes = nil
+				result.AllowDeletions = nil
+				applyBranchProtection(result, &github.Protection{RequiredStatusChecks: ghBranch.GetProtection().GetRequiredStatusChecks()})
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get branch protection: %s", branch),
+					resp,
+					err,
+				), nil, nil
+			}
 		}
-		newCommit, resp, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+
+		rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, branch, &github.ListOptions{PerPage: 100})
 		if err != nil {
 			return ghErrors.NewGitHubAPIErrorResponse(ctx,
-				"failed to create commit",
+				fmt.Sprintf("failed to get rules for branch: %s", branch),
 				resp,
 				err,
 			), nil, nil
 		}
 		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode != http.StatusCreated {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
-			}
-			return utils.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil, nil
+		if rules != nil {
+			applyBranchRules(result, rules)
 		}
 
-		// Update the branch reference to point to the new commit
-		ref.Object.SHA = newCommit.SHA
-		_, resp, err = client.Git.UpdateRef(ctx, owner, repo, *ref.Ref, github.UpdateRef{
-			SHA:   *newCommit.SHA,
-			Force: github.Ptr(false),
-		})
+		r, err := json.Marshal(result)
 		if err != nil {
-			return ghErrors.NewGitHubAPIErrorResponse(ctx,
-				"failed to update reference",
-				resp,
-				err,
-			), nil, nil
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
-		defer func() { _ = resp.Body.Close() }()
 
-		if resp.StatusCode != http.StatusOK {
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		return utils.NewToolResultText(string(r)), result, nil
+	})
+
+	return tool, handler
+}
+
+// applyBranchProtection adds the requirements of classic branch protection to result.
+func applyBranchProtection(result *BranchRulesResult, protection *github.Protection) {
+	if reviews := protection.GetRequiredPullRequestReviews(); reviews != nil {
+		result.PullRequestRequired = true
+		result.RequiredApprovingReviewCount = max(result.RequiredApprovingReviewCount, reviews.RequiredApprovingReviewCount)
+		result.RequireCodeOwnerReview = result.RequireCodeOwnerReview || reviews.RequireCodeOwnerReviews
+		result.DismissStaleReviews = result.DismissStaleReviews || reviews.DismissStaleReviews
+		result.RequireLastPushApproval = result.RequireLastPushApproval || reviews.RequireLastPushApproval
+	}
+	if checks := protection.GetRequiredStatusChecks(); checks != nil {
+		result.StrictStatusChecks = result.StrictStatusChecks || checks.Strict
+		if checks.Checks != nil {
+			for _, check := range *checks.Checks {
+				addRequiredStatusCheck(result, check.Context)
+			}
+		}
+		if checks.Contexts != nil {
+			for _, checkContext := range *checks.Contexts {
+				addRequiredStatusCheck(result, checkContext)
 			}
-			return utils.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil, nil
 		}
+	}
+	if protection.RequireLinearHistory != nil && protection.RequireLinearHistory.Enabled {
+		result.RequiredLinearHistory = true
+	}
+	if protection.GetRequiredSignatures().GetEnabled() {
+		result.RequiredSignatures = true
+	}
+	if protection.RequiredConversationResolution != nil && protection.RequiredConversationResolution.Enabled {
+		result.RequiredReviewThreadResolution = true
+	}
+	if protection.EnforceAdmins != nil && protection.EnforceAdmins.Enabled {
+		result.EnforceAdmins = true
+	}
+	if protection.AllowForcePushes != nil && !protection.AllowForcePushes.Enabled {
+		result.AllowForcePushes = github.Ptr(false)
+	}
+	if protection.AllowDeletions != nil && !protection.AllowDeletions.Enabled {
+		result.AllowDeletions = github.Ptr(false)
+	}
+}
 
-		// Create a response similar to what the DeleteFile API would return
-		response := map[string]interface{}{
-			"commit":  newCommit,
-			"content": nil,
+// applyBranchRules adds the requirements of the ruleset rules that apply to a branch to result. Rules of all
+// rulesets apply, so the most restrictive setting wins.
+func applyBranchRules(result *BranchRulesResult, rules *github.BranchRules) {
+	rulesets := make(map[int64]*MinimalBranchRuleset)
+	var order []int64
+	add := func(ruleType string, metadata github.BranchRuleMetadata) {
+		ruleset, ok := rulesets[metadata.RulesetID]
+		if !ok {
+			ruleset = &MinimalBranchRuleset{
+				ID:         metadata.RulesetID,
+				Source:     metadata.RulesetSource,
+				SourceType: string(metadata.RulesetSourceType),
+			}
+			rulesets[metadata.RulesetID] = ruleset
+			order = append(order, metadata.RulesetID)
+		}
+		if !slices.Contains(ruleset.Rules, ruleType) {
+			ruleset.Rules = append(ruleset.Rules, ruleType)
 		}
+	}
 
-		r, err := json.Marshal(response)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	for _, rule := range rules.PullRequest {
+		add("pull_request", rule.BranchRuleMetadata)
+		result.PullRequestRequired = true
+		result.RequiredApprovingReviewCount = max(result.RequiredApprovingReviewCount, rule.Parameters.RequiredApprovingReviewCount)
+		result.RequireCodeOwnerReview = result.RequireCodeOwnerReview || rule.Parameters.RequireCodeOwnerReview
+		result.DismissStaleReviews = result.DismissStaleReviews || rule.Parameters.DismissStaleReviewsOnPush
+		result.RequireLastPushApproval = result.RequireLastPushApproval || rule.Parameters.RequireLastPushApproval
+		result.RequiredReviewThreadResolution = result.RequiredReviewThreadResolution || rule.Parameters.RequiredReviewThreadResolution
+	}
+	for _, rule := range rules.RequiredStatusChecks {
+		add("required_status_checks", rule.BranchRuleMetadata)
+		result.StrictStatusChecks = result.StrictStatusChecks || rule.Parameters.StrictRequiredStatusChecksPolicy
+		for _, check := range rule.Parameters.RequiredStatusChecks {
+			addRequiredStatusCheck(result, check.Context)
 		}
+	}
+	for _, rule := range rules.RequiredLinearHistory {
+		add("required_linear_history", *rule)
+		result.RequiredLinearHistory = true
+	}
+	for _, rule := range rules.RequiredSignatures {
+		add("required_signatures", *rule)
+		result.RequiredSignatures = true
+	}
+	for _, rule := range rules.MergeQueue {
+		add("merge_queue", rule.BranchRuleMetadata)
+		result.MergeQueueRequired = true
+	}
+	for _, rule := range rules.NonFastForward {
+		add("non_fast_forward", *rule)
+		result.AllowForcePushes = github.Ptr(false)
+	}
+	for _, rule := range rules.Deletion {
+		add("deletion", *rule)
+		result.AllowDeletions = github.Ptr(false)
+	}
+	for _, rule := range rules.Update {
+		add("update", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.Creation {
+		add("creation", *rule)
+	}
+	for _, rule := range rules.RequiredDeployments {
+		add("required_deployments", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.CommitMessagePattern {
+		add("commit_message_pattern", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.CommitAuthorEmailPattern {
+		add("commit_author_email_pattern", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.CommitterEmailPattern {
+		add("committer_email_pattern", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.BranchNamePattern {
+		add("branch_name_pattern", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.FilePathRestriction {
+		add("file_path_restriction", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.MaxFilePathLength {
+		add("max_file_path_length", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.FileExtensionRestriction {
+		add("file_extension_restriction", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.MaxFileSize {
+		add("max_file_size", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.Workflows {
+		add("workflows", rule.BranchRuleMetadata)
+	}
+	for _, rule := range rules.CodeScanning {
+		add("code_scanning", rule.BranchRuleMetadata)
+	}
 
-		return utils.NewToolResultText(string(r)), nil, nil
-	})
+	for _, id := range order {
+		result.Rulesets = append(result.Rulesets, *rulesets[id])
+	}
+}
 
-	return tool, handler
+func addRequiredStatusCheck(result *BranchRulesResult, checkContext string) {
+	if !slices.Contains(result.RequiredStatusChecks, checkContext) {
+		result.RequiredStatusChecks = append(result.RequiredStatusChecks, checkContext)
+	}
 }
 
 // CreateBranch creates a tool to create a new branch.
@@ -1147,14 +2439,159 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 	return tool, handler
 }
 
+// DeleteBranch creates a tool to delete a branch.
+func DeleteBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+	tool := mcp.Tool{
+		Name:        "delete_branch",
+		Description: t("TOOL_DELETE_BRANCH_DESCRIPTION", "Delete a branch in a GitHub repository. GitHub rejects the deletion of the default branch, and of a branch whose protection rules or rulesets do not allow deletions; get_branch_rules tells which rules apply to a branch."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:           t("TOOL_DELETE_BRANCH_USER_TITLE", "Delete branch"),
+			ReadOnlyHint:    false,
+			DestructiveHint: github.Ptr(true),
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Name of the branch to delete",
+				},
+			},
+			Required: []string{"owner", "repo", "branch"},
+		},
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		branch, err := RequiredParam[string](args, "branch")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		resp, err := client.Git.DeleteRef(ctx, owner, repo, "refs/heads/"+branch)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to delete branch: %s", branch),
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		return utils.NewToolResultText(fmt.Sprintf("branch %s deleted successfully", branch)), nil, nil
+	})
+
+	return tool, handler
+}
+
+// RenameBranch creates a tool to rename a branch.
+func RenameBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalBranch]) {
+	tool := mcp.Tool{
+		Name:        "rename_branch",
+		Description: t("TOOL_RENAME_BRANCH_DESCRIPTION", "Rename a branch in a GitHub repository. Open pull requests, branch protection and the default branch setting follow the renamed branch."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:        t("TOOL_RENAME_BRANCH_USER_TITLE", "Rename branch"),
+			ReadOnlyHint: false,
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"branch": {
+					Type:        "string",
+					Description: "Current name of the branch",
+				},
+				"new_name": {
+					Type:        "string",
+					Description: "New name of the branch",
+				},
+			},
+			Required: []string{"owner", "repo", "branch", "new_name"},
+		},
+		OutputSchema: OutputSchema[*MinimalBranch](),
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, *MinimalBranch](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *MinimalBranch, error) {
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		branch, err := RequiredParam[string](args, "branch")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		newName, err := RequiredParam[string](args, "new_name")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		renamed, resp, err := client.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx,
+				fmt.Sprintf("failed to rename branch: %s", branch),
+				resp,
+				err,
+			), nil, nil
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		minimalBranch := convertToMinimalBranch(renamed)
+		r, err := json.Marshal(minimalBranch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+
+		return utils.NewToolResultText(string(r)), &minimalBranch, nil
+	})
+
+	return tool, handler
+}
+
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
 	tool := mcp.Tool{
 		Name:        "push_files",
-		Description: t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit"),
+		Description: t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit. Files can be created, updated, deleted or renamed, and binary files pushed as base64."),
 		Annotations: &mcp.ToolAnnotations{
-			Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
-			ReadOnlyHint: false,
+			Title:           t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
+			ReadOnlyHint:    false,
+			DestructiveHint: github.Ptr(true),
 		},
 		InputSchema: &jsonschema.Schema{
 			Type: "object",
@@ -1173,26 +2610,53 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 				},
 				"files": {
 					Type:        "array",
-					Description: "Array of file objects to push, each object with path (string) and content (string)",
+					Description: "Array of file objects to push, each object with path (string) and content (string), and optionally an operation",
 					Items: &jsonschema.Schema{
 						Type: "object",
 						Properties: map[string]*jsonschema.Schema{
+							"operation": {
+								Type: "string",
+								Description: `Operation on the file. Options are:
+- 'upsert' - creates or updates the file at path with content. This is the default.
+- 'delete' - deletes the file at path.
+- 'rename' - moves the file at from_path to path, replacing its content if content is given.
+`,
+								Enum: []any{"upsert", "delete", "rename"},
+							},
 							"path": {
 								Type:        "string",
 								Description: "path to the file",
 							},
+							"from_path": {
+								Type:        "string",
+								Description: "path the file is moved from, for 'rename'",
+							},
 							"content": {
 								Type:        "string",
-								Description: "file content",
+								Description: "file content, required for 'upsert'",
+							},
+							"encoding": {
+								Type:        "string",
+								Description: "encoding of content: 'text' by default, or 'base64' for binary files",
+								Enum:        []any{"text", "base64"},
+							},
+							"mode": {
+								Type:        "string",
+								Description: "file mode: '100644' for a regular file, '100755' for an executable or '120000' for a symlink. Defaults to '100644', or to the mode of a renamed file",
+								Enum:        []any{"100644", "100755", "120000"},
 							},
 						},
-						Required: []string{"path", "content"},
+						Required: []string{"path"},
 					},
 				},
 				"message": {
 					Type:        "string",
 					Description: "Commit message",
 				},
+				"expected_head_sha": {
+					Type:        "string",
+					Description: "SHA the branch is expected to point at. The push fails if the branch has moved, instead of committing on top of changes that were not seen",
+				},
 			},
 			Required: []string{"owner", "repo", "branch", "files", "message"},
 		},
@@ -1216,11 +2680,28 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 			return utils.NewToolResultError(err.Error()), nil, nil
 		}
 
+		expectedHeadSHA, err := OptionalParam[string](args, "expected_head_sha")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
 		// Parse files parameter - this should be an array of objects with path and content
 		filesObj, ok := args["files"].([]interface{})
 		if !ok {
 			return utils.NewToolResultError("files parameter must be an array of objects with path and content"), nil, nil
 		}
+		files := make([]pushFile, 0, len(filesObj))
+		for _, file := range filesObj {
+			fileMap, ok := file.(map[string]interface{})
+			if !ok {
+				return utils.NewToolResultError("each file must be an object with path and content"), nil, nil
+			}
+			f, err := pushFileFromParams(fileMap)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			files = append(files, f)
+		}
 
 		client, err := getClient(ctx)
 		if err != nil {
@@ -1238,6 +2719,10 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 		}
 		defer func() { _ = resp.Body.Close() }()
 
+		if expectedHeadSHA != "" && ref.GetObject().GetSHA() != expectedHeadSHA {
+			return utils.NewToolResultError(fmt.Sprintf("branch %s is at %s, not at expected_head_sha %s", branch, ref.GetObject().GetSHA(), expectedHeadSHA)), nil, nil
+		}
+
 		// Get the commit object that the branch points to
 		baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
 		if err != nil {
@@ -1252,29 +2737,68 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 		// Create tree entries for all files
 		var entries []*github.TreeEntry
 
-		for _, file := range filesObj {
-			fileMap, ok := file.(map[string]interface{})
-			if !ok {
-				return utils.NewToolResultError("each file must be an object with path and content"), nil, nil
+		for _, file := range files {
+			if file.operation == "delete" || file.operation == "rename" {
+				deletedPath := file.path
+				if file.operation == "rename" {
+					deletedPath = file.fromPath
+				}
+				// An entry without SHA or content deletes the path
+				entries = append(entries, &github.TreeEntry{
+					Path: github.Ptr(deletedPath),
+					Mode: github.Ptr("100644"),
+					Type: github.Ptr("blob"),
+				})
+				if file.operation == "delete" {
+					continue
+				}
 			}
 
-			path, ok := fileMap["path"].(string)
-			if !ok || path == "" {
-				return utils.NewToolResultError("each file must have a path"), nil, nil
+			entry := &github.TreeEntry{
+				Path: github.Ptr(file.path),
+				Mode: github.Ptr(file.mode),
+				Type: github.Ptr("blob"),
 			}
-
-			content, ok := fileMap["content"].(string)
-			if !ok {
-				return utils.NewToolResultError("each file must have content"), nil, nil
+			if file.operation == "rename" {
+				// A renamed file keeps its mode unless given, and its blob unless given content
+				source, resp, err := getTreeEntry(ctx, client, owner, repo, baseCommit.GetTree().GetSHA(), file.fromPath)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to get %s", file.fromPath),
+						resp,
+						err,
+					), nil, nil
+				}
+				if source == nil || source.GetType() != "blob" {
+					return utils.NewToolResultError(fmt.Sprintf("cannot rename %s: no such file on branch %s", file.fromPath, branch)), nil, nil
+				}
+				if file.mode == "" {
+					entry.Mode = source.Mode
+				}
+				if !file.hasContent {
+					entry.SHA = source.SHA
+				}
 			}
-
-			// Create a tree entry for the file
-			entries = append(entries, &github.TreeEntry{
-				Path:    github.Ptr(path),
-				Mode:    github.Ptr("100644"), // Regular file mode
-				Type:    github.Ptr("blob"),
-				Content: github.Ptr(content),
-			})
+			switch {
+			case file.hasContent && file.encoding == "base64":
+				// Tree entries only take text content, so binary content is pushed as a blob first
+				blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, github.Blob{
+					Content:  github.Ptr(file.content),
+					Encoding: github.Ptr("base64"),
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						fmt.Sprintf("failed to create blob for %s", file.path),
+						resp,
+						err,
+					), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				entry.SHA = blob.SHA
+			case file.hasContent:
+				entry.Content = github.Ptr(file.content)
+			}
+			entries = append(entries, entry)
 		}
 
 		// Create a new tree with the file entries
@@ -1330,8 +2854,110 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp
 	return tool, handler
 }
 
+// pushFile is a file of the files parameter of push_files.
+type pushFile struct {
+	operation  string
+	path       string
+	fromPath   string
+	content    string
+	hasContent bool
+	encoding   string
+	mode       string
+}
+
+func pushFileFromParams(fileMap map[string]any) (pushFile, error) {
+	var f pushFile
+	var err error
+
+	path, ok := fileMap["path"].(string)
+	if !ok || path == "" {
+		return f, fmt.Errorf("each file must have a path")
+	}
+	f.path = path
+	if f.operation, err = OptionalParam[string](fileMap, "operation"); err != nil {
+		return f, err
+	}
+	if f.fromPath, err = OptionalParam[string](fileMap, "from_path"); err != nil {
+		return f, err
+	}
+	if f.content, f.hasContent, err = OptionalParamOK[string](fileMap, "content"); err != nil {
+		return f, err
+	}
+	if f.encoding, err = OptionalParam[string](fileMap, "encoding"); err != nil {
+		return f, err
+	}
+	if f.mode, err = OptionalParam[string](fileMap, "mode"); err != nil {
+		return f, err
+	}
+
+	switch f.operation {
+	case "", "upsert":
+		f.operation = "upsert"
+		if !f.hasContent {
+			return f, fmt.Errorf("each file must have content, unless it is deleted or renamed: %s", path)
+		}
+	case "delete":
+		if f.hasContent || f.fromPath != "" || f.mode != "" {
+			return f, fmt.Errorf("deleted file %s cannot have content, from_path or mode", path)
+		}
+	case "rename":
+		if f.fromPath == "" {
+			return f, fmt.Errorf("renamed file %s must have a from_path", path)
+		}
+		if f.fromPath == path {
+			return f, fmt.Errorf("renamed file %s must have a from_path different from its path", path)
+		}
+	default:
+		return f, fmt.Errorf("invalid operation %q for %s, must be one of 'upsert', 'delete' or 'rename'", f.operation, path)
+	}
+
+	switch f.encoding {
+	case "", "text":
+		f.encoding = "text"
+	case "base64":
+		if _, err := base64.StdEncoding.DecodeString(f.content); err != nil {
+			return f, fmt.Errorf("invalid base64 content for %s: %w", path, err)
+		}
+	default:
+		return f, fmt.Errorf("invalid encoding %q for %s, must be either 'text' or 'base64'", f.encoding, path)
+	}
+	if f.operation == "upsert" && f.mode == "" {
+		f.mode = "100644"
+	}
+	return f, nil
+}
+
+// getTreeEntry returns the entry at path in a tree, walking one directory at a time so that large trees are not
+// truncated, or nil if there is none.
+func getTreeEntry(ctx context.Context, client *github.Client, owner, repo, treeSHA, path string) (*github.TreeEntry, *github.Response, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeSHA, false)
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+
+		var found *github.TreeEntry
+		for _, entry := range tree.Entries {
+			if entry.GetPath() == segment {
+				found = entry
+				break
+			}
+		}
+		if found == nil || i == len(segments)-1 {
+			return found, resp, nil
+		}
+		if found.GetType() != "tree" {
+			return nil, resp, nil
+		}
+		treeSHA = found.GetSHA()
+	}
+	return nil, nil, nil
+}
+
 // ListTags creates a tool to list tags in a GitHub repository.
-func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.RepositoryTag]) {
 	tool := mcp.Tool{
 		Name:        "list_tags",
 		Description: t("TOOL_LIST_TAGS_DESCRIPTION", "List git tags in a GitHub repository"),
@@ -1339,7 +2965,7 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.
 			Title:        t("TOOL_LIST_TAGS_USER_TITLE", "List tags"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -1352,10 +2978,11 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.
 				},
 			},
 			Required: []string{"owner", "repo"},
-		}),
+		})),
+		OutputSchema: ProjectedOutputSchema[[]*github.RepositoryTag](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.RepositoryTag](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.RepositoryTag, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1402,14 +3029,14 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), tags, nil
 	})
 
 	return tool, handler
 }
 
 // GetTag creates a tool to get details about a specific tag in a GitHub repository.
-func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.Tag]) {
 	tool := mcp.Tool{
 		Name:        "get_tag",
 		Description: t("TOOL_GET_TAG_DESCRIPTION", "Get details about a specific git tag in a GitHub repository"),
@@ -1417,7 +3044,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.To
 			Title:        t("TOOL_GET_TAG_USER_TITLE", "Get tag details"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -1434,10 +3061,11 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.To
 				},
 			},
 			Required: []string{"owner", "repo", "tag"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.Tag](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.Tag](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.Tag, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1499,14 +3127,14 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.To
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), tagObj, nil
 	})
 
 	return tool, handler
 }
 
 // ListReleases creates a tool to list releases in a GitHub repository.
-func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.RepositoryRelease]) {
 	tool := mcp.Tool{
 		Name:        "list_releases",
 		Description: t("TOOL_LIST_RELEASES_DESCRIPTION", "List releases in a GitHub repository"),
@@ -1514,7 +3142,7 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			Title:        t("TOOL_LIST_RELEASES_USER_TITLE", "List releases"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithResultFormat(WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -1527,10 +3155,11 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				},
 			},
 			Required: []string{"owner", "repo"},
-		}),
+		}))),
+		OutputSchema: ProjectedOutputSchema[[]*github.RepositoryRelease](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.RepositoryRelease](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.RepositoryRelease, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1549,6 +3178,11 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			PerPage: pagination.PerPage,
 		}
 
+		format, err := OptionalResultFormat(args)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
 		client, err := getClient(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -1573,14 +3207,19 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		result, err := FormatListResult(r, format, "", ReleaseColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return result, releases, nil
 	})
 
 	return tool, handler
 }
 
 // GetLatestRelease creates a tool to get the latest release in a GitHub repository.
-func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.RepositoryRelease]) {
 	tool := mcp.Tool{
 		Name:        "get_latest_release",
 		Description: t("TOOL_GET_LATEST_RELEASE_DESCRIPTION", "Get the latest release in a GitHub repository"),
@@ -1588,7 +3227,7 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 			Title:        t("TOOL_GET_LATEST_RELEASE_USER_TITLE", "Get latest release"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -1601,10 +3240,11 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.RepositoryRelease](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.RepositoryRelease](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.RepositoryRelease, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1638,13 +3278,13 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), release, nil
 	})
 
 	return tool, handler
 }
 
-func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.RepositoryRelease]) {
 	tool := mcp.Tool{
 		Name:        "get_release_by_tag",
 		Description: t("TOOL_GET_RELEASE_BY_TAG_DESCRIPTION", "Get a specific release by its tag name in a GitHub repository"),
@@ -1652,7 +3292,7 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 			Title:        t("TOOL_GET_RELEASE_BY_TAG_USER_TITLE", "Get a release by tag name"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -1669,10 +3309,11 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				},
 			},
 			Required: []string{"owner", "repo", "tag"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.RepositoryRelease](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.RepositoryRelease](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.RepositoryRelease, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1714,12 +3355,411 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 			return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), release, nil
+	})
+
+	return tool, handler
+}
+
+// ReleaseWrite creates a tool to create, update and delete releases, generate release notes and upload release assets.
+func ReleaseWrite(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+	tool := mcp.Tool{
+		Name:        "release_write",
+		Description: t("TOOL_RELEASE_WRITE_DESCRIPTION", "Create, update or delete a release in a GitHub repository, generate release notes, or upload a release asset."),
+		Annotations: &mcp.ToolAnnotations{
+			Title:           t("TOOL_RELEASE_WRITE_USER_TITLE", "Write operations on releases"),
+			ReadOnlyHint:    false,
+			DestructiveHint: github.Ptr(true),
+		},
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"method": {
+					Type: "string",
+					Description: `Write operation to perform on a single release.
+Options are:
+- 'create' - creates a new release for tag_name.
+- 'update' - updates the release with release_id.
+- 'delete' - deletes the release with release_id. The tag is kept.
+- 'generate_notes' - generates a name and body for a release of tag_name, without creating it.
+- 'upload_asset' - uploads asset_content as an asset of the release with release_id.
+`,
+					Enum: []any{"create", "update", "delete", "generate_notes", "upload_asset"},
+				},
+				"owner": {
+					Type:        "string",
+					Description: "Repository owner",
+				},
+				"repo": {
+					Type:        "string",
+					Description: "Repository name",
+				},
+				"release_id": {
+					Type:        "number",
+					Description: "Release ID. Required for 'update', 'delete' and 'upload_asset'.",
+				},
+				"tag_name": {
+					Type:        "string",
+					Description: "Tag name of the release (e.g., 'v1.0.0'). Required for 'create' and 'generate_notes'.",
+				},
+				"target_commitish": {
+					Type:        "string",
+					Description: "Branch or commit SHA the tag is created from if it does not exist. Defaults to the default branch.",
+				},
+				"name": {
+					Type:        "string",
+					Description: "Release name",
+				},
+				"body": {
+					Type:        "string",
+					Description: "Release notes in markdown",
+				},
+				"draft": {
+					Type:        "boolean",
+					Description: "Whether the release is a draft",
+				},
+				"prerelease": {
+					Type:        "boolean",
+					Description: "Whether the release is a prerelease",
+				},
+				"make_latest": {
+					Type:        "string",
+					Description: "Whether the release is set as the latest release. 'legacy' uses the creation date and semantic version.",
+					Enum:        []any{"true", "false", "legacy"},
+				},
+				"generate_release_notes": {
+					Type:        "boolean",
+					Description: "Generate the name and body of the release automatically, for 'create'. Given name and body are prepended.",
+				},
+				"previous_tag_name": {
+					Type:        "string",
+					Description: "Tag of the previous release to generate notes from, for 'generate_notes'. Defaults to the latest release.",
+				},
+				"asset_name": {
+					Type:        "string",
+					Description: "File name of the asset. Required for 'upload_asset'.",
+				},
+				"asset_label": {
+					Type:        "string",
+					Description: "Label shown for the asset instead of its name",
+				},
+				"asset_content": {
+					Type:        "string",
+					Description: "Content of the asset. Required for 'upload_asset'.",
+				},
+				"asset_encoding": {
+					Type:        "string",
+					Description: "Encoding of asset_content: 'text' for text files, 'base64' for binary files. Defaults to 'text'.",
+					Enum:        []any{"text", "base64"},
+				},
+				"asset_content_type": {
+					Type:        "string",
+					Description: "Media type of the asset. Defaults to the type of the asset_name extension, or 'application/octet-stream'.",
+				},
+			},
+			Required: []string{"method", "owner", "repo"},
+		},
+	}
+
+	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		method, err := RequiredParam[string](args, "method")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		owner, err := RequiredParam[string](args, "owner")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+		repo, err := RequiredParam[string](args, "repo")
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+
+		switch method {
+		case "create":
+			release, err := releaseFromParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if release.GetTagName() == "" {
+				return utils.NewToolResultError("missing required parameter: tag_name"), nil, nil
+			}
+			generateNotes, err := OptionalParam[bool](args, "generate_release_notes")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+			return createRelease(ctx, client, owner, repo, release)
+		case "update":
+			releaseID, err := RequiredInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			release, err := releaseFromParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			return updateRelease(ctx, client, owner, repo, int64(releaseID), release)
+		case "delete":
+			releaseID, err := RequiredInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			return deleteRelease(ctx, client, owner, repo, int64(releaseID))
+		case "generate_notes":
+			tagName, err := RequiredParam[string](args, "tag_name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := &github.GenerateNotesOptions{TagName: tagName}
+			previousTagName, err := OptionalParam[string](args, "previous_tag_name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if previousTagName != "" {
+				opts.PreviousTagName = github.Ptr(previousTagName)
+			}
+			targetCommitish, err := OptionalParam[string](args, "target_commitish")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if targetCommitish != "" {
+				opts.TargetCommitish = github.Ptr(targetCommitish)
+			}
+			return generateReleaseNotes(ctx, client, owner, repo, opts)
+		case "upload_asset":
+			releaseID, err := RequiredInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			return uploadReleaseAsset(ctx, client, owner, repo, int64(releaseID), args)
+		default:
+			return utils.NewToolResultError("invalid method, must be one of 'create', 'update', 'delete', 'generate_notes' or 'upload_asset'"), nil, nil
+		}
 	})
 
 	return tool, handler
 }
 
+// releaseFromParams returns a release with the fields given in args, leaving the others unset.
+func releaseFromParams(args map[string]any) (*github.RepositoryRelease, error) {
+	release := &github.RepositoryRelease{}
+	for param, field := range map[string]**string{
+		"tag_name":         &release.TagName,
+		"target_commitish": &release.TargetCommitish,
+		"name":             &release.Name,
+		"body":             &release.Body,
+		"make_latest":      &release.MakeLatest,
+	} {
+		value, ok, err := OptionalParamOK[string](args, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok && value != "" {
+			*field = github.Ptr(value)
+		}
+	}
+	for param, field := range map[string]**bool{
+		"draft":      &release.Draft,
+		"prerelease": &release.Prerelease,
+	} {
+		value, ok, err := OptionalParamOK[bool](args, param)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	return release, nil
+}
+
+func createRelease(ctx context.Context, client *github.Client, owner, repo string, release *github.RepositoryRelease) (*mcp.CallToolResult, any, error) {
+	created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to create release: %s", release.GetTagName()),
+			resp,
+			err,
+		), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to create release: %s", string(body))), nil, nil
+	}
+
+	return marshalMinimalResponse(fmt.Sprintf("%d", created.GetID()), created.GetHTMLURL())
+}
+
+func updateRelease(ctx context.Context, client *github.Client, owner, repo string, releaseID int64, release *github.RepositoryRelease) (*mcp.CallToolResult, any, error) {
+	updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, releaseID, release)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to update release: %d", releaseID),
+			resp,
+			err,
+		), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to update release: %s", string(body))), nil, nil
+	}
+
+	return marshalMinimalResponse(fmt.Sprintf("%d", updated.GetID()), updated.GetHTMLURL())
+}
+
+func deleteRelease(ctx context.Context, client *github.Client, owner, repo string, releaseID int64) (*mcp.CallToolResult, any, error) {
+	resp, err := client.Repositories.DeleteRelease(ctx, owner, repo, releaseID)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to delete release: %d", releaseID),
+			resp,
+			err,
+		), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusNoContent {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to delete release: %s", string(body))), nil, nil
+	}
+
+	return utils.NewToolResultText(fmt.Sprintf("release %d deleted successfully", releaseID)), nil, nil
+}
+
+func generateReleaseNotes(ctx context.Context, client *github.Client, owner, repo string, opts *github.GenerateNotesOptions) (*mcp.CallToolResult, any, error) {
+	notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to generate release notes: %s", opts.TagName),
+			resp,
+			err,
+		), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to generate release notes: %s", string(body))), nil, nil
+	}
+
+	r, err := json.Marshal(notes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return utils.NewToolResultText(string(r)), nil, nil
+}
+
+// uploadReleaseAsset uploads an asset given as text or base64 content to the upload URL of the client, since
+// go-github only uploads assets from files.
+func uploadReleaseAsset(ctx context.Context, client *github.Client, owner, repo string, releaseID int64, args map[string]any) (*mcp.CallToolResult, any, error) {
+	name, err := RequiredParam[string](args, "asset_name")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	// Empty assets are rejected by the API, so the content is required as well
+	content, err := RequiredParam[string](args, "asset_content")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	label, err := OptionalParam[string](args, "asset_label")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	encoding, err := OptionalParam[string](args, "asset_encoding")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+	contentType, err := OptionalParam[string](args, "asset_content_type")
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
+
+	var data []byte
+	switch encoding {
+	case "", "text":
+		data = []byte(content)
+	case "base64":
+		data, err = base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("failed to decode base64 asset_content: %s", err)), nil, nil
+		}
+	default:
+		return utils.NewToolResultError("invalid asset_encoding, must be either 'text' or 'base64'"), nil, nil
+	}
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	query := url.Values{}
+	query.Set("name", name)
+	if label != "" {
+		query.Set("label", label)
+	}
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+	req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+
+	asset := new(github.ReleaseAsset)
+	resp, err := client.Do(ctx, req, asset)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx,
+			fmt.Sprintf("failed to upload release asset: %s", name),
+			resp,
+			err,
+		), nil, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return utils.NewToolResultError(fmt.Sprintf("failed to upload release asset: %s", string(body))), nil, nil
+	}
+
+	return marshalMinimalResponse(fmt.Sprintf("%d", asset.GetID()), asset.GetBrowserDownloadURL())
+}
+
+func marshalMinimalResponse(id, htmlURL string) (*mcp.CallToolResult, any, error) {
+	r, err := json.Marshal(MinimalResponse{ID: id, URL: htmlURL})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return utils.NewToolResultText(string(r)), nil, nil
+}
+
 // filterPaths filters the entries in a GitHub tree to find paths that
 // match the given suffix.
 // maxResults limits the number of results returned to first maxResults entries,
@@ -1856,7 +3896,7 @@ func resolveGitReference(ctx context.Context, githubClient *github.Client, owner
 }
 
 // ListStarredRepositories creates a tool to list starred repositories for the authenticated user or a specified user.
-func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []MinimalRepository]) {
 	tool := mcp.Tool{
 		Name:        "list_starred_repositories",
 		Description: t("TOOL_LIST_STARRED_REPOSITORIES_DESCRIPTION", "List starred repositories"),
@@ -1864,7 +3904,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 			Title:        t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: WithPagination(&jsonschema.Schema{
+		InputSchema: WithFieldProjection(WithPagination(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"username": {
@@ -1882,10 +3922,11 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 					Enum:        []any{"asc", "desc"},
 				},
 			},
-		}),
+		})),
+		OutputSchema: ProjectedOutputSchema[[]MinimalRepository](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []MinimalRepository](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []MinimalRepository, error) {
 		username, err := OptionalParam[string](args, "username")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -1980,7 +4021,7 @@ func ListStarredRepositories(getClient GetClientFn, t translations.TranslationHe
 			return nil, nil, fmt.Errorf("failed to marshal starred repositories: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), minimalRepos, nil
 	})
 
 	return tool, handler
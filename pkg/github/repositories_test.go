@@ -3,12 +3,15 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/internal/githubv4mock"
 	"github.com/github/github-mcp-server/internal/toolsnaps"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -17,6 +20,7 @@ import (
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/migueleliasweb/go-github-mock/src/mock"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -329,6 +333,153 @@ func Test_GetFileContents(t *testing.T) {
 	}
 }
 
+func Test_GetFileContents_Ranges(t *testing.T) {
+	content := "line 1\nline 2\nline 3\nline 4\nline 5"
+
+	// rawHandler serves content, honoring Range headers unless ignoreRange is set
+	rawHandler := func(ignoreRange bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			rangeHeader := r.Header.Get("Range")
+			if rangeHeader == "" || ignoreRange {
+				_, _ = w.Write([]byte(content))
+				return
+			}
+			var start, end int
+			if _, err := fmt.Sscanf(rangeHeader, "bytes=%d-%d", &start, &end); err != nil {
+				end = len(content) - 1
+			}
+			if start >= len(content) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			end = min(end, len(content)-1)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(content[start : end+1]))
+		}
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		ignoreRange    bool
+		expectedText   string
+		expectedOutput FileContentsOutput
+		expectedErrMsg string
+	}{
+		{
+			name:           "whole file",
+			requestArgs:    map[string]any{},
+			expectedText:   content,
+			expectedOutput: FileContentsOutput{Size: len(content), TotalLines: 5},
+		},
+		{
+			name:           "line range",
+			requestArgs:    map[string]any{"start_line": float64(2), "end_line": float64(3)},
+			expectedText:   "line 2\nline 3\n",
+			expectedOutput: FileContentsOutput{Size: 14, TotalLines: 5, StartLine: 2, EndLine: 3},
+		},
+		{
+			name:           "line range past the end",
+			requestArgs:    map[string]any{"start_line": float64(4), "end_line": float64(10)},
+			expectedText:   "line 4\nline 5",
+			expectedOutput: FileContentsOutput{Size: 13, TotalLines: 5, StartLine: 4, EndLine: 5},
+		},
+		{
+			name:           "byte range",
+			requestArgs:    map[string]any{"byte_offset": float64(7), "byte_length": float64(6)},
+			expectedText:   "line 2",
+			expectedOutput: FileContentsOutput{Size: 6, ByteOffset: 7, TotalSize: len(content)},
+		},
+		{
+			name:           "byte range ignored by the server",
+			requestArgs:    map[string]any{"byte_offset": float64(7), "byte_length": float64(6)},
+			ignoreRange:    true,
+			expectedText:   "line 2",
+			expectedOutput: FileContentsOutput{Size: 6, ByteOffset: 7, TotalSize: len(content)},
+		},
+		{
+			name:           "byte range to the end",
+			requestArgs:    map[string]any{"byte_offset": float64(28)},
+			expectedText:   "line 5",
+			expectedOutput: FileContentsOutput{Size: 6, ByteOffset: 28, TotalSize: len(content)},
+		},
+		{
+			name:           "start line beyond the end",
+			requestArgs:    map[string]any{"start_line": float64(6)},
+			expectedErrMsg: "start_line 6 is beyond the end of the file (5 lines)",
+		},
+		{
+			name:           "byte offset beyond the end",
+			requestArgs:    map[string]any{"byte_offset": float64(100)},
+			expectedErrMsg: "byte_offset 100 is beyond the end of the file",
+		},
+		{
+			name:           "lines and bytes combined",
+			requestArgs:    map[string]any{"start_line": float64(1), "byte_length": float64(10)},
+			expectedErrMsg: "start_line and end_line cannot be combined with byte_offset and byte_length",
+		},
+		{
+			name:           "start line after end line",
+			requestArgs:    map[string]any{"start_line": float64(3), "end_line": float64(2)},
+			expectedErrMsg: "start_line 3 is after end_line 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						contentBytes, _ := json.Marshal(&github.RepositoryContent{
+							Name: github.Ptr("file.txt"),
+							Path: github.Ptr("file.txt"),
+							SHA:  github.Ptr("abc123"),
+							Type: github.Ptr("file"),
+						})
+						_, _ = w.Write(contentBytes)
+					}),
+				),
+				mock.WithRequestMatchHandler(
+					raw.GetRawReposContentsByOwnerByRepoBySHAByPath,
+					rawHandler(tc.ignoreRange),
+				),
+			)
+			client := github.NewClient(mockedClient)
+			mockRawClient := raw.NewClient(client, &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			_, handler := GetFileContents(stubGetClientFn(client), stubGetRawClientFn(mockRawClient), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "path": "file.txt", "sha": "def456"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			resource := getResourceResult(t, result)
+			assert.Equal(t, tc.expectedText, resource.Text)
+
+			expectedOutput := tc.expectedOutput
+			expectedOutput.Type = "file"
+			expectedOutput.Path = "file.txt"
+			expectedOutput.SHA = "abc123"
+			expectedOutput.URI = "repo://owner/repo/sha/def456/contents/file.txt"
+			expectedOutput.MIMEType = "text/plain"
+			assert.Equal(t, &expectedOutput, output)
+		})
+	}
+}
+
 func Test_ForkRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -635,7 +786,7 @@ func Test_CreateBranch(t *testing.T) {
 func Test_GetCommit(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := GetCommit(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := GetCommit(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	schema, ok := tool.InputSchema.(*jsonschema.Schema)
@@ -728,7 +879,7 @@ func Test_GetCommit(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := GetCommit(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := GetCommit(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
@@ -767,7 +918,7 @@ func Test_GetCommit(t *testing.T) {
 func Test_ListCommits(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
-	tool, _ := ListCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	tool, _ := ListCommits(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 	require.NoError(t, toolsnaps.Test(tool.Name, tool))
 
 	schema, ok := tool.InputSchema.(*jsonschema.Schema)
@@ -951,13 +1102,13 @@ func Test_ListCommits(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup client with mock
 			client := github.NewClient(tc.mockedClient)
-			_, handler := ListCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+			_, handler := ListCommits(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
 
 			// Create call request
 			request := createMCPRequest(tc.requestArgs)
 
 			// Call handler
-			result, _, err := handler(context.Background(), &request, tc.requestArgs)
+			result, output, err := handler(context.Background(), &request, tc.requestArgs)
 
 			// Verify results
 			if tc.expectError {
@@ -979,8 +1130,10 @@ func Test_ListCommits(t *testing.T) {
 			err = json.Unmarshal([]byte(textContent.Text), &returnedCommits)
 			require.NoError(t, err)
 			assert.Len(t, returnedCommits, len(tc.expectedCommits))
+			assert.Len(t, output, len(tc.expectedCommits))
 			for i, commit := range returnedCommits {
 				assert.Equal(t, tc.expectedCommits[i].GetSHA(), commit.SHA)
+				assert.Equal(t, commit.SHA, output[i].SHA)
 				assert.Equal(t, tc.expectedCommits[i].GetHTMLURL(), commit.HTMLURL)
 				if tc.expectedCommits[i].Commit != nil {
 					assert.Equal(t, tc.expectedCommits[i].Commit.GetMessage(), commit.Commit.Message)
@@ -997,6 +1150,167 @@ func Test_ListCommits(t *testing.T) {
 	}
 }
 
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, FeatureFlags{})
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, schema.Properties, "base")
+	assert.Contains(t, schema.Properties, "head")
+	assert.Contains(t, schema.Properties, "comparison")
+	assert.Contains(t, schema.Properties, "include_diff")
+	assert.Contains(t, schema.Properties, "page")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "base", "head"})
+
+	comparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(1),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/v1.0.0...main"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:    github.Ptr("abc123"),
+				Commit: &github.Commit{Message: github.Ptr("Fix parser")},
+				Author: &github.User{Login: github.Ptr("maintainer")},
+			},
+			{
+				SHA:    github.Ptr("def456"),
+				Commit: &github.Commit{Message: github.Ptr("Update docs")},
+				Author: &github.User{Login: github.Ptr("testuser")},
+			},
+		},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("parser.go"), Status: github.Ptr("modified"), Additions: github.Ptr(10), Deletions: github.Ptr(2), Changes: github.Ptr(12)},
+			{Filename: github.Ptr("README.md"), Status: github.Ptr("added"), Additions: github.Ptr(5), Changes: github.Ptr(5)},
+		},
+	}
+	diff := "diff --git a/parser.go b/parser.go\n--- a/parser.go\n+++ b/parser.go\n@@ -1 +1 @@\n-old\n+new\n"
+
+	compareHandler := func(expectedBasehead string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/compare/"+expectedBasehead) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			if r.Header.Get("Accept") == "application/vnd.github.v3.diff" {
+				_, _ = w.Write([]byte(diff))
+				return
+			}
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(comparison)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		basehead        string
+		requestArgs     map[string]any
+		flags           FeatureFlags
+		expectedCommits []string
+		expectedDiff    *CompareDiff
+		expectedErrMsg  string
+	}{
+		{
+			name:            "three-dot comparison",
+			basehead:        "v1.0.0...main",
+			requestArgs:     map[string]any{},
+			expectedCommits: []string{"abc123", "def456"},
+		},
+		{
+			name:            "two-dot comparison with a page of the diff",
+			basehead:        "v1.0.0..main",
+			requestArgs:     map[string]any{"comparison": "two_dot", "include_diff": true, "diff_page": float64(2), "diff_per_page": float64(4)},
+			expectedCommits: []string{"abc123", "def456"},
+			expectedDiff:    &CompareDiff{Text: "-old\n+new\n", Page: 2, TotalPages: 2, TotalLines: 6},
+		},
+		{
+			name:            "lockdown mode filters commits of untrusted authors",
+			basehead:        "v1.0.0...main",
+			requestArgs:     map[string]any{},
+			flags:           FeatureFlags{LockdownMode: true},
+			expectedCommits: []string{"abc123"},
+		},
+		{
+			name:           "unknown ref",
+			basehead:       "v1.0.0...other",
+			requestArgs:    map[string]any{},
+			expectedErrMsg: "failed to compare v1.0.0...main",
+		},
+		{
+			name:           "invalid comparison",
+			requestArgs:    map[string]any{"comparison": "four_dot"},
+			expectedErrMsg: `invalid comparison "four_dot"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					compareHandler(tc.basehead),
+				),
+			))
+			_, handler := CompareRefs(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, tc.flags)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "base": "v1.0.0", "head": "main", "page": float64(2)}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned CompareRefsResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, output, &returned)
+
+			assert.Equal(t, "diverged", returned.Status)
+			assert.Equal(t, 2, returned.AheadBy)
+			assert.Equal(t, 1, returned.BehindBy)
+			assert.Equal(t, 2, returned.TotalCommits)
+			assert.Equal(t, "base123", returned.MergeBaseSHA)
+			shas := make([]string, 0, len(returned.Commits))
+			for _, commit := range returned.Commits {
+				shas = append(shas, commit.SHA)
+			}
+			assert.Equal(t, tc.expectedCommits, shas)
+			assert.Equal(t, []MinimalCommitFile{
+				{Filename: "parser.go", Status: "modified", Additions: 10, Deletions: 2, Changes: 12},
+				{Filename: "README.md", Status: "added", Additions: 5, Changes: 5},
+			}, returned.Files)
+			assert.Equal(t, &MinimalCommitStats{Additions: 15, Deletions: 2, Total: 17}, returned.Stats)
+			assert.Equal(t, tc.expectedDiff, returned.Diff)
+		})
+	}
+}
+
+func Test_PaginateDiff(t *testing.T) {
+	diff := "line 1\nline 2\nline 3"
+
+	assert.Equal(t, &CompareDiff{Text: "line 1\nline 2\n", Page: 1, TotalPages: 2, TotalLines: 3}, paginateDiff(diff, 1, 2))
+	assert.Equal(t, &CompareDiff{Text: "line 3", Page: 2, TotalPages: 2, TotalLines: 3}, paginateDiff(diff, 2, 2))
+	assert.Equal(t, &CompareDiff{Text: "", Page: 3, TotalPages: 2, TotalLines: 3}, paginateDiff(diff, 3, 2))
+	assert.Equal(t, &CompareDiff{Text: "", Page: 1, TotalPages: 0, TotalLines: 0}, paginateDiff("", 1, 2))
+}
+
 func Test_CreateOrUpdateFile(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1482,6 +1796,177 @@ func Test_PushFiles(t *testing.T) {
 			expectError: false,
 			expectedRef: mockUpdatedRef,
 		},
+		{
+			name: "successful push of deletions, renames and binary files",
+			mockedClient: mock.NewMockedHTTPClient(
+				// Get branch reference
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				// Get commit
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Get the trees of the renamed files, one directory at a time
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Empty(t, r.URL.Query().Get("recursive"))
+						trees := map[string]*github.Tree{
+							"def456": {SHA: github.Ptr("def456"), Entries: []*github.TreeEntry{
+								{Path: github.Ptr("src"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("src123")},
+							}},
+							"src123": {SHA: github.Ptr("src123"), Entries: []*github.TreeEntry{
+								{Path: github.Ptr("build.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("build123")},
+								{Path: github.Ptr("main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("main123")},
+							}},
+						}
+						tree, ok := trees[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+						if !ok {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(tree)
+					}),
+				),
+				// Create blob for the binary file
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("png123")}),
+					),
+				),
+				// Create tree
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{"path": "old.txt", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "src/build.sh", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "scripts/build.sh", "mode": "100755", "type": "blob", "sha": "build123"},
+							map[string]interface{}{"path": "src/main.go", "mode": "100644", "type": "blob", "sha": nil},
+							map[string]interface{}{"path": "cmd/main.go", "mode": "100644", "type": "blob", "content": "package main\n"},
+							map[string]interface{}{"path": "logo.png", "mode": "100644", "type": "blob", "sha": "png123"},
+							map[string]interface{}{"path": "run.sh", "mode": "100755", "type": "blob", "content": "#!/bin/sh\n"},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				// Create commit
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				// Update reference
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{"path": "old.txt", "operation": "delete"},
+					map[string]interface{}{"path": "scripts/build.sh", "operation": "rename", "from_path": "src/build.sh"},
+					map[string]interface{}{"path": "cmd/main.go", "operation": "rename", "from_path": "src/main.go", "content": "package main\n"},
+					map[string]interface{}{"path": "logo.png", "content": "iVBORw0KGgo=", "encoding": "base64"},
+					map[string]interface{}{"path": "run.sh", "content": "#!/bin/sh\n", "mode": "100755"},
+				},
+				"message":           "Reorganize files",
+				"expected_head_sha": "abc123",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "fails when the branch moved from expected_head_sha",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{"path": "README.md", "content": "# README"},
+				},
+				"message":           "Update file",
+				"expected_head_sha": "old456",
+			},
+			expectError:    false,
+			expectedErrMsg: "branch main is at abc123, not at expected_head_sha old456",
+		},
+		{
+			name: "fails when renamed file does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					&github.Tree{SHA: github.Ptr("def456")},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{"path": "b.txt", "operation": "rename", "from_path": "a.txt"},
+				},
+				"message": "Rename file",
+			},
+			expectError:    false,
+			expectedErrMsg: "cannot rename a.txt: no such file on branch main",
+		},
+		{
+			name:         "fails when a file operation is invalid",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{"path": "a.txt", "operation": "rename"},
+				},
+				"message": "Rename file",
+			},
+			expectError:    false,
+			expectedErrMsg: "renamed file a.txt must have a from_path",
+		},
+		{
+			name:         "fails when base64 content is invalid",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{"path": "logo.png", "content": "not base64!", "encoding": "base64"},
+				},
+				"message": "Add logo",
+			},
+			expectError:    false,
+			expectedErrMsg: "invalid base64 content for logo.png",
+		},
 		{
 			name:         "fails when files parameter is invalid",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -2615,18 +3100,257 @@ func Test_GetReleaseByTag(t *testing.T) {
 	}
 }
 
-func Test_filterPaths(t *testing.T) {
-	tests := []struct {
-		name       string
-		tree       []*github.TreeEntry
-		path       string
-		maxResults int
-		expected   []string
-	}{
-		{
-			name: "file name",
-			tree: []*github.TreeEntry{
-				{Path: github.Ptr("folder/foo.txt"), Type: github.Ptr("blob")},
+func Test_ReleaseWrite(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := ReleaseWrite(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "release_write", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, schema.Properties, "method")
+	assert.Contains(t, schema.Properties, "release_id")
+	assert.Contains(t, schema.Properties, "tag_name")
+	assert.Contains(t, schema.Properties, "asset_content")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo"})
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	mockRelease := &github.RepositoryRelease{
+		ID:      github.Ptr(int64(42)),
+		TagName: github.Ptr("v1.0.0"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "create release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":               "v1.0.0",
+						"name":                   "Version 1",
+						"draft":                  false,
+						"make_latest":            "legacy",
+						"generate_release_notes": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":                 "create",
+				"tag_name":               "v1.0.0",
+				"name":                   "Version 1",
+				"draft":                  false,
+				"make_latest":            "legacy",
+				"generate_release_notes": true,
+			},
+			expectedText: `{"id":"42","url":"https://github.com/owner/repo/releases/tag/v1.0.0"}`,
+		},
+		{
+			name:           "create release without tag",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"method": "create", "name": "Version 1"},
+			expectedErrMsg: "missing required parameter: tag_name",
+		},
+		{
+			name: "update release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposReleasesByOwnerByRepoByReleaseId,
+					expectRequestBody(t, map[string]any{
+						"body":       "Fixed notes",
+						"prerelease": true,
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockRelease),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":     "update",
+				"release_id": float64(42),
+				"body":       "Fixed notes",
+				"prerelease": true,
+			},
+			expectedText: `{"id":"42","url":"https://github.com/owner/repo/releases/tag/v1.0.0"}`,
+		},
+		{
+			name:           "update release without ID",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"method": "update", "body": "Fixed notes"},
+			expectedErrMsg: "missing required parameter: release_id",
+		},
+		{
+			name: "delete release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			requestArgs:  map[string]any{"method": "delete", "release_id": float64(42)},
+			expectedText: "release 42 deleted successfully",
+		},
+		{
+			name: "delete missing release",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposReleasesByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs:    map[string]any{"method": "delete", "release_id": float64(43)},
+			expectedErrMsg: "failed to delete release: 43",
+		},
+		{
+			name: "generate notes",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesGenerateNotesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"tag_name":          "v1.1.0",
+						"previous_tag_name": "v1.0.0",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.RepositoryReleaseNotes{Name: "v1.1.0", Body: "## What's Changed"}),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":            "generate_notes",
+				"tag_name":          "v1.1.0",
+				"previous_tag_name": "v1.0.0",
+			},
+			expectedText: `{"name":"v1.1.0","body":"## What's Changed"}`,
+		},
+		{
+			name: "upload text asset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "uploads.github.com", r.Host)
+						assert.Equal(t, "checksums.txt", r.URL.Query().Get("name"))
+						assert.Equal(t, "Checksums", r.URL.Query().Get("label"))
+						assert.Equal(t, "text/plain; charset=utf-8", r.Header.Get("Content-Type"))
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						assert.Equal(t, "abc  app.tar.gz\n", string(body))
+						w.WriteHeader(http.StatusCreated)
+						_ = json.NewEncoder(w).Encode(&github.ReleaseAsset{
+							ID:                 github.Ptr(int64(7)),
+							BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/checksums.txt"),
+						})
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":        "upload_asset",
+				"release_id":    float64(42),
+				"asset_name":    "checksums.txt",
+				"asset_label":   "Checksums",
+				"asset_content": "abc  app.tar.gz\n",
+			},
+			expectedText: `{"id":"7","url":"https://github.com/owner/repo/releases/download/v1.0.0/checksums.txt"}`,
+		},
+		{
+			name: "upload base64 asset",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposReleasesAssetsByOwnerByRepoByReleaseId,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						assert.Equal(t, []byte{0x00, 0x01, 0xff}, body)
+						w.WriteHeader(http.StatusCreated)
+						_ = json.NewEncoder(w).Encode(&github.ReleaseAsset{ID: github.Ptr(int64(8))})
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"method":         "upload_asset",
+				"release_id":     float64(42),
+				"asset_name":     "app",
+				"asset_content":  "AAH/",
+				"asset_encoding": "base64",
+			},
+			expectedText: `{"id":"8","url":""}`,
+		},
+		{
+			name:         "upload invalid base64 asset",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":         "upload_asset",
+				"release_id":     float64(42),
+				"asset_name":     "app",
+				"asset_content":  "not base64!",
+				"asset_encoding": "base64",
+			},
+			expectedErrMsg: "failed to decode base64 asset_content",
+		},
+		{
+			name:           "invalid method",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]any{"method": "publish"},
+			expectedErrMsg: "invalid method",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReleaseWrite(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			for key, value := range tc.requestArgs {
+				args[key] = value
+			}
+			request := createMCPRequest(args)
+			result, _, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			if strings.HasPrefix(tc.expectedText, "{") {
+				assert.JSONEq(t, tc.expectedText, textContent.Text)
+			} else {
+				assert.Equal(t, tc.expectedText, textContent.Text)
+			}
+		})
+	}
+}
+
+func Test_filterPaths(t *testing.T) {
+	tests := []struct {
+		name       string
+		tree       []*github.TreeEntry
+		path       string
+		maxResults int
+		expected   []string
+	}{
+		{
+			name: "file name",
+			tree: []*github.TreeEntry{
+				{Path: github.Ptr("folder/foo.txt"), Type: github.Ptr("blob")},
 				{Path: github.Ptr("bar.txt"), Type: github.Ptr("blob")},
 				{Path: github.Ptr("nested/folder/foo.txt"), Type: github.Ptr("blob")},
 				{Path: github.Ptr("nested/folder/baz.txt"), Type: github.Ptr("blob")},
@@ -3470,3 +4194,929 @@ func Test_RepositoriesGetRepositoryTree(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCommit_Lockdown(t *testing.T) {
+	commit := func(login string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.Ptr("abc123"),
+			Commit: &github.Commit{Message: github.Ptr("Commit message")},
+			Author: &github.User{Login: github.Ptr(login)},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		author          string
+		redact          bool
+		expectedErrMsg  string
+		expectedMessage string
+	}{
+		{
+			name:            "author with push access",
+			author:          "maintainer",
+			expectedMessage: "Commit message",
+		},
+		{
+			name:           "author lacks push access",
+			author:         "testuser",
+			expectedErrMsg: "access to commit is restricted by lockdown mode",
+		},
+		{
+			name:            "redacts message of untrusted author",
+			author:          "testuser",
+			redact:          true,
+			expectedMessage: RedactedContentPlaceholder,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepoByRef, commit(tc.author)),
+			))
+			_, handler := GetCommit(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true, "lockdown-redact": tc.redact}))
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "sha": "abc123"}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, tc.expectedMessage, output.Commit.Message)
+			assert.Equal(t, tc.redact, output.Redacted)
+		})
+	}
+}
+
+func Test_ListCommits_Lockdown(t *testing.T) {
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA:    github.Ptr("abc123"),
+			Commit: &github.Commit{Message: github.Ptr("Maintainer commit")},
+			Author: &github.User{Login: github.Ptr("maintainer")},
+		},
+		{
+			SHA:    github.Ptr("def456"),
+			Commit: &github.Commit{Message: github.Ptr("External commit")},
+			Author: &github.User{Login: github.Ptr("testuser")},
+		},
+		{
+			SHA:    github.Ptr("ghi789"),
+			Commit: &github.Commit{Message: github.Ptr("Commit without a GitHub author")},
+		},
+		{
+			SHA: github.Ptr("jkl012"),
+		},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposCommitsByOwnerByRepo, mockCommits, mockCommits),
+	))
+	_, handler := ListCommits(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true}))
+
+	args := map[string]interface{}{"owner": "owner", "repo": "repo"}
+	request := createMCPRequest(args)
+	result, commits, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	require.Len(t, commits, 1)
+	assert.Equal(t, "abc123", commits[0].SHA)
+
+	// In redaction mode, the untrusted commits are kept with their message redacted
+	_, handler = ListCommits(stubGetClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true, "lockdown-redact": true}))
+	result, commits, err = handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	require.Len(t, commits, 4)
+	assert.False(t, commits[0].Redacted)
+	assert.Equal(t, "Maintainer commit", commits[0].Commit.Message)
+	for _, commit := range commits[1:3] {
+		assert.True(t, commit.Redacted)
+		assert.Equal(t, RedactedContentPlaceholder, commit.Commit.Message)
+	}
+	assert.Equal(t, "def456", commits[1].SHA)
+
+	// A commit without commit details has no message to redact
+	assert.True(t, commits[3].Redacted)
+	assert.Nil(t, commits[3].Commit)
+}
+
+func Test_DeleteBranch(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "delete_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "successful deletion",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Equal(t, "/repos/owner/repo/git/refs/heads/feature/done", r.URL.Path)
+						w.WriteHeader(http.StatusNoContent)
+					}),
+				),
+			),
+			expectedText: "branch feature/done deleted successfully",
+		},
+		{
+			name: "protected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Cannot delete this protected branch"}`),
+				),
+			),
+			expectedErrMsg: "failed to delete branch: feature/done",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := DeleteBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "branch": "feature/done"}
+			request := createMCPRequest(args)
+			result, _, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_RenameBranch(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := RenameBranch(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "rename_branch", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch", "new_name"})
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.PostReposBranchesRenameByOwnerByRepoByBranch,
+			expectRequestBody(t, map[string]any{"new_name": "feature/renamed"}).andThen(
+				mockResponse(t, http.StatusCreated, &github.Branch{
+					Name:      github.Ptr("feature/renamed"),
+					Commit:    &github.RepositoryCommit{SHA: github.Ptr("abc123")},
+					Protected: github.Ptr(false),
+				}),
+			),
+		),
+	))
+	_, handler := RenameBranch(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	args := map[string]any{"owner": "owner", "repo": "repo", "branch": "old-feature", "new_name": "feature/renamed"}
+	request := createMCPRequest(args)
+	result, output, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	expected := &MinimalBranch{Name: "feature/renamed", SHA: "abc123"}
+	var returned MinimalBranch
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+	assert.Equal(t, expected, &returned)
+	assert.Equal(t, expected, output)
+}
+
+func Test_GetBranchRules(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetBranchRules(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "get_branch_rules", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch"})
+
+	protectedBranch := &github.Branch{
+		Name:      github.Ptr("main"),
+		Protected: github.Ptr(true),
+		Protection: &github.Protection{
+			RequiredStatusChecks: &github.RequiredStatusChecks{Strict: true, Contexts: &[]string{"build"}},
+		},
+	}
+	protection := &github.Protection{
+		RequiredPullRequestReviews: &github.PullRequestReviewsEnforcement{
+			RequiredApprovingReviewCount: 1,
+			DismissStaleReviews:          true,
+		},
+		RequiredStatusChecks: &github.RequiredStatusChecks{
+			Strict: true,
+			Checks: &[]*github.RequiredStatusCheck{{Context: "build"}},
+		},
+		EnforceAdmins:    &github.AdminEnforcement{Enabled: true},
+		AllowForcePushes: &github.AllowForcePushes{Enabled: true},
+		AllowDeletions:   &github.AllowDeletions{Enabled: false},
+	}
+	rules := `[
+		{"type": "pull_request", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7,
+		 "parameters": {"required_approving_review_count": 2, "require_code_owner_review": true, "dismiss_stale_reviews_on_push": false,
+		 "require_last_push_approval": false, "required_review_thread_resolution": true, "allowed_merge_methods": ["squash"]}},
+		{"type": "required_status_checks", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 3,
+		 "parameters": {"strict_required_status_checks_policy": false, "required_status_checks": [{"context": "build"}, {"context": "lint"}]}},
+		{"type": "required_linear_history", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 7},
+		{"type": "non_fast_forward", "ruleset_source_type": "Organization", "ruleset_source": "owner", "ruleset_id": 3}
+	]`
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expected       *BranchRulesResult
+		expectedErrMsg string
+	}{
+		{
+			name: "protection and rulesets",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedBranch),
+				mock.WithRequestMatch(mock.GetReposBranchesProtectionByOwnerByRepoByBranch, protection),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write([]byte(rules))
+					}),
+				),
+			),
+			expected: &BranchRulesResult{
+				Branch:                         "main",
+				Protected:                      true,
+				PullRequestRequired:            true,
+				RequiredApprovingReviewCount:   2,
+				RequireCodeOwnerReview:         true,
+				DismissStaleReviews:            true,
+				RequiredReviewThreadResolution: true,
+				RequiredStatusChecks:           []string{"build", "lint"},
+				StrictStatusChecks:             true,
+				RequiredLinearHistory:          true,
+				AllowForcePushes:               github.Ptr(false),
+				AllowDeletions:                 github.Ptr(false),
+				EnforceAdmins:                  true,
+				Rulesets: []MinimalBranchRuleset{
+					{ID: 7, Source: "owner/repo", SourceType: "Repository", Rules: []string{"pull_request", "required_linear_history"}},
+					{ID: 3, Source: "owner", SourceType: "Organization", Rules: []string{"required_status_checks", "non_fast_forward"}},
+				},
+			},
+		},
+		{
+			name: "protection without admin access",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedBranch),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+			),
+			expected: &BranchRulesResult{
+				Branch:               "main",
+				Protected:            true,
+				RequiredStatusChecks: []string{"build"},
+				StrictStatusChecks:   true,
+				Rulesets:             []MinimalBranchRuleset{},
+				Notes:                []string{"classic branch protection could not be read, which requires admin access to the repository; only its required status checks are included, and whether it allows force pushes and deletions is unknown"},
+			},
+		},
+		{
+			name: "protection without admin access and a deletion rule",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedBranch),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesProtectionByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusForbidden, `{"message": "Resource not accessible by integration"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposRulesBranchesByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						_, _ = w.Write([]byte(`[{"type": "deletion", "ruleset_source_type": "Repository", "ruleset_source": "owner/repo", "ruleset_id": 9}]`))
+					}),
+				),
+			),
+			expected: &BranchRulesResult{
+				Branch:               "main",
+				Protected:            true,
+				RequiredStatusChecks: []string{"build"},
+				StrictStatusChecks:   true,
+				AllowDeletions:       github.Ptr(false),
+				Rulesets:             []MinimalBranchRuleset{{ID: 9, Source: "owner/repo", SourceType: "Repository", Rules: []string{"deletion"}}},
+				Notes:                []string{"classic branch protection could not be read, which requires admin access to the repository; only its required status checks are included, and whether it allows force pushes and deletions is unknown"},
+			},
+		},
+		{
+			name: "unprotected branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, &github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(false)}),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+			),
+			expected: &BranchRulesResult{
+				Branch:               "main",
+				RequiredStatusChecks: []string{},
+				AllowForcePushes:     github.Ptr(true),
+				AllowDeletions:       github.Ptr(true),
+				Rulesets:             []MinimalBranchRuleset{},
+			},
+		},
+		{
+			name: "missing branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					mockResponse(t, http.StatusNotFound, `{"message": "Branch not found"}`),
+				),
+			),
+			expectedErrMsg: "failed to get branch: main",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetBranchRules(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "branch": "main"}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned BranchRulesResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_GetRepository(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := GetRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "get_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	repository := &github.Repository{
+		ID:               github.Ptr(int64(42)),
+		Name:             github.Ptr("repo"),
+		FullName:         github.Ptr("owner/repo"),
+		Description:      github.Ptr("A test repository"),
+		Homepage:         github.Ptr("https://example.com"),
+		HTMLURL:          github.Ptr("https://github.com/owner/repo"),
+		Visibility:       github.Ptr("public"),
+		DefaultBranch:    github.Ptr("main"),
+		Topics:           []string{"go", "mcp"},
+		License:          &github.License{Key: github.Ptr("mit"), Name: github.Ptr("MIT License"), SPDXID: github.Ptr("MIT")},
+		StargazersCount:  github.Ptr(10),
+		HasIssues:        github.Ptr(true),
+		AllowSquashMerge: github.Ptr(true),
+		AllowRebaseMerge: github.Ptr(true),
+	}
+	languages := map[string]int{"Go": 1000, "Shell": 20}
+	metrics := &github.CommunityHealthMetrics{
+		HealthPercentage: github.Ptr(50),
+		Files: &github.CommunityHealthFiles{
+			Readme:  &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/README.md")},
+			License: &github.Metric{HTMLURL: github.Ptr("https://github.com/owner/repo/blob/main/LICENSE")},
+		},
+	}
+	details := func(profile *MinimalCommunityProfile) *RepositoryDetails {
+		return &RepositoryDetails{
+			ID:               42,
+			Name:             "repo",
+			FullName:         "owner/repo",
+			Description:      "A test repository",
+			Homepage:         "https://example.com",
+			HTMLURL:          "https://github.com/owner/repo",
+			Visibility:       "public",
+			DefaultBranch:    "main",
+			Topics:           []string{"go", "mcp"},
+			Languages:        languages,
+			License:          &MinimalLicense{Key: "mit", Name: "MIT License", SPDXID: "MIT"},
+			Stars:            10,
+			HasIssues:        true,
+			AllowSquashMerge: true,
+			AllowRebaseMerge: true,
+			CommunityProfile: profile,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expected       *RepositoryDetails
+		expectedErrMsg string
+	}{
+		{
+			name: "repository with community profile",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repository),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, languages),
+				mock.WithRequestMatch(mock.GetReposCommunityProfileByOwnerByRepo, metrics),
+			),
+			expected: details(&MinimalCommunityProfile{
+				HealthPercentage: 50,
+				Files: map[string]string{
+					"readme":  "https://github.com/owner/repo/blob/main/README.md",
+					"license": "https://github.com/owner/repo/blob/main/LICENSE",
+				},
+				Missing: []string{"contributing", "code_of_conduct", "issue_template", "pull_request_template"},
+			}),
+		},
+		{
+			name: "community profile not available",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposByOwnerByRepo, repository),
+				mock.WithRequestMatch(mock.GetReposLanguagesByOwnerByRepo, languages),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommunityProfileByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expected: details(nil),
+		},
+		{
+			name: "missing repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectedErrMsg: "failed to get repository: owner/repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			args := map[string]any{"owner": "owner", "repo": "repo"}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned RepositoryDetails
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_UpdateRepository(t *testing.T) {
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "update_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "topics")
+	assert.Contains(t, schema.Properties, "archived")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	t.Run("settings and topics", func(t *testing.T) {
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchReposByOwnerByRepo,
+				expectRequestBody(t, map[string]any{
+					"description":        "Updated",
+					"homepage":           "",
+					"allow_merge_commit": false,
+				}).andThen(
+					mockResponse(t, http.StatusOK, &github.Repository{
+						FullName:         github.Ptr("owner/repo"),
+						Description:      github.Ptr("Updated"),
+						AllowMergeCommit: github.Ptr(false),
+						AllowSquashMerge: github.Ptr(true),
+						Topics:           []string{"old"},
+					}),
+				),
+			),
+			mock.WithRequestMatchHandler(
+				mock.PutReposTopicsByOwnerByRepo,
+				expectRequestBody(t, map[string]any{"names": []any{"go", "mcp"}}).andThen(
+					mockResponse(t, http.StatusOK, map[string]any{"names": []string{"go", "mcp"}}),
+				),
+			),
+		))
+		_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		args := map[string]any{
+			"owner":              "owner",
+			"repo":               "repo",
+			"description":        "Updated",
+			"homepage":           "",
+			"allow_merge_commit": false,
+			"topics":             []any{"go", "mcp"},
+		}
+		request := createMCPRequest(args)
+		result, output, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+
+		textContent := getTextResult(t, result)
+		var returned RepositoryDetails
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+		assert.Equal(t, "Updated", returned.Description)
+		assert.Equal(t, []string{"go", "mcp"}, returned.Topics)
+		assert.False(t, returned.AllowMergeCommit)
+		assert.True(t, returned.AllowSquashMerge)
+		assert.Equal(t, &returned, output)
+	})
+
+	t.Run("archive after other changes", func(t *testing.T) {
+		var edits []map[string]any
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchReposByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					edits = append(edits, body)
+					_, archived := body["archived"]
+					mockResponse(t, http.StatusOK, &github.Repository{
+						FullName:    github.Ptr("owner/repo"),
+						Description: github.Ptr("Deprecated"),
+						Archived:    github.Ptr(archived),
+					})(w, r)
+				}),
+			),
+		))
+		_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		args := map[string]any{"owner": "owner", "repo": "repo", "description": "Deprecated", "archived": true}
+		request := createMCPRequest(args)
+		result, output, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		assert.Equal(t, []map[string]any{{"description": "Deprecated"}, {"archived": true}}, edits)
+		assert.True(t, output.Archived)
+	})
+
+	t.Run("unarchive before other changes", func(t *testing.T) {
+		var edits []map[string]any
+		client := github.NewClient(mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.PatchReposByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					edits = append(edits, body)
+					mockResponse(t, http.StatusOK, &github.Repository{FullName: github.Ptr("owner/repo")})(w, r)
+				}),
+			),
+		))
+		_, handler := UpdateRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+		args := map[string]any{"owner": "owner", "repo": "repo", "delete_branch_on_merge": true, "archived": false}
+		request := createMCPRequest(args)
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		assert.Equal(t, []map[string]any{{"archived": false}, {"delete_branch_on_merge": true}}, edits)
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		_, handler := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		args := map[string]any{"owner": "owner", "repo": "repo"}
+		request := createMCPRequest(args)
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Equal(t, "at least one setting to update must be provided", errorContent.Text)
+	})
+
+	t.Run("null topics are not an update", func(t *testing.T) {
+		_, handler := UpdateRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+		args := map[string]any{"owner": "owner", "repo": "repo", "topics": nil}
+		request := createMCPRequest(args)
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+
+		errorContent := getErrorResult(t, result)
+		assert.Equal(t, "at least one setting to update must be provided", errorContent.Text)
+	})
+}
+
+func Test_GetFileBlame(t *testing.T) {
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetFileBlame(stubGetGQLClientFn(mockClient), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Contains(t, schema.Properties, "start_line")
+	assert.Contains(t, schema.Properties, "end_line")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "path"})
+
+	qBlame := "query($owner:String!$path:String!$ref:String!$repo:String!){repository(owner: $owner, name: $repo){object(expression: $ref){... on Commit{oid,blame(path: $path){ranges{startingLine,endingLine,age,commit{oid,messageHeadline,author{name,email,date,user{login}},associatedPullRequests(first: 1){nodes{number}}}}}}}}}"
+
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"oid": "head123",
+				"blame": map[string]any{
+					"ranges": []any{
+						map[string]any{
+							"startingLine": 1,
+							"endingLine":   10,
+							"age":          10,
+							"commit": map[string]any{
+								"oid":             "aaa111",
+								"messageHeadline": "Initial commit",
+								"author": map[string]any{
+									"name":  "Octo Cat",
+									"email": "octocat@example.com",
+									"date":  "2024-01-02T03:04:05Z",
+									"user":  map[string]any{"login": "octocat"},
+								},
+								"associatedPullRequests": map[string]any{"nodes": []any{}},
+							},
+						},
+						map[string]any{
+							"startingLine": 11,
+							"endingLine":   12,
+							"age":          1,
+							"commit": map[string]any{
+								"oid":             "bbb222",
+								"messageHeadline": "Fix parser (#42)",
+								"author": map[string]any{
+									"name":  "Hubot",
+									"email": "hubot@example.com",
+									"date":  "2025-06-07T08:09:10Z",
+									"user":  nil,
+								},
+								"associatedPullRequests": map[string]any{"nodes": []any{map[string]any{"number": 42}}},
+							},
+						},
+						map[string]any{
+							"startingLine": 13,
+							"endingLine":   30,
+							"age":          10,
+							"commit": map[string]any{
+								"oid":             "aaa111",
+								"messageHeadline": "Initial commit",
+								"author": map[string]any{
+									"name":  "Octo Cat",
+									"email": "octocat@example.com",
+									"date":  "2024-01-02T03:04:05Z",
+									"user":  map[string]any{"login": "octocat"},
+								},
+								"associatedPullRequests": map[string]any{"nodes": []any{}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	initialCommit := func(start, end int) BlameRange {
+		return BlameRange{
+			StartLine:       start,
+			EndLine:         end,
+			Age:             10,
+			SHA:             "aaa111",
+			Author:          "Octo Cat",
+			AuthorEmail:     "octocat@example.com",
+			AuthorLogin:     "octocat",
+			Date:            "2024-01-02T03:04:05Z",
+			MessageHeadline: "Initial commit",
+		}
+	}
+	fixCommit := BlameRange{
+		StartLine:       11,
+		EndLine:         12,
+		Age:             1,
+		SHA:             "bbb222",
+		Author:          "Hubot",
+		AuthorEmail:     "hubot@example.com",
+		Date:            "2025-06-07T08:09:10Z",
+		MessageHeadline: "Fix parser (#42)",
+		PullRequest:     42,
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		vars           map[string]any
+		response       githubv4mock.GQLResponse
+		expected       *FileBlameResult
+		expectedErrMsg string
+	}{
+		{
+			name:     "whole file at default branch",
+			args:     map[string]any{"owner": "owner", "repo": "repo", "path": "main.go"},
+			vars:     map[string]any{"owner": "owner", "repo": "repo", "ref": "HEAD", "path": "main.go"},
+			response: blameResponse,
+			expected: &FileBlameResult{
+				Path:   "main.go",
+				Ref:    "HEAD",
+				SHA:    "head123",
+				Ranges: []BlameRange{initialCommit(1, 10), fixCommit, initialCommit(13, 30)},
+			},
+		},
+		{
+			name:     "line range at ref",
+			args:     map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "v1.0", "start_line": float64(8), "end_line": float64(12)},
+			vars:     map[string]any{"owner": "owner", "repo": "repo", "ref": "v1.0", "path": "main.go"},
+			response: blameResponse,
+			expected: &FileBlameResult{
+				Path:   "main.go",
+				Ref:    "v1.0",
+				SHA:    "head123",
+				Ranges: []BlameRange{initialCommit(8, 10), fixCommit},
+			},
+		},
+		{
+			name:           "start_line beyond the end of the file",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(31)},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "ref": "HEAD", "path": "main.go"},
+			response:       blameResponse,
+			expectedErrMsg: "start_line 31 is beyond the end of the file",
+		},
+		{
+			name:           "ref not found",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "ref": "missing"},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "ref": "missing", "path": "main.go"},
+			response:       githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"object": nil}}),
+			expectedErrMsg: "ref missing does not point to a commit",
+		},
+		{
+			name:           "query error",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "missing.go"},
+			vars:           map[string]any{"owner": "owner", "repo": "repo", "ref": "HEAD", "path": "missing.go"},
+			response:       githubv4mock.ErrorResponse("Could not resolve file for path 'missing.go'."),
+			expectedErrMsg: "Could not resolve file for path 'missing.go'.",
+		},
+		{
+			name:           "start_line after end_line",
+			args:           map[string]any{"owner": "owner", "repo": "repo", "path": "main.go", "start_line": float64(5), "end_line": float64(2)},
+			expectedErrMsg: "start_line 5 is after end_line 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var httpClient *http.Client
+			if tc.vars != nil {
+				httpClient = githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qBlame, tc.vars, tc.response))
+			}
+			client := githubv4.NewClient(httpClient)
+			_, handler := GetFileBlame(stubGetGQLClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": false}))
+
+			request := createMCPRequest(tc.args)
+			result, output, err := handler(context.Background(), &request, tc.args)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			textContent := getTextResult(t, result)
+			var returned FileBlameResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returned))
+			assert.Equal(t, tc.expected, &returned)
+			assert.Equal(t, tc.expected, output)
+		})
+	}
+}
+
+func Test_GetFileBlame_Lockdown(t *testing.T) {
+	qBlame := "query($owner:String!$path:String!$ref:String!$repo:String!){repository(owner: $owner, name: $repo){object(expression: $ref){... on Commit{oid,blame(path: $path){ranges{startingLine,endingLine,age,commit{oid,messageHeadline,author{name,email,date,user{login}},associatedPullRequests(first: 1){nodes{number}}}}}}}}}"
+	blameRange := func(line int, headline string, user any) map[string]any {
+		return map[string]any{
+			"startingLine": line,
+			"endingLine":   line,
+			"age":          1,
+			"commit": map[string]any{
+				"oid":                    fmt.Sprintf("sha%d", line),
+				"messageHeadline":        headline,
+				"author":                 map[string]any{"name": "Author", "email": "", "date": "2024-01-02T03:04:05Z", "user": user},
+				"associatedPullRequests": map[string]any{"nodes": []any{}},
+			},
+		}
+	}
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": map[string]any{
+				"oid": "head123",
+				"blame": map[string]any{
+					"ranges": []any{
+						blameRange(1, "Maintainer commit", map[string]any{"login": "maintainer"}),
+						blameRange(2, "External commit", map[string]any{"login": "testuser"}),
+						blameRange(3, "Commit without a GitHub author", nil),
+					},
+				},
+			},
+		},
+	})
+
+	tests := []struct {
+		name              string
+		redact            bool
+		expectedHeadlines []string
+		expectedRedacted  []bool
+	}{
+		{
+			name:              "filters ranges of untrusted authors",
+			expectedHeadlines: []string{"Maintainer commit"},
+			expectedRedacted:  []bool{false},
+		},
+		{
+			name:              "redacts ranges of untrusted authors",
+			redact:            true,
+			expectedHeadlines: []string{"Maintainer commit", RedactedContentPlaceholder, RedactedContentPlaceholder},
+			expectedRedacted:  []bool{false, true, true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vars := map[string]any{"owner": "owner", "repo": "repo", "ref": "HEAD", "path": "main.go"}
+			client := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(qBlame, vars, blameResponse)))
+			_, handler := GetFileBlame(stubGetGQLClientFn(client), repoAccessCache, translations.NullTranslationHelper, stubFeatureFlags(map[string]bool{"lockdown-mode": true, "lockdown-redact": tc.redact}))
+
+			args := map[string]any{"owner": "owner", "repo": "repo", "path": "main.go"}
+			request := createMCPRequest(args)
+			result, output, err := handler(context.Background(), &request, args)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			headlines := make([]string, 0, len(output.Ranges))
+			redacted := make([]bool, 0, len(output.Ranges))
+			for _, r := range output.Ranges {
+				headlines = append(headlines, r.MessageHeadline)
+				redacted = append(redacted, r.Redacted)
+			}
+			assert.Equal(t, tc.expectedHeadlines, headlines)
+			assert.Equal(t, tc.expectedRedacted, redacted)
+		})
+	}
+}
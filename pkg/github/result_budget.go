@@ -0,0 +1,477 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// DefaultResponseTokenBudget is the default maximum size of a tool result, in tokens.
+	DefaultResponseTokenBudget = 25000
+
+	// bytesPerToken approximates the number of bytes of JSON or code per token.
+	bytesPerToken = 4
+
+	defaultContinuationTTL   = 10 * time.Minute
+	defaultMaxPendingResults = 100
+	continueResultToolName   = "continue_result"
+	continuationTokenParam   = "continuation_token"
+)
+
+// ResultBudget limits the size of tool results. Results over budget are cut into chunks at sensible boundaries,
+// such as array items, diff hunks or lines. The first chunk is returned along with a continuation token, which
+// the continue_result tool accepts to return the next chunk in the session that called the tool.
+type ResultBudget struct {
+	maxTokens  int
+	ttl        time.Duration
+	maxPending int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*pendingResult
+}
+
+type pendingResult struct {
+	// session is the session that called the tool, the only one that can get the remaining chunks
+	session *mcp.ServerSession
+	chunks  []string
+	next    int
+	expires time.Time
+}
+
+// ResultBudgetOption configures ResultBudget at construction time.
+type ResultBudgetOption func(*ResultBudget)
+
+// WithContinuationTTL overrides how long the remaining chunks of a result are kept for continue_result.
+func WithContinuationTTL(ttl time.Duration) ResultBudgetOption {
+	return func(b *ResultBudget) {
+		b.ttl = ttl
+	}
+}
+
+// WithMaxPendingResults overrides how many results with remaining chunks are kept. When the limit is reached,
+// the result that expires first is dropped.
+func WithMaxPendingResults(n int) ResultBudgetOption {
+	return func(b *ResultBudget) {
+		b.maxPending = n
+	}
+}
+
+// NewResultBudget creates a ResultBudget limiting tool results to maxTokens tokens.
+func NewResultBudget(maxTokens int, opts ...ResultBudgetOption) *ResultBudget {
+	b := &ResultBudget{
+		maxTokens:  maxTokens,
+		ttl:        defaultContinuationTTL,
+		maxPending: defaultMaxPendingResults,
+		now:        time.Now,
+		pending:    make(map[string]*pendingResult),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// maxBytes is the budget in bytes, as token counts are estimated from the size of the content.
+func (b *ResultBudget) maxBytes() int {
+	return b.maxTokens * bytesPerToken
+}
+
+// Middleware enforces the budget on the results of tool calls. Only text is cut, so results made of binary
+// content are returned unchanged. The structured content of a cut result is cut to the same size between the
+// items of its largest array, or dropped when it has none, as the other parts are returned as text only.
+func (b *ResultBudget) Middleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || method != "tools/call" {
+			return result, err
+		}
+
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult.IsError {
+			return result, nil
+		}
+
+		session, _ := req.GetSession().(*mcp.ServerSession)
+		return b.apply(session, callToolResult)
+	}
+}
+
+func (b *ResultBudget) apply(session *mcp.ServerSession, result *mcp.CallToolResult) (*mcp.CallToolResult, error) {
+	// Find the largest text content, which is the one to cut
+	total, largest, largestSize := 0, -1, 0
+	for i, content := range result.Content {
+		size := len(contentText(content))
+		total += size
+		if size > largestSize {
+			largest, largestSize = i, size
+		}
+	}
+	if total <= b.maxBytes() || largest == -1 {
+		// The text fits, but the structured content may not
+		structured, cut, err := cutStructuredContent(result.StructuredContent, b.maxBytes())
+		if err != nil || !cut {
+			return result, err
+		}
+		return &mcp.CallToolResult{Content: result.Content, StructuredContent: structured, Meta: result.Meta}, nil
+	}
+
+	// Leave room for the other content, but not so little that the result is cut into tiny parts
+	available := max(b.maxBytes()-(total-largestSize), b.maxBytes()/4)
+	chunks := splitResult(contentText(result.Content[largest]), available)
+	if len(chunks) < 2 {
+		return result, nil
+	}
+
+	token, err := b.store(session, chunks[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]mcp.Content, 0, len(result.Content)+1)
+	for i, c := range result.Content {
+		if i == largest {
+			c = &mcp.TextContent{Text: chunks[0]}
+		}
+		content = append(content, c)
+	}
+	content = append(content, &mcp.TextContent{Text: b.continuationMessage(1, len(chunks), token)})
+
+	structured, _, err := cutStructuredContent(result.StructuredContent, available)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.CallToolResult{Content: content, StructuredContent: structured, Meta: result.Meta}, nil
+}
+
+// cutStructuredContent cuts structured content to at most maxBytes bytes of JSON, reporting whether it was cut.
+// Only the largest array is cut, between items, which keeps the structured content valid against the output
+// schema of the tool, as output schemas require no properties. Structured content that cannot be cut that way
+// is dropped.
+func cutStructuredContent(structured any, maxBytes int) (any, bool, error) {
+	if structured == nil {
+		return nil, false, nil
+	}
+	data, err := json.Marshal(structured)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal structured content: %w", err)
+	}
+	if len(data) <= maxBytes {
+		return structured, false, nil
+	}
+
+	chunks, ok := splitJSON(string(data), maxBytes)
+	if !ok || len(chunks[0]) > maxBytes {
+		return nil, true, nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(chunks[0]))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal structured content: %w", err)
+	}
+	return v, true, nil
+}
+
+// contentText returns the text of content that can be cut, or an empty string.
+func contentText(content mcp.Content) string {
+	switch c := content.(type) {
+	case *mcp.TextContent:
+		return c.Text
+	case *mcp.EmbeddedResource:
+		if c.Resource != nil {
+			return c.Resource.Text
+		}
+	}
+	return ""
+}
+
+func (b *ResultBudget) continuationMessage(part, total int, token string) string {
+	return fmt.Sprintf("The result was cut to fit the response budget of %d tokens (part %d of %d). Call %s with %s %q to get the next part.",
+		b.maxTokens, part, total, continueResultToolName, continuationTokenParam, token)
+}
+
+func (b *ResultBudget) store(session *mcp.ServerSession, chunks []string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to create continuation token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	for t, p := range b.pending {
+		if now.After(p.expires) {
+			delete(b.pending, t)
+		}
+	}
+	for len(b.pending) > 0 && len(b.pending) >= b.maxPending {
+		var oldest string
+		for t, p := range b.pending {
+			if oldest == "" || p.expires.Before(b.pending[oldest].expires) {
+				oldest = t
+			}
+		}
+		delete(b.pending, oldest)
+	}
+
+	// The first chunk has already been returned
+	b.pending[token] = &pendingResult{session: session, chunks: chunks, next: 0, expires: now.Add(b.ttl)}
+	return token, nil
+}
+
+// nextChunk returns the next chunk of the result of a continuation token, with its part number and the total
+// number of parts. The token stays valid until the last chunk is returned. Tokens of other sessions are unknown.
+func (b *ResultBudget) nextChunk(session *mcp.ServerSession, token string) (chunk string, part, total int, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p, ok := b.pending[token]
+	if ok && p.session != session {
+		// The token may have leaked, so it is left to its session
+		return "", 0, 0, false
+	}
+	if !ok || b.now().After(p.expires) {
+		delete(b.pending, token)
+		return "", 0, 0, false
+	}
+
+	chunk = p.chunks[p.next]
+	p.next++
+	if p.next == len(p.chunks) {
+		delete(b.pending, token)
+	}
+
+	// The first part was returned with the original result
+	return chunk, p.next + 1, len(p.chunks) + 1, true
+}
+
+// ContinueResult creates a tool to get the next part of a result that was cut to fit the response budget.
+func ContinueResult(budget *ResultBudget, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+	return mcp.Tool{
+			Name:        continueResultToolName,
+			Description: t("TOOL_CONTINUE_RESULT_DESCRIPTION", "Get the next part of a tool result that was cut to fit the response budget, using the continuation token returned with the previous part"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CONTINUE_RESULT_USER_TITLE", "Continue a tool result"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					continuationTokenParam: {
+						Type:        "string",
+						Description: "The continuation token returned with the previous part of the result",
+					},
+				},
+				Required: []string{continuationTokenParam},
+			},
+		},
+		func(_ context.Context, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			token, err := RequiredParam[string](args, continuationTokenParam)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			chunk, part, total, ok := budget.nextChunk(req.Session, token)
+			if !ok {
+				return utils.NewToolResultError("unknown or expired continuation token, call the original tool again"), nil, nil
+			}
+
+			content := []mcp.Content{&mcp.TextContent{Text: chunk}}
+			if part < total {
+				content = append(content, &mcp.TextContent{Text: budget.continuationMessage(part, total, token)})
+			}
+			return &mcp.CallToolResult{Content: content}, nil, nil
+		}
+}
+
+// splitResult cuts text into chunks of at most maxBytes bytes. JSON arrays, including the largest array of a
+// JSON object, are cut between items, so that each chunk is valid JSON. Diffs are cut between hunks, and other
+// text between lines. Units larger than maxBytes are cut between lines, or between characters as a last resort.
+func splitResult(text string, maxBytes int) []string {
+	if len(text) <= maxBytes {
+		return []string{text}
+	}
+
+	var chunks []string
+	if jsonChunks, ok := splitJSON(text, maxBytes); ok {
+		chunks = jsonChunks
+	} else if strings.HasPrefix(text, "diff --git ") {
+		chunks = pack(splitBefore(text, "\ndiff --git ", "\n@@ "), maxBytes)
+	} else {
+		chunks = pack(splitLines(text), maxBytes)
+	}
+
+	// Chunks made of a single unit can still be over budget
+	var result []string
+	for _, chunk := range chunks {
+		if len(chunk) <= maxBytes {
+			result = append(result, chunk)
+			continue
+		}
+		result = append(result, pack(splitLines(chunk), maxBytes)...)
+	}
+	return result
+}
+
+// splitJSON cuts a JSON array, or the largest array of a JSON object, between items.
+func splitJSON(text string, maxBytes int) ([]string, bool) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	// Keep numbers, such as IDs, as they are
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false
+	}
+
+	switch v := v.(type) {
+	case []any:
+		return splitJSONArray(v, maxBytes, func(items []any) any { return items })
+	case map[string]any:
+		// Cut the largest array, keeping the other fields in every chunk
+		var key string
+		largest := 0
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			items, ok := v[k].([]any)
+			if !ok {
+				continue
+			}
+			r, err := json.Marshal(items)
+			if err == nil && len(r) > largest {
+				key, largest = k, len(r)
+			}
+		}
+		if key == "" {
+			return nil, false
+		}
+
+		// The other fields are repeated in every chunk, so they must leave room for items
+		available := maxBytes - (len(text) - largest)
+		if available <= 0 {
+			return nil, false
+		}
+
+		items := v[key].([]any)
+		return splitJSONArray(items, available, func(items []any) any {
+			chunk := make(map[string]any, len(v))
+			for k, value := range v {
+				chunk[k] = value
+			}
+			chunk[key] = items
+			return chunk
+		})
+	default:
+		return nil, false
+	}
+}
+
+func splitJSONArray(items []any, maxBytes int, wrap func([]any) any) ([]string, bool) {
+	if len(items) < 2 {
+		return nil, false
+	}
+
+	var chunks []string
+	var current []any
+	size := 2 // brackets of the array
+	flush := func() bool {
+		r, err := json.Marshal(wrap(current))
+		if err != nil {
+			return false
+		}
+		chunks = append(chunks, string(r))
+		current, size = nil, 2
+		return true
+	}
+
+	for _, item := range items {
+		r, err := json.Marshal(item)
+		if err != nil {
+			return nil, false
+		}
+		if len(current) > 0 && size+len(r)+1 > maxBytes {
+			if !flush() {
+				return nil, false
+			}
+		}
+		current = append(current, item)
+		size += len(r) + 1
+	}
+	if !flush() {
+		return nil, false
+	}
+	return chunks, true
+}
+
+// splitBefore cuts text before each occurrence of the separators.
+func splitBefore(text string, separators ...string) []string {
+	var units []string
+	start := 0
+	for i := 1; i < len(text); i++ {
+		for _, sep := range separators {
+			if strings.HasPrefix(text[i:], sep) {
+				// Keep the newline with the previous unit
+				units = append(units, text[start:i+1])
+				start = i + 1
+				break
+			}
+		}
+	}
+	return append(units, text[start:])
+}
+
+// splitLines cuts text after each newline.
+func splitLines(text string) []string {
+	return strings.SplitAfter(text, "\n")
+}
+
+// pack joins consecutive units into chunks of at most maxBytes bytes. Units larger than maxBytes are cut
+// between characters.
+func pack(units []string, maxBytes int) []string {
+	var chunks []string
+	var current bytes.Buffer
+	for _, unit := range units {
+		if current.Len() > 0 && current.Len()+len(unit) > maxBytes {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		for len(unit) > maxBytes {
+			cut := maxBytes
+			for cut > 0 && !utf8.RuneStart(unit[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				// Not valid UTF-8, so there is no character boundary to cut at
+				cut = maxBytes
+			}
+			chunks = append(chunks, unit[:cut])
+			unit = unit[cut:]
+		}
+		current.WriteString(unit)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
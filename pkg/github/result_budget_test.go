@@ -0,0 +1,313 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var continuationTokenPattern = regexp.MustCompile(`continuation_token "([0-9a-f]+)"`)
+
+// callWithBudget calls a tool returning result through the budget middleware.
+func callWithBudget(t *testing.T, budget *ResultBudget, result *mcp.CallToolResult) *mcp.CallToolResult {
+	t.Helper()
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return result, nil
+	}
+	request := createMCPRequest(map[string]any{})
+	r, err := budget.Middleware(next)(context.Background(), "tools/call", &request)
+	require.NoError(t, err)
+	callToolResult, ok := r.(*mcp.CallToolResult)
+	require.True(t, ok)
+	return callToolResult
+}
+
+// continueAll calls continue_result until the last part and returns the text of every part.
+func continueAll(t *testing.T, budget *ResultBudget, result *mcp.CallToolResult) []string {
+	t.Helper()
+	_, handler := ContinueResult(budget, translations.NullTranslationHelper)
+
+	var parts []string
+	for {
+		parts = append(parts, result.Content[0].(*mcp.TextContent).Text)
+		if len(result.Content) == 1 {
+			return parts
+		}
+
+		match := continuationTokenPattern.FindStringSubmatch(result.Content[len(result.Content)-1].(*mcp.TextContent).Text)
+		require.NotNil(t, match, "expected a continuation token")
+
+		args := map[string]any{"continuation_token": match[1]}
+		request := createMCPRequest(args)
+		var err error
+		result, _, err = handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	}
+}
+
+func Test_ContinueResult(t *testing.T) {
+	tool, _ := ContinueResult(nil, translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "continue_result", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint, "continue_result tool should be read-only")
+}
+
+func Test_ResultBudget_UnderBudget(t *testing.T) {
+	budget := NewResultBudget(100)
+	result := &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: `{"number":1}`}},
+		StructuredContent: map[string]any{"number": 1},
+	}
+
+	assert.Same(t, result, callWithBudget(t, budget, result))
+}
+
+func Test_ResultBudget_ErrorResult(t *testing.T) {
+	budget := NewResultBudget(1)
+	result := utils.NewToolResultError(strings.Repeat("error ", 1000))
+
+	assert.Same(t, result, callWithBudget(t, budget, result))
+}
+
+func Test_ResultBudget_JSONArray(t *testing.T) {
+	var items []map[string]any
+	for i := range 100 {
+		items = append(items, map[string]any{"number": i, "title": fmt.Sprintf("Issue %d", i)})
+	}
+	r, err := json.Marshal(items)
+	require.NoError(t, err)
+
+	budget := NewResultBudget(300)
+	result := callWithBudget(t, budget, &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(r)}},
+		StructuredContent: map[string]any{"items": items},
+	})
+
+	// The structured content is cut between items too, keeping the shape of the output schema
+	structured, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(structured), 300*bytesPerToken)
+	var structuredItems struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(structured, &structuredItems))
+	assert.NotEmpty(t, structuredItems.Items)
+	assert.Less(t, len(structuredItems.Items), 100)
+	assert.Equal(t, float64(0), structuredItems.Items[0]["number"])
+
+	var all []map[string]any
+	parts := continueAll(t, budget, result)
+	require.Greater(t, len(parts), 1)
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(part), 300*bytesPerToken)
+
+		// Every part is a valid JSON array
+		var chunk []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(part), &chunk))
+		all = append(all, chunk...)
+	}
+	require.Len(t, all, 100)
+	assert.Equal(t, float64(99), all[99]["number"])
+}
+
+func Test_ResultBudget_JSONObject(t *testing.T) {
+	tree := map[string]any{"sha": "abc", "truncated": false}
+	var entries []map[string]any
+	for i := range 100 {
+		entries = append(entries, map[string]any{"path": fmt.Sprintf("src/file%d.go", i), "type": "blob"})
+	}
+	tree["tree"] = entries
+	r, err := json.Marshal(tree)
+	require.NoError(t, err)
+
+	budget := NewResultBudget(250)
+	result := callWithBudget(t, budget, utils.NewToolResultText(string(r)))
+
+	count := 0
+	parts := continueAll(t, budget, result)
+	require.Greater(t, len(parts), 1)
+	for _, part := range parts {
+		assert.LessOrEqual(t, len(part), 250*bytesPerToken)
+
+		// The other fields are kept in every part
+		var chunk struct {
+			SHA  string           `json:"sha"`
+			Tree []map[string]any `json:"tree"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(part), &chunk))
+		assert.Equal(t, "abc", chunk.SHA)
+		count += len(chunk.Tree)
+	}
+	assert.Equal(t, 100, count)
+}
+
+func Test_ResultBudget_Diff(t *testing.T) {
+	var diff strings.Builder
+	for i := range 20 {
+		fmt.Fprintf(&diff, "diff --git a/file%d.go b/file%d.go\n--- a/file%d.go\n+++ b/file%d.go\n", i, i, i, i)
+		for j := range 3 {
+			fmt.Fprintf(&diff, "@@ -%d,2 +%d,2 @@\n-old line %d\n+new line %d\n", j*10, j*10, j, j)
+		}
+	}
+
+	budget := NewResultBudget(100)
+	result := callWithBudget(t, budget, utils.NewToolResultText(diff.String()))
+
+	parts := continueAll(t, budget, result)
+	require.Greater(t, len(parts), 1)
+	for _, part := range parts[1:] {
+		// Parts start at a file or a hunk
+		assert.True(t, strings.HasPrefix(part, "diff --git ") || strings.HasPrefix(part, "@@ "), "unexpected part start: %q", part[:20])
+	}
+	assert.Equal(t, diff.String(), strings.Join(parts, ""))
+}
+
+func Test_ResultBudget_Lines(t *testing.T) {
+	var text strings.Builder
+	for i := range 1000 {
+		fmt.Fprintf(&text, "line %d\n", i)
+	}
+
+	budget := NewResultBudget(500)
+	result := callWithBudget(t, budget, utils.NewToolResultResource("successfully downloaded text file", &mcp.ResourceContents{
+		URI:      "repo://owner/repo/contents/file.txt",
+		MIMEType: "text/plain",
+		Text:     text.String(),
+	}))
+
+	// The message is kept, and the resource is cut
+	require.Len(t, result.Content, 3)
+	assert.Equal(t, "successfully downloaded text file", result.Content[0].(*mcp.TextContent).Text)
+
+	first := result.Content[1].(*mcp.TextContent).Text
+	assert.True(t, strings.HasSuffix(first, "\n"), "parts end at a line")
+
+	rest := continueAll(t, budget, &mcp.CallToolResult{Content: result.Content[1:]})
+	assert.Equal(t, text.String(), strings.Join(rest, ""))
+}
+
+func Test_ResultBudget_UnknownToken(t *testing.T) {
+	budget := NewResultBudget(100, WithContinuationTTL(time.Minute))
+	_, handler := ContinueResult(budget, translations.NullTranslationHelper)
+
+	args := map[string]any{"continuation_token": "unknown"}
+	request := createMCPRequest(args)
+	result, _, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "unknown or expired continuation token")
+}
+
+func Test_ResultBudget_ExpiredToken(t *testing.T) {
+	now := time.Now()
+	budget := NewResultBudget(10, WithContinuationTTL(time.Minute))
+	budget.now = func() time.Time { return now }
+
+	token, err := budget.store(nil, []string{"second", "third"})
+	require.NoError(t, err)
+
+	_, part, total, ok := budget.nextChunk(nil, token)
+	require.True(t, ok)
+	assert.Equal(t, 2, part)
+	assert.Equal(t, 3, total)
+
+	now = now.Add(2 * time.Minute)
+	_, _, _, ok = budget.nextChunk(nil, token)
+	assert.False(t, ok)
+}
+
+func Test_ResultBudget_MaxPendingResults(t *testing.T) {
+	budget := NewResultBudget(10, WithMaxPendingResults(2))
+
+	first, err := budget.store(nil, []string{"a"})
+	require.NoError(t, err)
+	_, err = budget.store(nil, []string{"b"})
+	require.NoError(t, err)
+	_, err = budget.store(nil, []string{"c"})
+	require.NoError(t, err)
+
+	assert.Len(t, budget.pending, 2)
+	_, _, _, ok := budget.nextChunk(nil, first)
+	assert.False(t, ok, "the result that expires first is dropped")
+}
+
+func Test_ResultBudget_OtherSession(t *testing.T) {
+	budget := NewResultBudget(10, WithContinuationTTL(time.Minute))
+	_, handler := ContinueResult(budget, translations.NullTranslationHelper)
+	session, other := &mcp.ServerSession{}, &mcp.ServerSession{}
+
+	token, err := budget.store(session, []string{"second", "third"})
+	require.NoError(t, err)
+
+	args := map[string]any{"continuation_token": token}
+	for _, s := range []*mcp.ServerSession{other, nil} {
+		request := createMCPRequest(args)
+		request.Session = s
+		result, _, err := handler(context.Background(), &request, args)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "unknown or expired continuation token")
+	}
+
+	// The session that called the tool still gets every part
+	request := createMCPRequest(args)
+	request.Session = session
+	result, _, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "second", result.Content[0].(*mcp.TextContent).Text)
+}
+
+func Test_Pack_InvalidUTF8(t *testing.T) {
+	// A run of continuation bytes has no character boundary, so it is cut at the budget
+	unit := strings.Repeat("\x80", 10)
+	chunks := pack([]string{unit}, 4)
+	assert.Equal(t, []string{"\x80\x80\x80\x80", "\x80\x80\x80\x80", "\x80\x80"}, chunks)
+}
+
+func Test_ResultBudget_StructuredContent(t *testing.T) {
+	t.Run("dropped when it has no array to cut", func(t *testing.T) {
+		content := strings.Repeat("line of code\n", 200)
+		budget := NewResultBudget(100)
+		result := callWithBudget(t, budget, &mcp.CallToolResult{
+			Content:           []mcp.Content{&mcp.TextContent{Text: content}},
+			StructuredContent: map[string]any{"path": "main.go", "content": content},
+		})
+
+		assert.Nil(t, result.StructuredContent)
+		assert.Equal(t, content, strings.Join(continueAll(t, budget, result), ""))
+	})
+
+	t.Run("cut when the text fits", func(t *testing.T) {
+		var items []map[string]any
+		for i := range 100 {
+			items = append(items, map[string]any{"number": i, "title": fmt.Sprintf("Issue %d", i)})
+		}
+		budget := NewResultBudget(100)
+		text := &mcp.TextContent{Text: "| number |\n| --- |\n| 1 |"}
+		result := callWithBudget(t, budget, &mcp.CallToolResult{
+			Content:           []mcp.Content{text},
+			StructuredContent: map[string]any{"items": items},
+		})
+
+		// The text is returned whole, so there is nothing to continue
+		require.Len(t, result.Content, 1)
+		assert.Same(t, text, result.Content[0])
+		structured, err := json.Marshal(result.StructuredContent)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(structured), 100*bytesPerToken)
+	})
+}
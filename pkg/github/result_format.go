@@ -0,0 +1,229 @@
+package github
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FormatParam is the name of the parameter selecting the format of list results.
+const FormatParam = "format"
+
+// Formats of list results.
+const (
+	ResultFormatJSON     = "json"
+	ResultFormatMarkdown = "markdown"
+	ResultFormatCSV      = "csv"
+)
+
+// Column is a column of the table that list results are rendered as in the markdown and CSV formats. Path selects
+// the value of the column in each item, with the same syntax as the fields parameter. Values of nested lists,
+// such as the names of labels, are joined.
+type Column struct {
+	Header string
+	Path   string
+}
+
+// WithResultFormat adds the format parameter to a list tool.
+func WithResultFormat(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties[FormatParam] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Format of the results: json (default), or a compact markdown or csv table of the main fields",
+		Enum:        []any{ResultFormatJSON, ResultFormatMarkdown, ResultFormatCSV},
+	}
+
+	return schema
+}
+
+// OptionalResultFormat returns the format of list results requested by the format parameter, json by default.
+func OptionalResultFormat(args map[string]any) (string, error) {
+	format, err := OptionalParam[string](args, FormatParam)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case "":
+		return ResultFormatJSON, nil
+	case ResultFormatJSON, ResultFormatMarkdown, ResultFormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid format %q, must be one of json, markdown or csv", format)
+	}
+}
+
+// FormatListResult returns the text result of a list tool in the requested format. r is the JSON result, and list
+// is the path of the field holding the items, or empty if the result is the list itself. In the markdown and CSV
+// formats, the items are rendered as a table of the given columns, and the other fields of the result, such as
+// pagination details, are returned as JSON in a second text content. The structured content of the result is
+// then set to the result with only the fields of the columns in its items, so that the output of the tool, which
+// has every field, is not returned as well.
+func FormatListResult(r []byte, format string, list string, columns []Column) (*mcp.CallToolResult, error) {
+	if format == ResultFormatJSON {
+		return utils.NewToolResultText(string(r)), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(r))
+	// Keep numbers, such as IDs, as they are
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, fmt.Errorf("failed to decode result: %w", err)
+	}
+
+	var items []any
+	var rest map[string]any
+	if list == "" {
+		items, _ = v.([]any)
+	} else if object, ok := v.(map[string]any); ok {
+		path := strings.Split(list, ".")
+		rest = make(map[string]any, len(object))
+		for key, value := range object {
+			if key != path[0] {
+				rest[key] = value
+			}
+		}
+
+		var value any = object
+		for _, key := range path {
+			m, _ := value.(map[string]any)
+			value = m[key]
+		}
+		items, _ = value.([]any)
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, 0, len(columns))
+		for _, column := range columns {
+			row = append(row, columnValue(item, strings.Split(column.Path, ".")))
+		}
+		rows = append(rows, row)
+	}
+
+	var table string
+	var err error
+	switch format {
+	case ResultFormatMarkdown:
+		table = markdownTable(columns, rows)
+	case ResultFormatCSV:
+		table, err = csvTable(columns, rows)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := utils.NewToolResultText(table)
+	paths := make([][]string, 0, len(columns))
+	for _, column := range columns {
+		paths = append(paths, strings.Split(column.Path, "."))
+	}
+	if list == "" {
+		// As structured content must be an object, lists are wrapped like the outputs of tools
+		result.StructuredContent = map[string]any{"items": ProjectFields(items, paths)}
+	} else {
+		result.StructuredContent = replaceList(v, strings.Split(list, "."), ProjectFields(items, paths))
+	}
+	if len(rest) > 0 {
+		r, err := json.Marshal(rest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+		result.Content = append(result.Content, &mcp.TextContent{Text: string(r)})
+	}
+	return result, nil
+}
+
+// replaceList returns a copy of a decoded JSON value with the list at path replaced by items.
+func replaceList(value any, path []string, items any) any {
+	if len(path) == 0 {
+		return items
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	replaced := maps.Clone(object)
+	replaced[path[0]] = replaceList(object[path[0]], path[1:], items)
+	return replaced
+}
+
+// columnValue returns the text of the value at path in a decoded JSON item. Values found in lists are joined.
+func columnValue(item any, path []string) string {
+	switch v := item.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, element := range v {
+			if value := columnValue(element, path); value != "" {
+				values = append(values, value)
+			}
+		}
+		return strings.Join(values, ", ")
+	case map[string]any:
+		if len(path) == 0 {
+			r, _ := json.Marshal(v)
+			return string(r)
+		}
+		return columnValue(v[path[0]], path[1:])
+	case nil:
+		return ""
+	default:
+		if len(path) > 0 {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+func markdownTable(columns []Column, rows [][]string) string {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" ")
+			b.WriteString(sanitize.EscapeMarkdownTableCell(cell))
+			b.WriteString(" |")
+		}
+		b.WriteString("\n")
+	}
+
+	headers := make([]string, 0, len(columns))
+	separators := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.Header)
+		separators = append(separators, "---")
+	}
+	writeRow(headers)
+	writeRow(separators)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+func csvTable(columns []Column, rows [][]string) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.Header)
+	}
+	if err := w.Write(headers); err != nil {
+		return "", fmt.Errorf("failed to write csv: %w", err)
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return "", fmt.Errorf("failed to write csv: %w", err)
+	}
+	return b.String(), nil
+}
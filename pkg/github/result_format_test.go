@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OptionalResultFormat(t *testing.T) {
+	format, err := OptionalResultFormat(map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, ResultFormatJSON, format)
+
+	format, err = OptionalResultFormat(map[string]any{"format": "csv"})
+	require.NoError(t, err)
+	assert.Equal(t, ResultFormatCSV, format)
+
+	_, err = OptionalResultFormat(map[string]any{"format": "xml"})
+	require.Error(t, err)
+}
+
+func Test_FormatListResult(t *testing.T) {
+	issues := []*github.Issue{
+		{
+			Number: github.Ptr(1),
+			Title:  github.Ptr("Fix | bug\n| --- |"),
+			State:  github.Ptr("open"),
+			User:   &github.User{Login: github.Ptr("octocat")},
+			Labels: []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+		},
+		{
+			Number: github.Ptr(2),
+			Title:  github.Ptr(`Quote "this", please`),
+			State:  github.Ptr("closed"),
+		},
+	}
+	columns := []Column{
+		{Header: "number", Path: "number"},
+		{Header: "title", Path: "title"},
+		{Header: "author", Path: "user.login"},
+		{Header: "labels", Path: "labels.name"},
+	}
+
+	list, err := json.Marshal(issues)
+	require.NoError(t, err)
+	wrapped, err := json.Marshal(map[string]any{
+		"issues":     issues,
+		"totalCount": 2,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		result             []byte
+		format             string
+		list               string
+		expectedText       string
+		expectedRest       string
+		expectedStructured string
+	}{
+		{
+			name:         "json is unchanged",
+			result:       list,
+			format:       ResultFormatJSON,
+			expectedText: string(list),
+		},
+		{
+			name:   "markdown",
+			result: list,
+			format: ResultFormatMarkdown,
+			expectedText: "| number | title | author | labels |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1 | Fix \\| bug \\| --- \\| | octocat | bug, p1 |\n" +
+				"| 2 | Quote \"this\", please |  |  |\n",
+			expectedStructured: `{"items":[{"number":1,"title":"Fix | bug\n| --- |","user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"p1"}]},{"number":2,"title":"Quote \"this\", please"}]}`,
+		},
+		{
+			name:   "csv",
+			result: list,
+			format: ResultFormatCSV,
+			expectedText: "number,title,author,labels\n" +
+				"1,\"Fix | bug\n| --- |\",octocat,\"bug, p1\"\n" +
+				"2,\"Quote \"\"this\"\", please\",,\n",
+			expectedStructured: `{"items":[{"number":1,"title":"Fix | bug\n| --- |","user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"p1"}]},{"number":2,"title":"Quote \"this\", please"}]}`,
+		},
+		{
+			name:   "items of an object",
+			result: wrapped,
+			format: ResultFormatMarkdown,
+			list:   "issues",
+			expectedText: "| number | title | author | labels |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1 | Fix \\| bug \\| --- \\| | octocat | bug, p1 |\n" +
+				"| 2 | Quote \"this\", please |  |  |\n",
+			expectedRest:       `{"totalCount":2}`,
+			expectedStructured: `{"issues":[{"number":1,"title":"Fix | bug\n| --- |","user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"p1"}]},{"number":2,"title":"Quote \"this\", please"}],"totalCount":2}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := FormatListResult(tc.result, tc.format, tc.list, columns)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedText, result.Content[0].(*mcp.TextContent).Text)
+			// Only the columns are left in the structured content of tables
+			if tc.expectedStructured == "" {
+				assert.Nil(t, result.StructuredContent)
+			} else {
+				structured, err := json.Marshal(result.StructuredContent)
+				require.NoError(t, err)
+				assert.JSONEq(t, tc.expectedStructured, string(structured))
+			}
+			if tc.expectedRest == "" {
+				assert.Len(t, result.Content, 1)
+				return
+			}
+			require.Len(t, result.Content, 2)
+			assert.JSONEq(t, tc.expectedRest, result.Content[1].(*mcp.TextContent).Text)
+		})
+	}
+}
+
+func Test_ListBranches_Format(t *testing.T) {
+	mockBranches := []*github.Branch{
+		{Name: github.Ptr("main"), Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")}, Protected: github.Ptr(true)},
+		{Name: github.Ptr("develop"), Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepo, mockBranches),
+	))
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	args := map[string]any{"owner": "owner", "repo": "repo", "format": "markdown"}
+	request := createMCPRequest(args)
+	result, output, err := handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	expected := "| name | sha | protected |\n" +
+		"| --- | --- | --- |\n" +
+		"| main | abc123 | true |\n" +
+		"| develop | def456 | false |\n"
+	assert.Equal(t, expected, getTextResult(t, result).Text)
+
+	// The output is not affected by the format, but the structured content only has the columns
+	require.Len(t, output, 2)
+	assert.Equal(t, "main", output[0].Name)
+	structured, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"items":[{"name":"main","sha":"abc123","protected":true},{"name":"develop","sha":"def456","protected":false}]}`, string(structured))
+
+	args["format"] = "xml"
+	request = createMCPRequest(args)
+	result, _, err = handler(context.Background(), &request, args)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getErrorResult(t, result).Text, "invalid format")
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultUserContentFields are the paths of the fields of JSON results holding user-authored text, which are
+// sanitized for toolsets that do not set their own with SetUserContentFields. A path is a dot-separated list of
+// object keys from the result, where "*" matches any key and arrays are walked through. They cover the titles and
+// bodies of issues, pull requests, comments, reviews, discussions and releases, whether returned alone, in a list
+// or in a wrapper object such as a search result, as well as commit messages, notification subjects and
+// descriptions.
+var DefaultUserContentFields = []string{
+	"title", "body", "message", "description",
+	"*.title", "*.body", "*.description",
+	"commit.message", "*.commit.message",
+	"subject.title",
+}
+
+// TextUserContentFields are the user content fields that may hold code, such as bodies, commit messages and gist
+// files, matched by the last key of their path. They are sanitized with Sanitizer.SanitizeText, which doesn't
+// escape HTML, while the other fields are markdown sanitized with Sanitizer.Sanitize. Bodies also lose the
+// suspicious metadata of their code fences.
+var TextUserContentFields = []string{"body", "message", "content"}
+
+// UserContentFields returns the fields sanitized in the results of each tool of tsg, keyed by tool name.
+func UserContentFields(tsg *toolsets.ToolsetGroup) map[string][]string {
+	fields := make(map[string][]string)
+	for _, toolset := range tsg.Toolsets {
+		toolsetFields := toolset.GetUserContentFields()
+		if toolsetFields == nil {
+			toolsetFields = DefaultUserContentFields
+		}
+		for _, tool := range toolset.GetAvailableTools() {
+			fields[tool.Tool.Name] = toolsetFields
+		}
+	}
+	return fields
+}
+
+// SanitizationMiddleware sanitizes the user-authored text of tool results, so that tools do not have to. The
+// string values at the given field paths of each tool are sanitized in JSON text and structured
+// content, and tables of list results in the markdown and CSV formats are sanitized as a whole. Other text, such
+// as file contents, logs or diffs, is returned unchanged, as are the results of tools without fields.
+// Tools return user-authored text as it is, so servers must add this middleware.
+func SanitizationMiddleware(sanitizer *sanitize.Sanitizer, fieldsByTool map[string][]string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil || method != "tools/call" {
+				return result, err
+			}
+
+			callToolRequest, ok := req.(*mcp.CallToolRequest)
+			if !ok || callToolRequest.Params == nil {
+				return result, nil
+			}
+			fields := fieldsByTool[callToolRequest.Params.Name]
+			if len(fields) == 0 {
+				return result, nil
+			}
+			callToolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || callToolResult.IsError {
+				return result, nil
+			}
+
+			var args map[string]any
+			_ = json.Unmarshal(callToolRequest.Params.Arguments, &args)
+			format, _ := OptionalParam[string](args, FormatParam)
+			table := format == ResultFormatMarkdown || format == ResultFormatCSV
+
+			changed := false
+			content := make([]mcp.Content, 0, len(callToolResult.Content))
+			for _, c := range callToolResult.Content {
+				text, ok := c.(*mcp.TextContent)
+				if !ok {
+					content = append(content, c)
+					continue
+				}
+				sanitized, isJSON := sanitizeJSONText(sanitizer, text.Text, fields)
+				if !isJSON && table {
+					sanitized = sanitizer.Sanitize(text.Text)
+				}
+				if sanitized == text.Text {
+					content = append(content, c)
+					continue
+				}
+				content = append(content, &mcp.TextContent{Text: sanitized, Meta: text.Meta, Annotations: text.Annotations})
+				changed = true
+			}
+			structuredContent, structuredChanged := sanitizeStructuredContent(sanitizer, callToolResult.StructuredContent, fields)
+			if !changed && !structuredChanged {
+				return result, nil
+			}
+
+			return &mcp.CallToolResult{
+				Meta:              callToolResult.Meta,
+				Content:           content,
+				StructuredContent: structuredContent,
+			}, nil
+		}
+	}
+}
+
+// sanitizeJSONText sanitizes the fields of JSON text, reporting false if text is not a JSON object or array.
+func sanitizeJSONText(sanitizer *sanitize.Sanitizer, text string, fields []string) (string, bool) {
+	v, ok := decodeJSONValue([]byte(text))
+	if !ok {
+		return text, false
+	}
+	if !sanitizeFields(sanitizer, v, fields) {
+		return text, true
+	}
+	r, err := json.Marshal(v)
+	if err != nil {
+		return text, true
+	}
+	return string(r), true
+}
+
+// sanitizeStructuredContent returns structured content with its fields sanitized, as a decoded JSON value when
+// any of them changed.
+func sanitizeStructuredContent(sanitizer *sanitize.Sanitizer, structuredContent any, fields []string) (any, bool) {
+	if structuredContent == nil {
+		return nil, false
+	}
+	r, err := json.Marshal(structuredContent)
+	if err != nil {
+		return structuredContent, false
+	}
+	v, ok := decodeJSONValue(r)
+	if !ok || !sanitizeFields(sanitizer, v, fields) {
+		return structuredContent, false
+	}
+	return v, true
+}
+
+func decodeJSONValue(data []byte) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep numbers, such as IDs, as they are
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return nil, false
+	}
+	switch v.(type) {
+	case map[string]any, []any:
+		return v, true
+	default:
+		return nil, false
+	}
+}
+
+// sanitizeFields sanitizes in place the string values at the given field paths of a decoded JSON value,
+// reporting whether any of them changed.
+func sanitizeFields(sanitizer *sanitize.Sanitizer, v any, fields []string) bool {
+	changed := false
+	for _, field := range fields {
+		if sanitizePath(sanitizer, v, strings.Split(field, ".")) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// sanitizePath sanitizes in place the string values at path of a decoded JSON value, walking through arrays.
+func sanitizePath(sanitizer *sanitize.Sanitizer, v any, path []string) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if path[0] != "*" && path[0] != key {
+				continue
+			}
+			if len(path) > 1 {
+				if sanitizePath(sanitizer, value, path[1:]) {
+					changed = true
+				}
+				continue
+			}
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			if sanitized := sanitizeField(sanitizer, key, s); sanitized != s {
+				v[key] = sanitized
+				changed = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if sanitizePath(sanitizer, item, path) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// sanitizeField sanitizes the value of a user content field named key.
+func sanitizeField(sanitizer *sanitize.Sanitizer, key, value string) string {
+	switch {
+	case key == "body":
+		return sanitizer.SanitizeText(sanitize.FilterCodeFenceMetadata(value))
+	case slices.Contains(TextUserContentFields, key):
+		return sanitizer.SanitizeText(value)
+	default:
+		return sanitizer.Sanitize(value)
+	}
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/toolsets"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v79/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SanitizationMiddleware(t *testing.T) {
+	fieldsByTool := map[string][]string{
+		"get_issue": DefaultUserContentFields,
+		"get_gist":  {"description", "files.*.content"},
+	}
+
+	tests := []struct {
+		name               string
+		tool               string
+		args               map[string]any
+		result             *mcp.CallToolResult
+		expectedContent    []string
+		expectedStructured string
+		expectUnchanged    bool
+	}{
+		{
+			name: "JSON object",
+			tool: "get_issue",
+			result: &mcp.CallToolResult{
+				Content:           []mcp.Content{&mcp.TextContent{Text: `{"number":9007199254740993,"title":"Bug<script>alert(1)</script>","body":"Hidden​text","user":{"login":"<b>octocat</b>"}}`}},
+				StructuredContent: map[string]any{"number": 1, "title": "Bug<script>alert(1)</script>"},
+			},
+			expectedContent: []string{
+				`{"number":9007199254740993,"title":"Bug","body":"Hiddentext","user":{"login":"<b>octocat</b>"}}`,
+			},
+			expectedStructured: `{"number":1,"title":"Bug"}`,
+		},
+		{
+			name:   "links outside the allowed hosts",
+			tool:   "get_issue",
+			result: utils.NewToolResultText(`{"body":"![diagram](https://ghes.example.com/d.png) ![x](https://attacker.example/x.png)"}`),
+			expectedContent: []string{
+				`{"body":"![diagram](https://ghes.example.com/d.png) [image removed: x]"}`,
+			},
+		},
+		{
+			name:   "nested fields of a list",
+			tool:   "get_gist",
+			result: utils.NewToolResultText(`[{"description":"<b>kept</b>","files":{"main.go":{"content":"![x](https://attacker.example/x.png)"}}}]`),
+			expectedContent: []string{
+				`[{"description":"<b>kept</b>","files":{"main.go":{"content":"[image removed: x]"}}}]`,
+			},
+		},
+		{
+			name:   "wrapped lists and commits",
+			tool:   "get_issue",
+			result: utils.NewToolResultText(`{"items":[{"title":"<b>Bug</b>"}],"commits":[{"commit":{"message":"![x](https://attacker.example/x.png)"}}]}`),
+			expectedContent: []string{
+				`{"items":[{"title":"<b>Bug</b>"}],"commits":[{"commit":{"message":"[image removed: x]"}}]}`,
+			},
+		},
+		{
+			name:            "fields at other paths are not sanitized",
+			tool:            "get_issue",
+			result:          utils.NewToolResultText(`{"title":"Bug","head":{"repo":{"title":"<script>kept</script>","body":"![x](https://attacker.example/x.png)"}}}`),
+			expectUnchanged: true,
+		},
+		{
+			name:            "code in a body",
+			tool:            "get_issue",
+			result:          utils.NewToolResultText(`{"title":"Fix the parser","body":"Use ` + "`a && b`" + ` with ` + "`List<T>`" + `:\n` + "```go\nif a && b {\n\treturn New[T]()\n}\n```" + `"}`),
+			expectUnchanged: true,
+		},
+		{
+			name: "table format",
+			tool: "get_issue",
+			args: map[string]any{"format": "markdown"},
+			result: &mcp.CallToolResult{Content: []mcp.Content{
+				&mcp.TextContent{Text: "| Title |\n| --- |\n| Bug<script>alert(1)</script> |\n"},
+				&mcp.TextContent{Text: `{"pageInfo":{"hasNextPage":false}}`},
+			}},
+			expectedContent: []string{
+				"| Title |\n| --- |\n| Bug |\n",
+				`{"pageInfo":{"hasNextPage":false}}`,
+			},
+		},
+		{
+			name:            "plain text is not sanitized",
+			tool:            "get_issue",
+			result:          utils.NewToolResultText("<b>file contents</b>"),
+			expectUnchanged: true,
+		},
+		{
+			name:            "nothing to sanitize",
+			tool:            "get_issue",
+			result:          utils.NewToolResultText(`{"title":"Fix the parser","body":"It fails on empty input"}`),
+			expectUnchanged: true,
+		},
+		{
+			name:            "tool without fields",
+			tool:            "continue_result",
+			result:          utils.NewToolResultText(`{"title":"<script>alert(1)</script>"}`),
+			expectUnchanged: true,
+		},
+		{
+			name:            "error result",
+			tool:            "get_issue",
+			result:          utils.NewToolResultError(`{"title":"<script>alert(1)</script>"}`),
+			expectUnchanged: true,
+		},
+	}
+
+	sanitizer := sanitize.NewSanitizer(append([]string{"ghes.example.com"}, sanitize.DefaultAllowedLinkHosts...))
+	middleware := SanitizationMiddleware(sanitizer, fieldsByTool)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+				return tc.result, nil
+			}
+
+			request := createMCPRequest(tc.args)
+			request.Params.Name = tc.tool
+			result, err := middleware(next)(context.Background(), "tools/call", &request)
+			require.NoError(t, err)
+
+			callToolResult, ok := result.(*mcp.CallToolResult)
+			require.True(t, ok)
+			if tc.expectUnchanged {
+				assert.Same(t, tc.result, callToolResult)
+				return
+			}
+
+			require.Len(t, callToolResult.Content, len(tc.expectedContent))
+			for i, expected := range tc.expectedContent {
+				content, ok := callToolResult.Content[i].(*mcp.TextContent)
+				require.True(t, ok)
+				if expected[0] == '{' || expected[0] == '[' {
+					assert.JSONEq(t, expected, content.Text)
+				} else {
+					assert.Equal(t, expected, content.Text)
+				}
+			}
+
+			if tc.expectedStructured == "" {
+				assert.Nil(t, callToolResult.StructuredContent)
+				return
+			}
+			structured, err := json.Marshal(callToolResult.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedStructured, string(structured))
+		})
+	}
+}
+
+func Test_UserContentFields(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), nil, translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	fieldsByTool := UserContentFields(tsg)
+	assert.Equal(t, DefaultUserContentFields, fieldsByTool["issue_read"])
+	assert.Equal(t, DefaultUserContentFields, fieldsByTool["list_commits"])
+	assert.Contains(t, fieldsByTool["get_gist"], "files.*.content")
+	assert.Contains(t, fieldsByTool["get_gist"], "description")
+	assert.NotContains(t, DefaultUserContentFields, "files.*.content")
+
+	toolset := toolsets.NewToolset("quiet", "A toolset without sanitization").
+		AddReadTools(toolsets.NewServerTool(GetMe(stubGetClientFn(nil), translations.NullTranslationHelper))).
+		SetUserContentFields()
+	group := toolsets.NewToolsetGroup(false)
+	group.AddToolset(toolset)
+	assert.Empty(t, UserContentFields(group)["get_me"])
+}
+
+func Test_SanitizationMiddleware_GistCodeRoundTrips(t *testing.T) {
+	source := "<!DOCTYPE html>\n<script>\n  if (a < b && c > d) { handlers[0](\"it's\"); }\n</script>\n" +
+		"<!-- see [docs](https://github.com/owner/repo) -->\n<a href=\"/path?x=1&y=2\">link</a>\n"
+	gist := &github.Gist{
+		Description: github.Ptr("Snippets"),
+		Files: map[github.GistFilename]github.GistFile{
+			"index.html": {Filename: github.Ptr("index.html"), Content: github.Ptr(source)},
+		},
+	}
+	r, err := json.Marshal(gist)
+	require.NoError(t, err)
+	result := utils.NewToolResultText(string(r))
+
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), nil, translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+	middleware := SanitizationMiddleware(sanitize.NewSanitizer(sanitize.DefaultAllowedLinkHosts), UserContentFields(tsg))
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return result, nil
+	}
+
+	request := createMCPRequest(map[string]any{})
+	request.Params.Name = "get_gist"
+	sanitized, err := middleware(next)(context.Background(), "tools/call", &request)
+	require.NoError(t, err)
+
+	callToolResult, ok := sanitized.(*mcp.CallToolResult)
+	require.True(t, ok)
+	require.Len(t, callToolResult.Content, 1)
+	var returned github.Gist
+	require.NoError(t, json.Unmarshal([]byte(callToolResult.Content[0].(*mcp.TextContent).Text), &returned))
+	file := returned.Files["index.html"]
+	assert.Equal(t, source, file.GetContent())
+}
@@ -0,0 +1,50 @@
+package github
+
+// toolsetScopes lists the classic personal access token scopes needed by the tools in each toolset.
+// Fine-grained tokens and GitHub Apps use permissions instead, which are not captured here.
+var toolsetScopes = map[string][]string{
+	ToolsetMetadataContext.ID:            {"read:org"},
+	ToolsetMetadataRepos.ID:              {"repo"},
+	ToolsetMetadataGit.ID:                {"repo"},
+	ToolsetMetadataIssues.ID:             {"repo"},
+	ToolsetMetadataPullRequests.ID:       {"repo"},
+	ToolsetMetadataUsers.ID:              {},
+	ToolsetMetadataOrgs.ID:               {},
+	ToolsetMetadataActions.ID:            {"repo"},
+	ToolsetMetadataCodeSecurity.ID:       {"security_events"},
+	ToolsetMetadataSecretProtection.ID:   {"security_events"},
+	ToolsetMetadataDependabot.ID:         {"security_events"},
+	ToolsetMetadataNotifications.ID:      {"notifications"},
+	ToolsetMetadataExperiments.ID:        {},
+	ToolsetMetadataDiscussions.ID:        {"repo"},
+	ToolsetMetadataGists.ID:              {"gist"},
+	ToolsetMetadataSecurityAdvisories.ID: {"security_events"},
+	ToolsetMetadataProjects.ID:           {"read:project"},
+	ToolsetMetadataStargazers.ID:         {"repo"},
+	ToolsetMetadataDynamic.ID:            {},
+	ToolsetLabels.ID:                     {"repo"},
+	ToolsetMetadataIssueDependencies.ID:  {"repo"},
+}
+
+// toolScopes overrides the toolset scopes for tools that need more, or less, than the rest of their toolset.
+var toolScopes = map[string][]string{
+	"get_me":                          {},
+	"search_repositories":             {},
+	"search_code":                     {},
+	"list_starred_repositories":       {},
+	"list_global_security_advisories": {},
+	"get_global_security_advisory":    {},
+	"add_project_item":                {"project"},
+	"update_project_item":             {"project"},
+	"delete_project_item":             {"project"},
+}
+
+// RequiredScopes returns the classic personal access token scopes that a tool needs.
+// The second return value is false if no scopes are known for the toolset.
+func RequiredScopes(toolsetID, toolName string) ([]string, bool) {
+	if scopes, ok := toolScopes[toolName]; ok {
+		return scopes, true
+	}
+	scopes, ok := toolsetScopes[toolsetID]
+	return scopes, ok
+}
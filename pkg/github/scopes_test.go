@@ -0,0 +1,37 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	// Every toolset must declare its scopes, so that new toolsets are not exported without them
+	for _, toolset := range AvailableTools() {
+		_, ok := RequiredScopes(toolset.ID, "")
+		assert.True(t, ok, "toolset %s has no required scopes", toolset.ID)
+	}
+
+	// Tool overrides must refer to tools that exist
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+	for toolName := range toolScopes {
+		_, _, err := tsg.FindToolByName(toolName)
+		require.NoError(t, err, "scope override for unknown tool %s", toolName)
+	}
+
+	scopes, ok := RequiredScopes(ToolsetMetadataProjects.ID, "list_projects")
+	require.True(t, ok)
+	assert.Equal(t, []string{"read:project"}, scopes)
+
+	scopes, ok = RequiredScopes(ToolsetMetadataProjects.ID, "add_project_item")
+	require.True(t, ok)
+	assert.Equal(t, []string{"project"}, scopes)
+
+	_, ok = RequiredScopes("nonexistent", "nonexistent")
+	assert.False(t, ok)
+}
@@ -51,7 +51,8 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				Title:        t("TOOL_SEARCH_REPOSITORIES_USER_TITLE", "Search repositories"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithWrappedListFieldProjection(schema, "items", "full_name"),
+			OutputSchema: AlternativeOutputSchema(ProjectedOutputSchema[*MinimalSearchRepositoriesResult](), ProjectedOutputSchema[*github.RepositoriesSearchResult]()),
 		},
 		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
 			query, err := RequiredParam[string](args, "query")
@@ -107,6 +108,7 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 
 			// Return either minimal or full response based on parameter
 			var r []byte
+			var output any
 			if minimalOutput {
 				minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
 				for _, repo := range result.Repositories {
@@ -149,19 +151,21 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				if err != nil {
 					return utils.NewToolResultErrorFromErr("failed to marshal minimal response", err), nil, nil
 				}
+				output = minimalResult
 			} else {
 				r, err = json.Marshal(result)
 				if err != nil {
 					return utils.NewToolResultErrorFromErr("failed to marshal full response", err), nil, nil
 				}
+				output = result
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), output, nil
 		}
 }
 
 // SearchCode creates a tool to search for code across GitHub repositories.
-func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.CodeSearchResult]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -190,9 +194,10 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 				Title:        t("TOOL_SEARCH_CODE_USER_TITLE", "Search code"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: schema,
+			InputSchema:  WithWrappedListFieldProjection(schema, "items", "path", "repository.full_name"),
+			OutputSchema: ProjectedOutputSchema[*github.CodeSearchResult](),
 		},
-		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+		func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.CodeSearchResult, error) {
 			query, err := RequiredParam[string](args, "query")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -247,12 +252,12 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 				return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
 			}
 
-			return utils.NewToolResultText(string(r)), nil, nil
+			return utils.NewToolResultText(string(r)), result, nil
 		}
 }
 
-func userOrOrgHandler(accountType string, getClient GetClientFn) mcp.ToolHandlerFor[map[string]any, any] {
-	return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+func userOrOrgHandler(accountType string, getClient GetClientFn) mcp.ToolHandlerFor[map[string]any, *MinimalSearchUsersResult] {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *MinimalSearchUsersResult, error) {
 		query, err := RequiredParam[string](args, "query")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -335,12 +340,12 @@ func userOrOrgHandler(accountType string, getClient GetClientFn) mcp.ToolHandler
 		if err != nil {
 			return utils.NewToolResultErrorFromErr("failed to marshal response", err), nil, nil
 		}
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), minimalResp, nil
 	}
 }
 
 // SearchUsers creates a tool to search for GitHub users.
-func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalSearchUsersResult]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -370,12 +375,13 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (m
 			Title:        t("TOOL_SEARCH_USERS_USER_TITLE", "Search users"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: schema,
+		InputSchema:  WithWrappedListFieldProjection(schema, "items", "login"),
+		OutputSchema: ProjectedOutputSchema[*MinimalSearchUsersResult](),
 	}, userOrOrgHandler("user", getClient)
 }
 
 // SearchOrgs creates a tool to search for GitHub organizations.
-func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *MinimalSearchUsersResult]) {
 	schema := &jsonschema.Schema{
 		Type: "object",
 		Properties: map[string]*jsonschema.Schema{
@@ -405,6 +411,7 @@ func SearchOrgs(getClient GetClientFn, t translations.TranslationHelperFunc) (mc
 			Title:        t("TOOL_SEARCH_ORGS_USER_TITLE", "Search organizations"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: schema,
+		InputSchema:  WithWrappedListFieldProjection(schema, "items", "login"),
+		OutputSchema: ProjectedOutputSchema[*MinimalSearchUsersResult](),
 	}, userOrOrgHandler("org", getClient)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"regexp"
 
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/go-github/v79/github"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -39,13 +40,15 @@ func hasTypeFilter(query string) bool {
 func searchHandler(
 	ctx context.Context,
 	getClient GetClientFn,
+	cache *lockdown.RepoAccessCache,
+	flags FeatureFlags,
 	args map[string]any,
 	searchType string,
 	errorPrefix string,
-) (*mcp.CallToolResult, error) {
+) (*mcp.CallToolResult, any, error) {
 	query, err := RequiredParam[string](args, "query")
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 
 	if !hasSpecificFilter(query, "is", searchType) {
@@ -54,12 +57,12 @@ func searchHandler(
 
 	owner, err := OptionalParam[string](args, "owner")
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 
 	repo, err := OptionalParam[string](args, "repo")
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 
 	if owner != "" && repo != "" && !hasRepoFilter(query) {
@@ -68,15 +71,15 @@ func searchHandler(
 
 	sort, err := OptionalParam[string](args, "sort")
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 	order, err := OptionalParam[string](args, "order")
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 	pagination, err := OptionalPaginationParams(args)
 	if err != nil {
-		return utils.NewToolResultError(err.Error()), nil
+		return utils.NewToolResultError(err.Error()), nil, nil
 	}
 
 	opts := &github.SearchOptions{
@@ -91,26 +94,48 @@ func searchHandler(
 
 	client, err := getClient(ctx)
 	if err != nil {
-		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to get GitHub client", err), nil
+		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to get GitHub client", err), nil, nil
 	}
 	result, resp, err := client.Search.Issues(ctx, query, opts)
 	if err != nil {
-		return utils.NewToolResultErrorFromErr(errorPrefix, err), nil
+		return utils.NewToolResultErrorFromErr(errorPrefix, err), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return utils.NewToolResultErrorFromErr(errorPrefix+": failed to read response body", err), nil
+			return utils.NewToolResultErrorFromErr(errorPrefix+": failed to read response body", err), nil, nil
 		}
-		return utils.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil
+		return utils.NewToolResultError(fmt.Sprintf("%s: %s", errorPrefix, string(body))), nil, nil
 	}
 
-	r, err := json.Marshal(result)
+	// Search results span repositories, so each result is checked against its own repository
+	var response any = result
+	if flags.LockdownMode {
+		items, err := lockdownContent(ctx, cache, flags, result.Issues, func(issue *github.Issue) (string, string, string) {
+			owner, repo := repositoryFromAPIURL(issue.GetRepositoryURL())
+			return issue.GetUser().GetLogin(), owner, repo
+		}, "title", "body")
+		if err != nil {
+			return utils.NewToolResultError(fmt.Sprintf("%s: failed to check lockdown mode: %v", errorPrefix, err)), nil, nil
+		}
+		if issues, ok := items.([]*github.Issue); ok {
+			result.Issues = issues
+		} else {
+			// Redacted items no longer fit in an IssuesSearchResult
+			response = map[string]any{
+				"total_count":        result.GetTotal(),
+				"incomplete_results": result.GetIncompleteResults(),
+				"items":              items,
+			}
+		}
+	}
+
+	r, err := json.Marshal(response)
 	if err != nil {
-		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to marshal response", err), nil
+		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to marshal response", err), nil, nil
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return utils.NewToolResultText(string(r)), response, nil
 }
@@ -14,7 +14,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.GlobalSecurityAdvisory]) {
 	tool := mcp.Tool{
 		Name:        "list_global_security_advisories",
 		Description: t("TOOL_LIST_GLOBAL_SECURITY_ADVISORIES_DESCRIPTION", "List global security advisories from GitHub."),
@@ -22,7 +22,7 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 			Title:        t("TOOL_LIST_GLOBAL_SECURITY_ADVISORIES_USER_TITLE", "List global security advisories"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"ghsaId": {
@@ -77,10 +77,11 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 					Description: "Filter by publish or update date or date range (ISO 8601 date or range).",
 				},
 			},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[[]*github.GlobalSecurityAdvisory](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.GlobalSecurityAdvisory](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.GlobalSecurityAdvisory, error) {
 		client, err := getClient(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -198,13 +199,13 @@ func ListGlobalSecurityAdvisories(getClient GetClientFn, t translations.Translat
 			return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), advisories, nil
 	})
 
 	return tool, handler
 }
 
-func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.SecurityAdvisory]) {
 	tool := mcp.Tool{
 		Name:        "list_repository_security_advisories",
 		Description: t("TOOL_LIST_REPOSITORY_SECURITY_ADVISORIES_DESCRIPTION", "List repository security advisories for a GitHub repository."),
@@ -212,7 +213,7 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 			Title:        t("TOOL_LIST_REPOSITORY_SECURITY_ADVISORIES_USER_TITLE", "List repository security advisories"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"owner": {
@@ -240,10 +241,11 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 				},
 			},
 			Required: []string{"owner", "repo"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[[]*github.SecurityAdvisory](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.SecurityAdvisory](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.SecurityAdvisory, error) {
 		owner, err := RequiredParam[string](args, "owner")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -301,13 +303,13 @@ func ListRepositorySecurityAdvisories(getClient GetClientFn, t translations.Tran
 			return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), advisories, nil
 	})
 
 	return tool, handler
 }
 
-func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, *github.GlobalSecurityAdvisory]) {
 	tool := mcp.Tool{
 		Name:        "get_global_security_advisory",
 		Description: t("TOOL_GET_GLOBAL_SECURITY_ADVISORY_DESCRIPTION", "Get a global security advisory"),
@@ -315,7 +317,7 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 			Title:        t("TOOL_GET_GLOBAL_SECURITY_ADVISORY_USER_TITLE", "Get a global security advisory"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"ghsaId": {
@@ -324,10 +326,11 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 				},
 			},
 			Required: []string{"ghsaId"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[*github.GlobalSecurityAdvisory](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, *github.GlobalSecurityAdvisory](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, *github.GlobalSecurityAdvisory, error) {
 		client, err := getClient(ctx)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -357,13 +360,13 @@ func GetGlobalSecurityAdvisory(getClient GetClientFn, t translations.Translation
 			return nil, nil, fmt.Errorf("failed to marshal advisory: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), advisory, nil
 	})
 
 	return tool, handler
 }
 
-func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, any]) {
+func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, mcp.ToolHandlerFor[map[string]any, []*github.SecurityAdvisory]) {
 	tool := mcp.Tool{
 		Name:        "list_org_repository_security_advisories",
 		Description: t("TOOL_LIST_ORG_REPOSITORY_SECURITY_ADVISORIES_DESCRIPTION", "List repository security advisories for a GitHub organization."),
@@ -371,7 +374,7 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 			Title:        t("TOOL_LIST_ORG_REPOSITORY_SECURITY_ADVISORIES_USER_TITLE", "List org repository security advisories"),
 			ReadOnlyHint: true,
 		},
-		InputSchema: &jsonschema.Schema{
+		InputSchema: WithFieldProjection(&jsonschema.Schema{
 			Type: "object",
 			Properties: map[string]*jsonschema.Schema{
 				"org": {
@@ -395,10 +398,11 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 				},
 			},
 			Required: []string{"org"},
-		},
+		}),
+		OutputSchema: ProjectedOutputSchema[[]*github.SecurityAdvisory](),
 	}
 
-	handler := mcp.ToolHandlerFor[map[string]any, any](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+	handler := mcp.ToolHandlerFor[map[string]any, []*github.SecurityAdvisory](func(ctx context.Context, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, []*github.SecurityAdvisory, error) {
 		org, err := RequiredParam[string](args, "org")
 		if err != nil {
 			return utils.NewToolResultError(err.Error()), nil, nil
@@ -451,7 +455,7 @@ func ListOrgRepositorySecurityAdvisories(getClient GetClientFn, t translations.T
 			return nil, nil, fmt.Errorf("failed to marshal advisories: %w", err)
 		}
 
-		return utils.NewToolResultText(string(r)), nil, nil
+		return utils.NewToolResultText(string(r)), advisories, nil
 	})
 
 	return tool, handler
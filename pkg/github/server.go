@@ -5,9 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/github/github-mcp-server/pkg/toolsets"
 	"github.com/github/github-mcp-server/pkg/utils"
 	"github.com/google/go-github/v79/github"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -443,3 +447,210 @@ func MarshalledTextResult(v any) *mcp.CallToolResult {
 
 	return utils.NewToolResultText(string(data))
 }
+
+// outputSchemaOptions describes the types that do not marshal to JSON the way their Go structure suggests.
+var outputSchemaOptions = jsonschema.ForOptions{
+	TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+		reflect.TypeFor[github.Timestamp](): {Type: "string"},
+	},
+}
+
+// OutputSchema derives the output schema of a tool from the type its handler returns as output.
+// Types referred to through a cycle of fields, such as a team and its parent team, are described as plain
+// objects, and types referred to more than once, such as users, are described once under $defs. Objects may have
+// properties that their types do not describe, such as the "redacted" marker of items redacted by lockdown mode.
+// It panics if the type cannot be described, which the tool snapshot tests catch.
+func OutputSchema[Out any]() *jsonschema.Schema {
+	opts := jsonschema.ForOptions{TypeSchemas: maps.Clone(outputSchemaOptions.TypeSchemas)}
+	for _, t := range cyclicTypes(reflect.TypeFor[Out](), opts.TypeSchemas) {
+		opts.TypeSchemas[t] = &jsonschema.Schema{Type: "object"}
+	}
+
+	shared := sharedTypes(reflect.TypeFor[Out](), opts.TypeSchemas)
+	for _, t := range shared {
+		opts.TypeSchemas[t] = &jsonschema.Schema{Ref: "#/$defs/" + t.String()}
+	}
+	defs := make(map[string]*jsonschema.Schema, len(shared))
+	for _, t := range shared {
+		// A copy of the struct is described, as the type itself is described by its reference
+		def, err := jsonschema.ForType(structCopy(t), &opts)
+		if err != nil {
+			panic(err)
+		}
+		defs[t.String()] = def
+	}
+
+	schema, err := toolsets.OutputSchema[Out](&opts)
+	if err != nil {
+		panic(err)
+	}
+	if len(defs) > 0 {
+		schema.Defs = defs
+	}
+	allowAdditionalProperties(schema)
+	return schema
+}
+
+// ProjectedOutputSchema is the output schema of tools accepting the fields parameter, which can leave any
+// property out of the result, so no property is required.
+func ProjectedOutputSchema[Out any]() *jsonschema.Schema {
+	schema := OutputSchema[Out]()
+	removeRequired(schema)
+	return schema
+}
+
+// AlternativeOutputSchema combines the output schemas of a tool whose output depends on its parameters, such as
+// the method of issue_read, into one schema matching any of them.
+func AlternativeOutputSchema(schemas ...*jsonschema.Schema) *jsonschema.Schema {
+	combined := &jsonschema.Schema{Type: "object"}
+	for _, schema := range schemas {
+		// References are resolved from the root of the schema, so the definitions are moved there
+		for name, def := range schema.Defs {
+			if combined.Defs == nil {
+				combined.Defs = make(map[string]*jsonschema.Schema)
+			}
+			combined.Defs[name] = def
+		}
+		schema.Defs = nil
+		combined.AnyOf = append(combined.AnyOf, schema)
+	}
+	return combined
+}
+
+// elemType returns the type of the values that t holds, following pointers, slices, arrays and maps.
+func elemType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// describedByFields reports whether the schema of t is derived from its fields, which is not the case of types
+// marshalling themselves to JSON, such as time.Time.
+func describedByFields(t reflect.Type, known map[reflect.Type]*jsonschema.Schema) bool {
+	marshaler := reflect.TypeFor[json.Marshaler]()
+	return t.Kind() == reflect.Struct && known[t] == nil && !t.Implements(marshaler) && !reflect.PointerTo(t).Implements(marshaler)
+}
+
+// schemaFields returns the fields of a struct that are described by its schema.
+func schemaFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for _, field := range reflect.VisibleFields(t) {
+		if field.Anonymous || !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// structCopy returns an unnamed struct type with the same fields as t.
+func structCopy(t reflect.Type) reflect.Type {
+	var fields []reflect.StructField
+	for _, field := range schemaFields(t) {
+		fields = append(fields, reflect.StructField{Name: field.Name, Type: field.Type, Tag: field.Tag})
+	}
+	return reflect.StructOf(fields)
+}
+
+// cyclicTypes returns the struct types that t refers to through a cycle of fields, such as a team and its parent
+// team, skipping the types that already have a schema. Schemas derived from types cannot describe these cycles.
+func cyclicTypes(t reflect.Type, known map[reflect.Type]*jsonschema.Schema) []reflect.Type {
+	var cyclic, stack []reflect.Type
+	done := make(map[reflect.Type]bool)
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		t = elemType(t)
+		if !describedByFields(t, known) || done[t] {
+			return
+		}
+		if i := slices.Index(stack, t); i >= 0 {
+			// Every type between t and the field referring back to it is part of the cycle
+			for _, c := range stack[i:] {
+				if !slices.Contains(cyclic, c) {
+					cyclic = append(cyclic, c)
+				}
+			}
+			return
+		}
+
+		stack = append(stack, t)
+		for _, field := range schemaFields(t) {
+			visit(field.Type)
+		}
+		stack = stack[:len(stack)-1]
+		done[t] = true
+	}
+	visit(t)
+
+	return cyclic
+}
+
+// sharedTypes returns the named struct types that t refers to from more than one field, skipping the types
+// that already have a schema.
+func sharedTypes(t reflect.Type, known map[reflect.Type]*jsonschema.Schema) []reflect.Type {
+	var shared []reflect.Type
+	references := make(map[reflect.Type]int)
+
+	var visit func(t reflect.Type)
+	visit = func(t reflect.Type) {
+		for _, field := range schemaFields(t) {
+			ft := elemType(field.Type)
+			if !describedByFields(ft, known) || ft.Name() == "" {
+				continue
+			}
+			references[ft]++
+			if references[ft] == 2 {
+				shared = append(shared, ft)
+			}
+			if references[ft] == 1 {
+				visit(ft)
+			}
+		}
+	}
+	if root := elemType(t); describedByFields(root, known) {
+		visit(root)
+	}
+
+	return shared
+}
+
+// removeRequired removes the required properties of a schema and of all the schemas nested in it.
+func removeRequired(schema *jsonschema.Schema) {
+	walkSchema(schema, func(s *jsonschema.Schema) {
+		s.Required = nil
+	})
+}
+
+// allowAdditionalProperties lets the objects of a schema and of all the schemas nested in it have properties
+// that the schema does not describe.
+func allowAdditionalProperties(schema *jsonschema.Schema) {
+	walkSchema(schema, func(s *jsonschema.Schema) {
+		if s.AdditionalProperties != nil && s.AdditionalProperties.Not != nil && reflect.ValueOf(*s.AdditionalProperties.Not).IsZero() {
+			s.AdditionalProperties = nil
+		}
+	})
+}
+
+// walkSchema calls f for a schema and all the schemas nested in it.
+func walkSchema(schema *jsonschema.Schema, f func(*jsonschema.Schema)) {
+	if schema == nil {
+		return
+	}
+	f(schema)
+	for _, property := range schema.Properties {
+		walkSchema(property, f)
+	}
+	for _, def := range schema.Defs {
+		walkSchema(def, f)
+	}
+	for _, item := range schema.PrefixItems {
+		walkSchema(item, f)
+	}
+	for _, alternative := range slices.Concat(schema.AllOf, schema.AnyOf, schema.OneOf) {
+		walkSchema(alternative, f)
+	}
+	walkSchema(schema.Items, f)
+	walkSchema(schema.AdditionalProperties, f)
+}
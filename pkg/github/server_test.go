@@ -41,13 +41,13 @@ func stubGetGQLClientFn(client *githubv4.Client) GetGQLClientFn {
 }
 
 func stubRepoAccessCache(client *githubv4.Client, ttl time.Duration) *lockdown.RepoAccessCache {
-	cacheName := fmt.Sprintf("repo-access-cache-test-%d", time.Now().UnixNano())
-	return lockdown.GetInstance(client, lockdown.WithTTL(ttl), lockdown.WithCacheName(cacheName))
+	return lockdown.NewRepoAccessCache(client, lockdown.WithTTL(ttl))
 }
 
 func stubFeatureFlags(enabledFlags map[string]bool) FeatureFlags {
 	return FeatureFlags{
-		LockdownMode: enabledFlags["lockdown-mode"],
+		LockdownMode:   enabledFlags["lockdown-mode"],
+		LockdownRedact: enabledFlags["lockdown-redact"],
 	}
 }
 
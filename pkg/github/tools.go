@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/github/github-mcp-server/pkg/lockdown"
@@ -165,6 +166,8 @@ func GetDefaultToolsetIDs() []string {
 	}
 }
 
+// DefaultToolsetGroup returns the toolsets of the server. Its tools return user-authored text as it is, so the
+// server must sanitize their results with SanitizationMiddleware and the UserContentFields of the group.
 func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetGQLClientFn, getRawClient raw.GetRawClientFn, t translations.TranslationHelperFunc, contentWindowSize int, flags FeatureFlags, cache *lockdown.RepoAccessCache) *toolsets.ToolsetGroup {
 	tsg := toolsets.NewToolsetGroup(readOnly)
 
@@ -173,11 +176,16 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	repos := toolsets.NewToolset(ToolsetMetadataRepos.ID, ToolsetMetadataRepos.Description).
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
+			toolsets.NewServerTool(GetRepository(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, getRawClient, t)),
-			toolsets.NewServerTool(ListCommits(getClient, t)),
+			toolsets.NewServerTool(GetFileBlame(getGQLClient, cache, t, flags)),
+			toolsets.NewServerTool(ListCommits(getClient, cache, t, flags)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
-			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(GrepRepository(getClient, getRawClient, t)),
+			toolsets.NewServerTool(GetCommit(getClient, cache, t, flags)),
+			toolsets.NewServerTool(CompareRefs(getClient, cache, t, flags)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
+			toolsets.NewServerTool(GetBranchRules(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
 			toolsets.NewServerTool(ListReleases(getClient, t)),
@@ -187,10 +195,14 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
 			toolsets.NewServerTool(CreateRepository(getClient, t)),
+			toolsets.NewServerTool(UpdateRepository(getClient, t)),
 			toolsets.NewServerTool(ForkRepository(getClient, t)),
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
+			toolsets.NewServerTool(DeleteBranch(getClient, t)),
+			toolsets.NewServerTool(RenameBranch(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(ReleaseWrite(getClient, t)),
 		).
 		AddResourceTemplates(
 			toolsets.NewServerResourceTemplate(GetRepositoryResourceContent(getClient, getRawClient, t)),
@@ -202,12 +214,19 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	git := toolsets.NewToolset(ToolsetMetadataGit.ID, ToolsetMetadataGit.Description).
 		AddReadTools(
 			toolsets.NewServerTool(GetRepositoryTree(getClient, t)),
+			toolsets.NewServerTool(GetBlob(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateTag(getClient, t)),
+			toolsets.NewServerTool(CreateTree(getClient, t)),
+			toolsets.NewServerTool(CreateCommit(getClient, t)),
+			toolsets.NewServerTool(UpdateRef(getClient, t)),
 		)
 	issues := toolsets.NewToolset(ToolsetMetadataIssues.ID, ToolsetMetadataIssues.Description).
 		AddReadTools(
 			toolsets.NewServerTool(IssueRead(getClient, getGQLClient, cache, t, flags)),
-			toolsets.NewServerTool(SearchIssues(getClient, t)),
-			toolsets.NewServerTool(ListIssues(getGQLClient, t)),
+			toolsets.NewServerTool(SearchIssues(getClient, cache, t, flags)),
+			toolsets.NewServerTool(ListIssues(getGQLClient, cache, t, flags)),
 			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 			toolsets.NewServerTool(GetLabel(getGQLClient, t)),
 		).
@@ -231,8 +250,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	pullRequests := toolsets.NewToolset(ToolsetMetadataPullRequests.ID, ToolsetMetadataPullRequests.Description).
 		AddReadTools(
 			toolsets.NewServerTool(PullRequestRead(getClient, cache, t, flags)),
-			toolsets.NewServerTool(ListPullRequests(getClient, t)),
-			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
+			toolsets.NewServerTool(ListPullRequests(getClient, cache, t, flags)),
+			toolsets.NewServerTool(SearchPullRequests(getClient, cache, t, flags)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(MergePullRequest(getClient, t)),
@@ -262,8 +281,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 
 	notifications := toolsets.NewToolset(ToolsetMetadataNotifications.ID, ToolsetMetadataNotifications.Description).
 		AddReadTools(
-			toolsets.NewServerTool(ListNotifications(getClient, t)),
-			toolsets.NewServerTool(GetNotificationDetails(getClient, t)),
+			toolsets.NewServerTool(ListNotifications(getClient, cache, t, flags)),
+			toolsets.NewServerTool(GetNotificationDetails(getClient, cache, t, flags)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(DismissNotification(getClient, t)),
@@ -274,9 +293,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 
 	discussions := toolsets.NewToolset(ToolsetMetadataDiscussions.ID, ToolsetMetadataDiscussions.Description).
 		AddReadTools(
-			toolsets.NewServerTool(ListDiscussions(getGQLClient, t)),
-			toolsets.NewServerTool(GetDiscussion(getGQLClient, t)),
-			toolsets.NewServerTool(GetDiscussionComments(getGQLClient, t)),
+			toolsets.NewServerTool(ListDiscussions(getGQLClient, cache, t, flags)),
+			toolsets.NewServerTool(GetDiscussion(getGQLClient, cache, t, flags)),
+			toolsets.NewServerTool(GetDiscussionComments(getGQLClient, cache, t, flags)),
 			toolsets.NewServerTool(ListDiscussionCategories(getGQLClient, t)),
 		)
 
@@ -317,16 +336,22 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(GetTeams(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(GetTeamMembers(getGQLClient, t)),
 		)
+	// The lockdown cache counters help debugging lockdown mode
+	if cache != nil {
+		contextTools.AddReadTools(toolsets.NewServerTool(LockdownCacheStats(cache, t)))
+	}
 
 	gists := toolsets.NewToolset(ToolsetMetadataGists.ID, ToolsetMetadataGists.Description).
 		AddReadTools(
-			toolsets.NewServerTool(ListGists(getClient, t)),
-			toolsets.NewServerTool(GetGist(getClient, t)),
+			toolsets.NewServerTool(ListGists(getClient, t, flags)),
+			toolsets.NewServerTool(GetGist(getClient, t, flags)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateGist(getClient, t)),
 			toolsets.NewServerTool(UpdateGist(getClient, t)),
-		)
+		).
+		// Gist files are user-authored as a whole
+		SetUserContentFields(append(slices.Clone(DefaultUserContentFields), "files.*.content")...)
 
 	projects := toolsets.NewToolset(ToolsetMetadataProjects.ID, ToolsetMetadataProjects.Description).
 		AddReadTools(
@@ -1,8 +1,14 @@
 package github
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v79/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -280,3 +286,121 @@ func TestContainsToolset(t *testing.T) {
 		})
 	}
 }
+
+func TestPromptAndResourceTemplateSnapshots(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	for _, toolset := range tsg.Toolsets {
+		for _, prompt := range toolset.GetAvailablePrompts() {
+			t.Run(prompt.Prompt.Name, func(t *testing.T) {
+				require.NoError(t, toolsnaps.TestArtifact(toolsnaps.KindPrompt, prompt.Prompt.Name, prompt.Prompt))
+			})
+		}
+		for _, template := range toolset.GetAvailableResourceTemplates() {
+			t.Run(template.Template.Name, func(t *testing.T) {
+				require.NoError(t, toolsnaps.TestArtifact(toolsnaps.KindResourceTemplate, template.Template.Name, template.Template))
+			})
+		}
+	}
+}
+
+func TestOutputSchemasDescribeProperties(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if tool.Tool.OutputSchema == nil {
+				continue
+			}
+			t.Run(tool.Tool.Name, func(t *testing.T) {
+				// An output schema of just {"type": "object"} gives clients nothing to validate against,
+				// so tools without a typed output should not declare one
+				schema, ok := tool.Tool.OutputSchema.(*jsonschema.Schema)
+				require.True(t, ok, "output schema should be a *jsonschema.Schema")
+				assert.Equal(t, "object", schema.Type)
+				// Tools whose output depends on their parameters describe each of their outputs
+				alternatives := schema.AnyOf
+				if len(alternatives) == 0 {
+					alternatives = []*jsonschema.Schema{schema}
+				}
+				for _, alternative := range alternatives {
+					assert.NotEmpty(t, alternative.Properties, "output schema of %s should describe its properties", tool.Tool.Name)
+				}
+			})
+		}
+	}
+}
+
+func TestReadToolsDeclareOutputSchema(t *testing.T) {
+	// In read-only mode, only the read tools are available
+	tsg := DefaultToolsetGroup(true, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			t.Run(tool.Tool.Name, func(t *testing.T) {
+				require.NotNil(t, tool.Tool.OutputSchema, "read tool %s should declare an output schema", tool.Tool.Name)
+			})
+		}
+	}
+}
+
+func TestReadToolsAcceptFieldProjection(t *testing.T) {
+	// Log tools return log text or download links, which gain nothing from projection
+	logTools := []string{"get_job_logs", "get_workflow_run_logs"}
+	// Project item tools have a fields parameter of their own, the IDs of the project fields to return
+	projectItemTools := []string{"list_project_items", "get_project_item"}
+	tsg := DefaultToolsetGroup(true, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			t.Run(tool.Tool.Name, func(t *testing.T) {
+				schema, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+				require.True(t, ok, "input schema should be a *jsonschema.Schema")
+				if slices.Contains(logTools, tool.Tool.Name) {
+					assert.NotContains(t, schema.Properties, FieldsParam)
+					return
+				}
+				if slices.Contains(projectItemTools, tool.Tool.Name) {
+					require.Contains(t, schema.Properties, FieldsParam)
+					assert.Equal(t, "array", schema.Properties[FieldsParam].Type)
+					return
+				}
+				assert.True(t, hasFieldProjection(schema), "read tool %s should accept field projection", tool.Tool.Name)
+			})
+		}
+	}
+}
+
+func TestOutputSchemasRequireNothingOnlyWithFieldProjection(t *testing.T) {
+	// Every field of the project items of go-github is optional
+	optionalOutputTools := []string{"get_project_item"}
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), stubGetRawClientFn(nil), translations.NullTranslationHelper, 5000, FeatureFlags{}, nil)
+
+	for _, toolset := range tsg.Toolsets {
+		for _, tool := range toolset.GetAvailableTools() {
+			if tool.Tool.OutputSchema == nil {
+				continue
+			}
+			t.Run(tool.Tool.Name, func(t *testing.T) {
+				input, ok := tool.Tool.InputSchema.(*jsonschema.Schema)
+				require.True(t, ok, "input schema should be a *jsonschema.Schema")
+				output, ok := tool.Tool.OutputSchema.(*jsonschema.Schema)
+				require.True(t, ok, "output schema should be a *jsonschema.Schema")
+
+				required := false
+				walkSchema(output, func(s *jsonschema.Schema) {
+					required = required || len(s.Required) > 0
+				})
+				switch {
+				case hasFieldProjection(input):
+					// The fields parameter can leave any property out of the result
+					assert.False(t, required, "output schema of %s should not require properties", tool.Tool.Name)
+				case slices.Contains(optionalOutputTools, tool.Tool.Name):
+					assert.False(t, required, "output schema of %s should not require properties", tool.Tool.Name)
+				default:
+					assert.True(t, required, "output schema of %s should require the properties always returned", tool.Tool.Name)
+				}
+			})
+		}
+	}
+}
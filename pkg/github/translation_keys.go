@@ -0,0 +1,20 @@
+package github
+
+import "strings"
+
+// TranslationKeys returns every translation key used by the server's tools, prompts and resource templates,
+// including the dynamic toolset and continue_result, mapped to its default value.
+func TranslationKeys() map[string]string {
+	keys := map[string]string{}
+	t := func(key string, defaultValue string) string {
+		keys[strings.ToUpper(key)] = defaultValue
+		return defaultValue
+	}
+
+	tsg := DefaultToolsetGroup(false, nil, nil, nil, t, 0, FeatureFlags{}, nil)
+	InitDynamicToolset(nil, tsg, t)
+	ContinueResult(nil, t)
+	LockdownCacheStats(nil, t)
+
+	return keys
+}
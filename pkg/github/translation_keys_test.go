@@ -0,0 +1,23 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslationKeys(t *testing.T) {
+	keys := TranslationKeys()
+
+	// Tool descriptions and titles
+	assert.Contains(t, keys, "TOOL_GET_ME_DESCRIPTION")
+	assert.Contains(t, keys, "TOOL_GET_ME_USER_TITLE")
+	// Dynamic toolset tools
+	assert.Contains(t, keys, "TOOL_ENABLE_TOOLSET_DESCRIPTION")
+	assert.Contains(t, keys, "TOOL_CONTINUE_RESULT_DESCRIPTION")
+
+	for key := range keys {
+		assert.Equal(t, key, strings.ToUpper(key), "translation keys are upper case")
+	}
+}
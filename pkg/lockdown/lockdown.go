@@ -2,10 +2,14 @@ package lockdown
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/muesli/cache2go"
@@ -21,11 +25,17 @@ type RepoAccessCache struct {
 	ttl              time.Duration
 	logger           *slog.Logger
 	trustedBotLogins map[string]struct{}
+	minPermission    Permission
+	orgPolicies      map[string]OrgPolicy
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
 }
 
 type repoAccessCacheEntry struct {
 	isPrivate   bool
-	knownUsers  map[string]bool // normalized login -> has push access
+	knownUsers  map[string]Permission // normalized login -> repository permission
 	viewerLogin string
 }
 
@@ -33,17 +43,84 @@ type repoAccessCacheEntry struct {
 type RepoAccessInfo struct {
 	IsPrivate     bool
 	HasPushAccess bool
-	ViewerLogin   string
+	// Permission is the permission of the user on the repository, empty if the user is not a collaborator.
+	Permission  Permission
+	ViewerLogin string
+}
+
+// Permission is a repository permission level, as returned by the GraphQL API.
+type Permission string
+
+// Repository permission levels, from the lowest to the highest.
+const (
+	PermissionRead     Permission = "READ"
+	PermissionTriage   Permission = "TRIAGE"
+	PermissionWrite    Permission = "WRITE"
+	PermissionMaintain Permission = "MAINTAIN"
+	PermissionAdmin    Permission = "ADMIN"
+)
+
+var permissionLevels = map[Permission]int{
+	PermissionRead:     1,
+	PermissionTriage:   2,
+	PermissionWrite:    3,
+	PermissionMaintain: 4,
+	PermissionAdmin:    5,
+}
+
+// ParsePermission parses a permission level such as "triage" or "WRITE".
+func ParsePermission(permission string) (Permission, error) {
+	p := Permission(strings.ToUpper(strings.TrimSpace(permission)))
+	if _, ok := permissionLevels[p]; !ok {
+		return "", fmt.Errorf("invalid permission %q, must be one of read, triage, write, maintain or admin", permission)
+	}
+	return p, nil
+}
+
+// AtLeast reports whether the permission is at least the given level. An empty or unknown permission is never
+// sufficient.
+func (p Permission) AtLeast(level Permission) bool {
+	return permissionLevels[p] > 0 && permissionLevels[p] >= permissionLevels[level]
+}
+
+// OrgPolicy overrides the lockdown policy for the repositories of an organization or user. Empty fields fall
+// back to the policy of the cache.
+type OrgPolicy struct {
+	// TrustedBots replaces the trusted bot logins for the organization.
+	TrustedBots []string
+	// MinimumPermission replaces the permission authors need for their content to be safe.
+	MinimumPermission Permission
 }
 
 const (
 	defaultRepoAccessTTL      = 20 * time.Minute
 	defaultRepoAccessCacheKey = "repo-access-cache"
+	defaultMinimumPermission  = PermissionWrite
+	// prefetchBatchSize is the number of users whose permission Prefetch queries at once.
+	prefetchBatchSize = 25
+	// maxInstances is the number of identities GetInstance keeps a cache for, the least recently used
+	// identity is evicted beyond it.
+	maxInstances = 100
+	// instanceIdleTTL is how long GetInstance keeps the cache of an identity that isn't requested.
+	instanceIdleTTL = time.Hour
 )
 
+// DefaultTrustedBots are the bot logins whose content is considered safe unless overridden with WithTrustedBots.
+var DefaultTrustedBots = []string{"copilot"}
+
+type instance struct {
+	cache    *RepoAccessCache
+	lastUsed time.Time
+	// refs counts the GetInstance callers that haven't called ReleaseInstance yet.
+	refs int
+}
+
 var (
-	instance   *RepoAccessCache
-	instanceMu sync.Mutex
+	instances     = make(map[string]*instance)
+	instanceMu    sync.Mutex
+	cacheSequence atomic.Int64
+	// now returns the current time, overridden in tests of the instance eviction.
+	now = time.Now
 )
 
 // RepoAccessOption configures RepoAccessCache at construction time.
@@ -64,6 +141,31 @@ func WithLogger(logger *slog.Logger) RepoAccessOption {
 	}
 }
 
+// WithTrustedBots sets the bot logins whose content is always considered safe, replacing DefaultTrustedBots.
+func WithTrustedBots(logins ...string) RepoAccessOption {
+	return func(c *RepoAccessCache) {
+		c.trustedBotLogins = loginSet(logins)
+	}
+}
+
+// WithMinimumPermission sets the permission authors need on a repository for their content to be considered
+// safe. The default is PermissionWrite, i.e. push access.
+func WithMinimumPermission(permission Permission) RepoAccessOption {
+	return func(c *RepoAccessCache) {
+		c.minPermission = permission
+	}
+}
+
+// WithOrgPolicy overrides the trusted bots and the minimum permission for the repositories owned by org.
+func WithOrgPolicy(org string, policy OrgPolicy) RepoAccessOption {
+	return func(c *RepoAccessCache) {
+		if c.orgPolicies == nil {
+			c.orgPolicies = make(map[string]OrgPolicy)
+		}
+		c.orgPolicies[strings.ToLower(org)] = policy
+	}
+}
+
 // WithCacheName overrides the cache table name used for storing entries. This option is intended for tests
 // that need isolated cache instances.
 func WithCacheName(name string) RepoAccessOption {
@@ -74,29 +176,105 @@ func WithCacheName(name string) RepoAccessOption {
 	}
 }
 
-// GetInstance returns the singleton instance of RepoAccessCache.
-// It initializes the instance on first call with the provided client and options.
-// Subsequent calls ignore the client and options parameters and return the existing instance.
+// GetInstance returns the RepoAccessCache of an identity, typically the TokenIdentity of the token a session is
+// authenticated with. It initializes the cache on the first call for the identity with the provided client and
+// options. Subsequent calls for the same identity ignore the client and options parameters and return the
+// existing cache, while each identity gets its own cache, so that sessions of different tokens neither share
+// clients nor cached permissions. Each call holds a reference on the cache until ReleaseInstance is called for
+// the identity. The unreferenced caches of identities that weren't requested for instanceIdleTTL, and of the
+// least recently requested identities beyond maxInstances, are closed and forgotten.
 // This is the preferred way to access the cache in production code.
-func GetInstance(client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessCache {
+func GetInstance(identity string, client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessCache {
 	instanceMu.Lock()
 	defer instanceMu.Unlock()
-	if instance == nil {
-		instance = &RepoAccessCache{
-			client: client,
-			cache:  cache2go.Cache(defaultRepoAccessCacheKey),
-			ttl:    defaultRepoAccessTTL,
-			trustedBotLogins: map[string]struct{}{
-				"copilot": {},
-			},
+	current := now()
+	evictInstances(current)
+	if inst, ok := instances[identity]; ok {
+		inst.lastUsed = current
+		inst.refs++
+		return inst.cache
+	}
+	if len(instances) >= maxInstances {
+		evictLeastRecentlyUsed()
+	}
+	c := NewRepoAccessCache(client, opts...)
+	instances[identity] = &instance{cache: c, lastUsed: current, refs: 1}
+	return c
+}
+
+// ReleaseInstance drops a reference taken by GetInstance on the cache of an identity, once its holder no
+// longer uses the cache. The cache stays available to later GetInstance calls until it is evicted.
+func ReleaseInstance(identity string) {
+	instanceMu.Lock()
+	defer instanceMu.Unlock()
+	if inst, ok := instances[identity]; ok && inst.refs > 0 {
+		inst.refs--
+		inst.lastUsed = now()
+	}
+}
+
+// evictInstances closes the unreferenced caches of the identities idle for longer than instanceIdleTTL.
+// instanceMu must be held.
+func evictInstances(current time.Time) {
+	for identity, inst := range instances {
+		if inst.refs == 0 && current.Sub(inst.lastUsed) > instanceIdleTTL {
+			inst.cache.Close()
+			delete(instances, identity)
 		}
-		for _, opt := range opts {
-			if opt != nil {
-				opt(instance)
-			}
+	}
+}
+
+// evictLeastRecentlyUsed closes the cache of the least recently requested identity whose cache isn't
+// referenced. No cache is evicted when all of them are referenced. instanceMu must be held.
+func evictLeastRecentlyUsed() {
+	var oldest string
+	for identity, inst := range instances {
+		if inst.refs > 0 {
+			continue
+		}
+		if oldest == "" || inst.lastUsed.Before(instances[oldest].lastUsed) {
+			oldest = identity
 		}
 	}
-	return instance
+	if oldest != "" {
+		instances[oldest].cache.Close()
+		delete(instances, oldest)
+	}
+}
+
+// TokenIdentity returns the identity of a token for GetInstance, without keeping the token itself around.
+func TokenIdentity(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewRepoAccessCache returns a new RepoAccessCache using client for its queries. Unless WithCacheName is set,
+// each cache stores its entries in its own cache table.
+func NewRepoAccessCache(client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessCache {
+	c := &RepoAccessCache{
+		client:           client,
+		cache:            cache2go.Cache(fmt.Sprintf("%s-%d", defaultRepoAccessCacheKey, cacheSequence.Add(1))),
+		ttl:              defaultRepoAccessTTL,
+		trustedBotLogins: loginSet(DefaultTrustedBots),
+		minPermission:    defaultMinimumPermission,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	c.cache.SetAboutToDeleteItemCallback(func(*cache2go.CacheItem) {
+		c.evictions.Add(1)
+	})
+	return c
+}
+
+// Close drops the entries of the cache and detaches it from its cache table. cache2go never deletes a table, so
+// this is what releases the memory of a cache that is no longer needed. A closed cache keeps answering
+// lookups, querying the API again.
+func (c *RepoAccessCache) Close() {
+	c.cache.RemoveAboutToDeleteItemCallback()
+	c.cache.Flush()
 }
 
 // SetLogger updates the logger used for cache diagnostics.
@@ -108,30 +286,47 @@ func (c *RepoAccessCache) SetLogger(logger *slog.Logger) {
 
 // CacheStats summarizes cache activity counters.
 type CacheStats struct {
-	Hits      int64
-	Misses    int64
-	Evictions int64
+	// Hits counts the permission lookups answered from the cache.
+	Hits int64 `json:"hits"`
+	// Misses counts the permission lookups, including prefetched ones, that were queried from the API.
+	Misses int64 `json:"misses"`
+	// Evictions counts the repository entries that expired.
+	Evictions int64 `json:"evictions"`
+	// Entries is the number of repositories currently cached.
+	Entries int `json:"entries"`
+}
+
+// Stats returns the activity counters of the cache.
+func (c *RepoAccessCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   c.cache.Count(),
+	}
 }
 
 // IsSafeContent determines if the specified user can safely access the requested repository content.
 // Safe access applies when any of the following is true:
 // - the content was created by a trusted bot;
-// - the author currently has push access to the repository;
+// - the author currently has at least the minimum permission on the repository, push access by default;
 // - the repository is private;
 // - the content was created by the viewer.
+// Trusted bots and the minimum permission can be overridden for the organization owning the repository.
 func (c *RepoAccessCache) IsSafeContent(ctx context.Context, username, owner, repo string) (bool, error) {
 	repoInfo, err := c.getRepoAccessInfo(ctx, username, owner, repo)
 	if err != nil {
 		return false, err
 	}
 
-	c.logDebug(ctx, fmt.Sprintf("evaluated repo access for user %s to %s/%s for content filtering, result: hasPushAccess=%t, isPrivate=%t",
-		username, owner, repo, repoInfo.HasPushAccess, repoInfo.IsPrivate))
+	minPermission := c.minimumPermission(owner)
+	c.logDebug(ctx, fmt.Sprintf("evaluated repo access for user %s to %s/%s for content filtering, result: permission=%s, minimumPermission=%s, isPrivate=%t",
+		username, owner, repo, repoInfo.Permission, minPermission, repoInfo.IsPrivate))
 
-	if c.isTrustedBot(username) || repoInfo.IsPrivate || repoInfo.ViewerLogin == strings.ToLower(username) {
+	if c.isTrustedBot(owner, username) || repoInfo.IsPrivate || repoInfo.ViewerLogin == strings.ToLower(username) {
 		return true, nil
 	}
-	return repoInfo.HasPushAccess, nil
+	return repoInfo.Permission.AtLeast(minPermission), nil
 }
 
 func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner, repo string) (RepoAccessInfo, error) {
@@ -148,15 +343,13 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 	cacheItem, err := c.cache.Value(key)
 	if err == nil {
 		entry := cacheItem.Data().(*repoAccessCacheEntry)
-		if cachedHasPush, known := entry.knownUsers[userKey]; known {
+		if _, known := entry.knownUsers[userKey]; known {
+			c.hits.Add(1)
 			c.logDebug(ctx, fmt.Sprintf("repo access cache hit for user %s to %s/%s", username, owner, repo))
-			return RepoAccessInfo{
-				IsPrivate:     entry.isPrivate,
-				HasPushAccess: cachedHasPush,
-				ViewerLogin:   entry.viewerLogin,
-			}, nil
+			return entry.accessInfo(userKey), nil
 		}
 
+		c.misses.Add(1)
 		c.logDebug(ctx, "known users cache miss, fetching from graphql API")
 
 		info, queryErr := c.queryRepoAccessInfo(ctx, username, owner, repo)
@@ -164,18 +357,15 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 			return RepoAccessInfo{}, queryErr
 		}
 
-		entry.knownUsers[userKey] = info.HasPushAccess
+		entry.knownUsers[userKey] = info.Permission
 		entry.viewerLogin = info.ViewerLogin
 		entry.isPrivate = info.IsPrivate
 		c.cache.Add(key, c.ttl, entry)
 
-		return RepoAccessInfo{
-			IsPrivate:     entry.isPrivate,
-			HasPushAccess: entry.knownUsers[userKey],
-			ViewerLogin:   entry.viewerLogin,
-		}, nil
+		return entry.accessInfo(userKey), nil
 	}
 
+	c.misses.Add(1)
 	c.logDebug(ctx, fmt.Sprintf("repo access cache miss for user %s to %s/%s", username, owner, repo))
 
 	info, queryErr := c.queryRepoAccessInfo(ctx, username, owner, repo)
@@ -185,17 +375,83 @@ func (c *RepoAccessCache) getRepoAccessInfo(ctx context.Context, username, owner
 
 	// Create new entry
 	entry := &repoAccessCacheEntry{
-		knownUsers:  map[string]bool{userKey: info.HasPushAccess},
+		knownUsers:  map[string]Permission{userKey: info.Permission},
 		isPrivate:   info.IsPrivate,
 		viewerLogin: info.ViewerLogin,
 	}
 	c.cache.Add(key, c.ttl, entry)
 
+	return entry.accessInfo(userKey), nil
+}
+
+// Prefetch fetches the permissions on a repository of the users that are not cached yet, in batched GraphQL
+// queries, so that checking a list of items such as comments takes a single query instead of one per author.
+func (c *RepoAccessCache) Prefetch(ctx context.Context, owner, repo string, usernames []string) error {
+	if c == nil {
+		return fmt.Errorf("nil repo access cache")
+	}
+
+	key := cacheKey(owner, repo)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entry *repoAccessCacheEntry
+	if cacheItem, err := c.cache.Value(key); err == nil {
+		entry = cacheItem.Data().(*repoAccessCacheEntry)
+	}
+
+	missing := make([]string, 0, len(usernames))
+	seen := make(map[string]struct{}, len(usernames))
+	for _, username := range usernames {
+		userKey := strings.ToLower(username)
+		if _, ok := seen[userKey]; ok || userKey == "" {
+			continue
+		}
+		seen[userKey] = struct{}{}
+		if entry != nil {
+			if _, known := entry.knownUsers[userKey]; known {
+				continue
+			}
+		}
+		missing = append(missing, username)
+	}
+	// A single user is queried on demand just as well
+	if len(missing) < 2 {
+		return nil
+	}
+
+	c.logDebug(ctx, fmt.Sprintf("prefetching repo access info for %d users to %s/%s", len(missing), owner, repo))
+
+	for start := 0; start < len(missing); start += prefetchBatchSize {
+		batch := missing[start:min(start+prefetchBatchSize, len(missing))]
+		info, permissions, err := c.queryCollaboratorPermissions(ctx, owner, repo, batch)
+		if err != nil {
+			return err
+		}
+		c.misses.Add(int64(len(batch)))
+
+		if entry == nil {
+			entry = &repoAccessCacheEntry{knownUsers: make(map[string]Permission, len(missing))}
+		}
+		for userKey, permission := range permissions {
+			entry.knownUsers[userKey] = permission
+		}
+		entry.isPrivate = info.IsPrivate
+		entry.viewerLogin = info.ViewerLogin
+		c.cache.Add(key, c.ttl, entry)
+	}
+
+	return nil
+}
+
+func (e *repoAccessCacheEntry) accessInfo(userKey string) RepoAccessInfo {
+	permission := e.knownUsers[userKey]
 	return RepoAccessInfo{
-		IsPrivate:     entry.isPrivate,
-		HasPushAccess: entry.knownUsers[userKey],
-		ViewerLogin:   entry.viewerLogin,
-	}, nil
+		IsPrivate:     e.isPrivate,
+		HasPushAccess: permission.AtLeast(PermissionWrite),
+		Permission:    permission,
+		ViewerLogin:   e.viewerLogin,
+	}
 }
 
 func (c *RepoAccessCache) queryRepoAccessInfo(ctx context.Context, username, owner, repo string) (RepoAccessInfo, error) {
@@ -230,26 +486,86 @@ func (c *RepoAccessCache) queryRepoAccessInfo(ctx context.Context, username, own
 		return RepoAccessInfo{}, fmt.Errorf("failed to query repository access info: %w", err)
 	}
 
-	hasPush := false
+	var permission Permission
 	for _, edge := range query.Repository.Collaborators.Edges {
 		login := string(edge.Node.Login)
 		if strings.EqualFold(login, username) {
-			permission := string(edge.Permission)
-			hasPush = permission == "WRITE" || permission == "ADMIN" || permission == "MAINTAIN"
+			permission = Permission(edge.Permission)
 			break
 		}
 	}
 
-	c.logDebug(ctx, fmt.Sprintf("queried repo access info for user %s to %s/%s: isPrivate=%t, hasPushAccess=%t, viewerLogin=%s",
-		username, owner, repo, bool(query.Repository.IsPrivate), hasPush, query.Viewer.Login))
+	c.logDebug(ctx, fmt.Sprintf("queried repo access info for user %s to %s/%s: isPrivate=%t, permission=%s, viewerLogin=%s",
+		username, owner, repo, bool(query.Repository.IsPrivate), permission, query.Viewer.Login))
 
 	return RepoAccessInfo{
 		IsPrivate:     bool(query.Repository.IsPrivate),
-		HasPushAccess: hasPush,
+		HasPushAccess: permission.AtLeast(PermissionWrite),
+		Permission:    permission,
 		ViewerLogin:   string(query.Viewer.Login),
 	}, nil
 }
 
+type collaboratorEdges struct {
+	Edges []struct {
+		Permission githubv4.String
+		Node       struct {
+			Login githubv4.String
+		}
+	}
+}
+
+// queryCollaboratorPermissions queries the permissions of several users on a repository at once, using one
+// aliased collaborators field per user. The returned permissions are keyed by normalized login.
+func (c *RepoAccessCache) queryCollaboratorPermissions(ctx context.Context, owner, repo string, usernames []string) (RepoAccessInfo, map[string]Permission, error) {
+	if c.client == nil {
+		return RepoAccessInfo{}, nil, fmt.Errorf("nil GraphQL client")
+	}
+
+	// The number of users varies, so the query struct is built at runtime
+	repositoryFields := []reflect.StructField{
+		{Name: "IsPrivate", Type: reflect.TypeOf(githubv4.Boolean(false))},
+	}
+	variables := map[string]interface{}{
+		"owner": githubv4.String(owner),
+		"name":  githubv4.String(repo),
+	}
+	for i, username := range usernames {
+		repositoryFields = append(repositoryFields, reflect.StructField{
+			Name: fmt.Sprintf("Collaborators%d", i),
+			Type: reflect.TypeOf(collaboratorEdges{}),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"collaborators%d: collaborators(query: $username%d, first: 1)"`, i, i)),
+		})
+		variables[fmt.Sprintf("username%d", i)] = githubv4.String(username)
+	}
+	query := reflect.New(reflect.StructOf([]reflect.StructField{
+		{Name: "Viewer", Type: reflect.TypeOf(struct{ Login githubv4.String }{})},
+		{Name: "Repository", Type: reflect.StructOf(repositoryFields), Tag: `graphql:"repository(owner: $owner, name: $name)"`},
+	}))
+
+	if err := c.client.Query(ctx, query.Interface(), variables); err != nil {
+		return RepoAccessInfo{}, nil, fmt.Errorf("failed to query repository access info: %w", err)
+	}
+
+	repository := query.Elem().FieldByName("Repository")
+	permissions := make(map[string]Permission, len(usernames))
+	for i, username := range usernames {
+		var permission Permission
+		for _, edge := range repository.Field(i + 1).Interface().(collaboratorEdges).Edges {
+			if strings.EqualFold(string(edge.Node.Login), username) {
+				permission = Permission(edge.Permission)
+				break
+			}
+		}
+		permissions[strings.ToLower(username)] = permission
+	}
+
+	return RepoAccessInfo{
+		IsPrivate:   bool(repository.FieldByName("IsPrivate").Interface().(githubv4.Boolean)),
+		ViewerLogin: string(query.Elem().FieldByName("Viewer").Field(0).Interface().(githubv4.String)),
+	}, permissions, nil
+}
+
 func (c *RepoAccessCache) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
 	if c == nil || c.logger == nil {
 		return
@@ -264,11 +580,32 @@ func (c *RepoAccessCache) logDebug(ctx context.Context, msg string, attrs ...slo
 	c.log(ctx, slog.LevelDebug, msg, attrs...)
 }
 
-func (c *RepoAccessCache) isTrustedBot(username string) bool {
-	_, ok := c.trustedBotLogins[strings.ToLower(username)]
+func (c *RepoAccessCache) isTrustedBot(owner, username string) bool {
+	trustedBotLogins := c.trustedBotLogins
+	if policy, ok := c.orgPolicies[strings.ToLower(owner)]; ok && policy.TrustedBots != nil {
+		trustedBotLogins = loginSet(policy.TrustedBots)
+	}
+	_, ok := trustedBotLogins[strings.ToLower(username)]
 	return ok
 }
 
+func (c *RepoAccessCache) minimumPermission(owner string) Permission {
+	if policy, ok := c.orgPolicies[strings.ToLower(owner)]; ok && policy.MinimumPermission != "" {
+		return policy.MinimumPermission
+	}
+	return c.minPermission
+}
+
+func loginSet(logins []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(logins))
+	for _, login := range logins {
+		if login = strings.TrimSpace(login); login != "" {
+			set[strings.ToLower(login)] = struct{}{}
+		}
+	}
+	return set
+}
+
 func cacheKey(owner, repo string) string {
 	return fmt.Sprintf("%s/%s", strings.ToLower(owner), strings.ToLower(repo))
 }
@@ -1,7 +1,12 @@
 package lockdown
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -89,7 +94,7 @@ func newMockRepoAccessCache(t *testing.T, ttl time.Duration) (*RepoAccessCache,
 
 	gqlClient := githubv4.NewClient(httpClient)
 
-	return GetInstance(gqlClient, WithTTL(ttl)), counting
+	return NewRepoAccessCache(gqlClient, WithTTL(ttl)), counting
 }
 
 func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
@@ -110,3 +115,305 @@ func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
 	require.True(t, info.HasPushAccess)
 	require.EqualValues(t, 2, transport.CallCount())
 }
+
+func newMockGQLClient(user string, permission string) *githubv4.Client {
+	var query repoAccessQuery
+
+	variables := map[string]any{
+		"owner":    githubv4.String(testOwner),
+		"name":     githubv4.String(testRepo),
+		"username": githubv4.String(user),
+	}
+
+	edges := []any{}
+	if permission != "" {
+		edges = append(edges, map[string]any{
+			"permission": permission,
+			"node": map[string]any{
+				"login": user,
+			},
+		})
+	}
+	response := githubv4mock.DataResponse(map[string]any{
+		"viewer": map[string]any{
+			"login": "viewer",
+		},
+		"repository": map[string]any{
+			"isPrivate": false,
+			"collaborators": map[string]any{
+				"edges": edges,
+			},
+		},
+	})
+
+	return githubv4.NewClient(githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, variables, response)))
+}
+
+func TestParsePermission(t *testing.T) {
+	permission, err := ParsePermission(" triage ")
+	require.NoError(t, err)
+	require.Equal(t, PermissionTriage, permission)
+
+	_, err = ParsePermission("owner")
+	require.Error(t, err)
+
+	require.True(t, PermissionAdmin.AtLeast(PermissionWrite))
+	require.False(t, PermissionTriage.AtLeast(PermissionWrite))
+	require.False(t, Permission("").AtLeast(PermissionRead))
+}
+
+func TestIsSafeContentPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       string
+		permission string
+		opts       []RepoAccessOption
+		expected   bool
+	}{
+		{
+			name:       "write access is safe by default",
+			user:       testUser,
+			permission: "WRITE",
+			expected:   true,
+		},
+		{
+			name:       "triage access is not safe by default",
+			user:       testUser,
+			permission: "TRIAGE",
+			expected:   false,
+		},
+		{
+			name:       "triage access is safe with a lower minimum permission",
+			user:       testUser,
+			permission: "TRIAGE",
+			opts:       []RepoAccessOption{WithMinimumPermission(PermissionTriage)},
+			expected:   true,
+		},
+		{
+			name:       "write access is not safe with a higher minimum permission",
+			user:       testUser,
+			permission: "WRITE",
+			opts:       []RepoAccessOption{WithMinimumPermission(PermissionMaintain)},
+			expected:   false,
+		},
+		{
+			name:     "copilot is trusted by default",
+			user:     "Copilot",
+			expected: true,
+		},
+		{
+			name:     "configured bots replace the default ones",
+			user:     "copilot",
+			opts:     []RepoAccessOption{WithTrustedBots("dependabot", "renovate")},
+			expected: false,
+		},
+		{
+			name:     "configured bots are trusted",
+			user:     "renovate",
+			opts:     []RepoAccessOption{WithTrustedBots("dependabot", "renovate")},
+			expected: true,
+		},
+		{
+			name:       "org policy overrides the minimum permission",
+			user:       testUser,
+			permission: "TRIAGE",
+			opts: []RepoAccessOption{
+				WithMinimumPermission(PermissionAdmin),
+				WithOrgPolicy("Octo-Org", OrgPolicy{MinimumPermission: PermissionTriage}),
+			},
+			expected: true,
+		},
+		{
+			name: "org policy overrides the trusted bots",
+			user: "internal-bot",
+			opts: []RepoAccessOption{
+				WithOrgPolicy(testOwner, OrgPolicy{TrustedBots: []string{"internal-bot"}}),
+			},
+			expected: true,
+		},
+		{
+			name: "policy of other orgs does not apply",
+			user: "internal-bot",
+			opts: []RepoAccessOption{
+				WithOrgPolicy("other-org", OrgPolicy{TrustedBots: []string{"internal-bot"}}),
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]RepoAccessOption{WithCacheName(t.Name())}, tc.opts...)
+			cache := NewRepoAccessCache(newMockGQLClient(tc.user, tc.permission), opts...)
+
+			safe, err := cache.IsSafeContent(t.Context(), tc.user, testOwner, testRepo)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, safe)
+		})
+	}
+}
+
+// batchTransport answers repository access queries, including batched ones, with the given permissions of the
+// users on the test repository.
+type batchTransport struct {
+	countingTransport
+	permissions map[string]string
+}
+
+func newBatchTransport(permissions map[string]string) *batchTransport {
+	t := &batchTransport{permissions: permissions}
+	t.next = roundTripFunc(t.respond)
+	return t
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func (t *batchTransport) respond(req *http.Request) (*http.Response, error) {
+	var payload struct {
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	repository := map[string]any{"isPrivate": false}
+	for name, value := range payload.Variables {
+		field, found := strings.CutPrefix(name, "username")
+		if !found {
+			continue
+		}
+		edges := []any{}
+		if permission := t.permissions[value.(string)]; permission != "" {
+			edges = append(edges, map[string]any{
+				"permission": permission,
+				"node":       map[string]any{"login": value},
+			})
+		}
+		repository["collaborators"+field] = map[string]any{"edges": edges}
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"viewer":     map[string]any{"login": "viewer"},
+			"repository": repository,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestPrefetchBatchesQueries(t *testing.T) {
+	ctx := t.Context()
+
+	transport := newBatchTransport(map[string]string{
+		"maintainer":  "MAINTAIN",
+		"contributor": "READ",
+	})
+	cache := NewRepoAccessCache(githubv4.NewClient(&http.Client{Transport: transport}))
+
+	users := []string{"maintainer", "contributor", "Maintainer", "stranger", ""}
+	require.NoError(t, cache.Prefetch(ctx, testOwner, testRepo, users))
+	require.Equal(t, 1, transport.CallCount())
+
+	for user, expected := range map[string]bool{"maintainer": true, "contributor": false, "stranger": false} {
+		safe, err := cache.IsSafeContent(ctx, user, testOwner, testRepo)
+		require.NoError(t, err)
+		require.Equal(t, expected, safe, user)
+	}
+	require.Equal(t, 1, transport.CallCount())
+
+	// Known users are not fetched again
+	require.NoError(t, cache.Prefetch(ctx, testOwner, testRepo, users))
+	require.Equal(t, 1, transport.CallCount())
+
+	stats := cache.Stats()
+	require.EqualValues(t, 3, stats.Misses)
+	require.EqualValues(t, 3, stats.Hits)
+	require.Equal(t, 1, stats.Entries)
+}
+
+func TestCacheStats(t *testing.T) {
+	ctx := t.Context()
+
+	cache, transport := newMockRepoAccessCache(t, 5*time.Millisecond)
+	for range 2 {
+		_, err := cache.IsSafeContent(ctx, testUser, testOwner, testRepo)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, transport.CallCount())
+	require.Equal(t, CacheStats{Hits: 1, Misses: 1, Entries: 1}, cache.Stats())
+
+	require.Eventually(t, func() bool {
+		return cache.Stats().Evictions == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestGetInstancePerIdentity(t *testing.T) {
+	first := GetInstance(TokenIdentity("first-token"), nil)
+	second := GetInstance(TokenIdentity("second-token"), nil)
+
+	require.NotSame(t, first, second)
+	require.Same(t, first, GetInstance(TokenIdentity("first-token"), nil, WithTTL(time.Minute)))
+	require.NotContains(t, TokenIdentity("first-token"), "first-token")
+	ReleaseInstance(TokenIdentity("first-token"))
+	ReleaseInstance(TokenIdentity("first-token"))
+	ReleaseInstance(TokenIdentity("second-token"))
+}
+
+func TestGetInstanceEvictsIdentities(t *testing.T) {
+	current := time.Now()
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+
+	idle := GetInstance(TokenIdentity("idle-token"), nil, WithCacheName("idle-instance-cache"))
+	idle.cache.Add("owner/repo", time.Minute, &repoAccessCacheEntry{})
+	ReleaseInstance(TokenIdentity("idle-token"))
+
+	current = current.Add(instanceIdleTTL + time.Minute)
+	GetInstance(TokenIdentity("fresh-token"), nil)
+	ReleaseInstance(TokenIdentity("fresh-token"))
+	require.NotContains(t, instances, TokenIdentity("idle-token"))
+	require.Zero(t, idle.cache.Count(), "the table of an evicted identity is flushed")
+	require.NotSame(t, idle, GetInstance(TokenIdentity("idle-token"), nil))
+	ReleaseInstance(TokenIdentity("idle-token"))
+
+	for i := 0; i < maxInstances; i++ {
+		current = current.Add(time.Second)
+		GetInstance(TokenIdentity(fmt.Sprintf("token-%d", i)), nil)
+		ReleaseInstance(TokenIdentity(fmt.Sprintf("token-%d", i)))
+	}
+	require.Len(t, instances, maxInstances)
+	require.NotContains(t, instances, TokenIdentity("fresh-token"), "the least recently used identity is evicted")
+}
+
+func TestGetInstanceKeepsReferencedIdentities(t *testing.T) {
+	current := time.Now()
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+
+	held := GetInstance(TokenIdentity("held-token"), nil, WithCacheName("held-instance-cache"))
+	held.cache.Add("owner/repo", time.Minute, &repoAccessCacheEntry{})
+	t.Cleanup(func() { ReleaseInstance(TokenIdentity("held-token")) })
+
+	current = current.Add(instanceIdleTTL + time.Minute)
+	for i := 0; i < maxInstances; i++ {
+		current = current.Add(time.Second)
+		GetInstance(TokenIdentity(fmt.Sprintf("other-token-%d", i)), nil)
+		ReleaseInstance(TokenIdentity(fmt.Sprintf("other-token-%d", i)))
+	}
+	require.Contains(t, instances, TokenIdentity("held-token"), "a referenced identity is neither idle nor least recently used")
+	require.Equal(t, 1, held.cache.Count(), "the table of a referenced identity is kept")
+	require.Same(t, held, GetInstance(TokenIdentity("held-token"), nil))
+	ReleaseInstance(TokenIdentity("held-token"))
+}
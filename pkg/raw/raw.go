@@ -3,6 +3,7 @@ package raw
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -71,3 +72,22 @@ func (c *Client) GetRawContent(ctx context.Context, owner, repo, path string, op
 
 	return c.client.Client().Do(req)
 }
+
+// GetRawContentRange fetches length bytes of the raw content of a file from offset, or the rest of the file if
+// length is 0, with an HTTP Range request. Servers honoring the range respond with 206 Partial Content and a
+// Content-Range header, but may respond with the whole file instead.
+func (c *Client) GetRawContentRange(ctx context.Context, owner, repo, path string, opts *ContentOpts, offset, length int64) (*http.Response, error) {
+	url := c.URLFromOpts(opts, owner, repo, path)
+	req, err := c.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if length > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	return c.client.Client().Do(req)
+}
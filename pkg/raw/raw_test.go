@@ -100,6 +100,44 @@ func TestGetRawContent(t *testing.T) {
 	}
 }
 
+func TestGetRawContentRange(t *testing.T) {
+	base, _ := url.Parse("https://raw.example.com/")
+
+	tests := []struct {
+		name          string
+		offset        int64
+		length        int64
+		expectedRange string
+	}{
+		{name: "offset and length", offset: 10, length: 5, expectedRange: "bytes=10-14"},
+		{name: "rest of the file", offset: 10, expectedRange: "bytes=10-"},
+		{name: "first bytes", length: 100, expectedRange: "bytes=0-99"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var rangeHeader string
+			mockedClient := mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					GetRawReposContentsByOwnerByRepoBySHAByPath,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						rangeHeader = r.Header.Get("Range")
+						w.WriteHeader(http.StatusPartialContent)
+					}),
+				),
+			)
+			client := NewClient(github.NewClient(mockedClient), base)
+			resp, err := client.GetRawContentRange(context.Background(), "octocat", "hello", "README.md", &ContentOpts{SHA: "abc123"}, tc.offset, tc.length)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+			require.Equal(t, tc.expectedRange, rangeHeader)
+		})
+	}
+}
+
 func TestUrlFromOpts(t *testing.T) {
 	base, _ := url.Parse("https://raw.example.com/")
 	ghClient := github.NewClient(nil)
@@ -0,0 +1,243 @@
+package sanitize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// InjectionKind classifies the text flagged by InjectionDetector.
+type InjectionKind string
+
+const (
+	// InjectionInstructionOverride is text telling the reader to ignore or replace its instructions.
+	InjectionInstructionOverride InjectionKind = "instruction_override"
+	// InjectionRoleMarker is text impersonating a system or assistant turn of a conversation.
+	InjectionRoleMarker InjectionKind = "role_marker"
+	// InjectionToolCall is text that looks like a tool or function call.
+	InjectionToolCall InjectionKind = "tool_call"
+	// InjectionExfiltrationLink is a hidden markdown link or an image whose URL can carry data out.
+	InjectionExfiltrationLink InjectionKind = "exfiltration_link"
+)
+
+// DefaultInjectionThreshold is the minimum score of text flagged by InjectionDetector unless overridden with
+// WithInjectionThreshold.
+const DefaultInjectionThreshold = 0.5
+
+const (
+	injectionMarkerStart = "[possible prompt injection: "
+	injectionMarkerEnd   = "]"
+)
+
+// InjectionFinding is a span of text flagged by InjectionDetector. Start and End are byte offsets.
+type InjectionFinding struct {
+	Kind  InjectionKind
+	Text  string
+	Start int
+	End   int
+	// Score estimates from 0 to 1 how likely the span is an injection attempt.
+	Score float64
+}
+
+type injectionPattern struct {
+	kind  InjectionKind
+	re    *regexp.Regexp
+	score float64
+}
+
+var defaultInjectionPatterns = []injectionPattern{
+	{
+		kind:  InjectionInstructionOverride,
+		re:    regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override|bypass)\s+(?:all\s+|any\s+)?(?:of\s+)?(?:the\s+|your\s+|my\s+)?(?:previous|prior|above|earlier|preceding|original|system|existing)\s+(?:instructions?|prompts?|rules|directions|directives|guidelines|context)\b`),
+		score: 0.9,
+	},
+	{
+		kind:  InjectionInstructionOverride,
+		re:    regexp.MustCompile(`(?i)\b(?:do\s+not|don't|never)\s+(?:tell|inform|mention\s+(?:this|it)\s+to|alert|notify)\s+the\s+user\b`),
+		score: 0.8,
+	},
+	{
+		kind:  InjectionInstructionOverride,
+		re:    regexp.MustCompile(`(?i)\b(?:new|updated|real|actual)\s+instructions\s*:`),
+		score: 0.7,
+	},
+	{
+		kind:  InjectionInstructionOverride,
+		re:    regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(?:a|an|in)\b`),
+		score: 0.5,
+	},
+	{
+		kind:  InjectionRoleMarker,
+		re:    regexp.MustCompile(`(?i)<\|im_start\|>|<\|im_end\|>|<\|(?:system|assistant|user)\|>|\[/?INST\]|<</?SYS>>`),
+		score: 0.8,
+	},
+	{
+		kind:  InjectionRoleMarker,
+		re:    regexp.MustCompile(`(?im)^[ \t]*(?:#{1,6}[ \t]*)?(?:system|assistant)[ \t]*(?:prompt|message)?[ \t]*:`),
+		score: 0.4,
+	},
+	{
+		kind:  InjectionToolCall,
+		re:    regexp.MustCompile(`(?is)<(?:\w+:)?(?:function_calls|invoke|tool_call|tool_use)\b[^>]*>`),
+		score: 0.8,
+	},
+	{
+		kind:  InjectionToolCall,
+		re:    regexp.MustCompile(`(?s)\{\s*"(?:tool_calls|function_call|tool_use)"\s*:`),
+		score: 0.8,
+	},
+	{
+		kind:  InjectionToolCall,
+		re:    regexp.MustCompile(`(?s)\{\s*"(?:name|tool|function)"\s*:\s*"[\w.-]+"\s*,\s*"(?:arguments|parameters|input|args)"\s*:\s*\{`),
+		score: 0.7,
+	},
+	{
+		// A link without visible text is invisible once the markdown is rendered
+		kind:  InjectionExfiltrationLink,
+		re:    regexp.MustCompile(`(?i)!?\[\s*\]\(\s*<?https?://[^)\s]+[^)]*\)`),
+		score: 0.7,
+	},
+	{
+		// Images are fetched when rendered, so query parameters send data without any click
+		kind:  InjectionExfiltrationLink,
+		re:    regexp.MustCompile(`(?i)!\[[^\]]*\]\(\s*<?https?://[^)\s?]*\?[^)\s]*=[^)\s]*[^)]*\)`),
+		score: 0.6,
+	},
+	{
+		// URLs with placeholders ask the reader to fill in data
+		kind:  InjectionExfiltrationLink,
+		re:    regexp.MustCompile(`(?i)!?\[[^\]]*\]\(\s*<?https?://[^)\s]*(?:\{[^}\s]*\}|\$\{?\w+|<[A-Z_]+>|%7B\w+%7D)[^)]*\)`),
+		score: 0.8,
+	},
+}
+
+// InjectionDetector flags text that looks like an attempt to inject instructions into a model reading it, such as
+// "ignore previous instructions", tool calls or hidden links to exfiltration URLs. It relies on heuristics, so it
+// reduces the risk of prompt injection without ruling it out.
+type InjectionDetector struct {
+	threshold      float64
+	kindThresholds map[InjectionKind]float64
+	patterns       []injectionPattern
+}
+
+// InjectionDetectorOption configures InjectionDetector at construction time.
+type InjectionDetectorOption func(*InjectionDetector)
+
+// WithInjectionThreshold sets the minimum score of flagged text. A lower threshold flags weaker signals, such as
+// lines starting with "system:", at the cost of more false positives.
+func WithInjectionThreshold(threshold float64) InjectionDetectorOption {
+	return func(d *InjectionDetector) {
+		d.threshold = threshold
+	}
+}
+
+// WithInjectionKindThreshold overrides the threshold for one kind of injection. A threshold above 1 disables the
+// kind.
+func WithInjectionKindThreshold(kind InjectionKind, threshold float64) InjectionDetectorOption {
+	return func(d *InjectionDetector) {
+		if d.kindThresholds == nil {
+			d.kindThresholds = make(map[InjectionKind]float64)
+		}
+		d.kindThresholds[kind] = threshold
+	}
+}
+
+// WithInjectionPattern adds a pattern flagged as kind with the given score.
+func WithInjectionPattern(kind InjectionKind, pattern *regexp.Regexp, score float64) InjectionDetectorOption {
+	return func(d *InjectionDetector) {
+		d.patterns = append(d.patterns, injectionPattern{kind: kind, re: pattern, score: score})
+	}
+}
+
+// NewInjectionDetector returns an InjectionDetector using the built-in patterns.
+func NewInjectionDetector(opts ...InjectionDetectorOption) *InjectionDetector {
+	d := &InjectionDetector{
+		threshold: DefaultInjectionThreshold,
+		patterns:  append([]injectionPattern(nil), defaultInjectionPatterns...),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(d)
+		}
+	}
+	return d
+}
+
+// Detect returns the spans of text scoring at least the threshold, ordered by offset. Overlapping spans are
+// merged, keeping the kind with the highest score.
+func (d *InjectionDetector) Detect(text string) []InjectionFinding {
+	if d == nil || text == "" {
+		return nil
+	}
+
+	var findings []InjectionFinding
+	for _, pattern := range d.patterns {
+		if pattern.score < d.thresholdOf(pattern.kind) {
+			continue
+		}
+		for _, loc := range pattern.re.FindAllStringIndex(text, -1) {
+			findings = append(findings, InjectionFinding{
+				Kind:  pattern.kind,
+				Start: loc[0],
+				End:   loc[1],
+				Score: pattern.score,
+			})
+		}
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Start != findings[j].Start {
+			return findings[i].Start < findings[j].Start
+		}
+		return findings[i].End > findings[j].End
+	})
+	merged := findings[:1]
+	for _, finding := range findings[1:] {
+		last := &merged[len(merged)-1]
+		if finding.Start >= last.End {
+			merged = append(merged, finding)
+			continue
+		}
+		last.End = max(last.End, finding.End)
+		if finding.Score > last.Score {
+			last.Kind = finding.Kind
+			last.Score = finding.Score
+		}
+	}
+	for i := range merged {
+		merged[i].Text = text[merged[i].Start:merged[i].End]
+	}
+	return merged
+}
+
+// Annotate wraps the spans returned by Detect in markers, so that a model reading the text can tell them apart,
+// and returns the annotated text with the findings. Text without findings is returned unchanged.
+func (d *InjectionDetector) Annotate(text string) (string, []InjectionFinding) {
+	findings := d.Detect(text)
+	if len(findings) == 0 {
+		return text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(text) + len(findings)*(len(injectionMarkerStart)+len(injectionMarkerEnd)))
+	offset := 0
+	for _, finding := range findings {
+		b.WriteString(text[offset:finding.Start])
+		b.WriteString(injectionMarkerStart)
+		b.WriteString(finding.Text)
+		b.WriteString(injectionMarkerEnd)
+		offset = finding.End
+	}
+	b.WriteString(text[offset:])
+	return b.String(), findings
+}
+
+func (d *InjectionDetector) thresholdOf(kind InjectionKind) float64 {
+	if threshold, ok := d.kindThresholds[kind]; ok {
+		return threshold
+	}
+	return d.threshold
+}
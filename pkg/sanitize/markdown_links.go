@@ -0,0 +1,377 @@
+package sanitize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DefaultAllowedLinkHosts are the hosts that links and images may point to unless a Sanitizer is created with
+// others. Entries starting with "*." match any subdomain.
+var DefaultAllowedLinkHosts = []string{
+	"github.com",
+	"*.github.com",
+	"githubusercontent.com",
+	"*.githubusercontent.com",
+}
+
+// Sanitizer sanitizes user content, filtering the links and images that point outside its allowed hosts. It is
+// safe for concurrent use.
+type Sanitizer struct {
+	allowedLinkHosts []string
+	policy           *bluemonday.Policy
+}
+
+// defaultSanitizer is used by the package level functions.
+var defaultSanitizer = NewSanitizer(DefaultAllowedLinkHosts)
+
+// NewSanitizer returns a Sanitizer allowing links and images to point to the given hosts, such as the host of a
+// GitHub Enterprise Server. Entries starting with "*." match any subdomain.
+func NewSanitizer(allowedLinkHosts []string) *Sanitizer {
+	normalized := make([]string, 0, len(allowedLinkHosts))
+	for _, host := range allowedLinkHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			normalized = append(normalized, host)
+		}
+	}
+	s := &Sanitizer{allowedLinkHosts: normalized}
+	s.policy = s.htmlPolicy()
+	return s
+}
+
+var (
+	// markdownInlineLinkRegex matches inline links and images, e.g. [text](url "title") or ![alt](<url>). One
+	// level of nested brackets in the text and of parentheses in the URL is supported.
+	markdownInlineLinkRegex = regexp.MustCompile(`(!?)\[((?:[^\[\]]|\[[^\[\]]*\])*)\]\(\s*(<[^<>\n]*>|(?:[^()\s]|\([^()\s]*\))*)(\s+(?:"[^"]*"|'[^']*'|\([^()]*\)))?\s*\)`)
+	// markdownLinkDefinitionRegex matches link reference definitions, e.g. [id]: https://example.com "title".
+	markdownLinkDefinitionRegex = regexp.MustCompile(`^( {0,3}\[[^\[\]]+\]:[ \t]*)(<[^<>\n]*>|\S+)(.*)$`)
+	// markdownAutolinkRegex matches autolinks, e.g. <https://example.com>.
+	markdownAutolinkRegex = regexp.MustCompile(`<[a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*>`)
+)
+
+// htmlURLAttributes are the HTML attributes holding a URL that a browser loads or follows.
+var htmlURLAttributes = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"data":       true,
+	"dynsrc":     true,
+	"formaction": true,
+	"href":       true,
+	"longdesc":   true,
+	"lowsrc":     true,
+	"poster":     true,
+	"src":        true,
+	"xlink:href": true,
+}
+
+// maxLinkFilterPasses bounds the passes of FilterMarkdownLinks, see unfilteredLinkReplacer.
+const maxLinkFilterPasses = 8
+
+// unfilteredLinkReplacer breaks all links and HTML tags of the input that FilterMarkdownLinks could not filter
+// in maxLinkFilterPasses passes, which only happens when links are crafted to be nested into each other.
+var unfilteredLinkReplacer = strings.NewReplacer("<", "&lt;", "](", "] (", "]:", "] :")
+
+// FilterMarkdownLinks filters the links and images of input with the DefaultAllowedLinkHosts, see
+// Sanitizer.FilterMarkdownLinks.
+func FilterMarkdownLinks(input string) string {
+	return defaultSanitizer.FilterMarkdownLinks(input)
+}
+
+// FilterMarkdownLinks removes the URLs of links and images that point outside the allowed hosts, as rendering
+// them, or following them, can send data to any server. This covers markdown links, images, autolinks and
+// reference definitions, as well as the URL attributes of HTML tags. Links keep their text, images are replaced
+// by a placeholder with their alt text, autolinks by a placeholder, reference definitions and HTML tags lose
+// their URL. Relative URLs and anchors are kept, while URLs with other schemes than http, https and mailto are
+// removed. Code spans and fenced code blocks are left untouched, except for HTML tags in code spans.
+func (s *Sanitizer) FilterMarkdownLinks(input string) string {
+	// Removing a link joins the text around it, which can form another link, e.g. <[i](x)mg src="...">
+	for range maxLinkFilterPasses {
+		filtered := s.filterLinks(input)
+		if filtered == input {
+			return filtered
+		}
+		input = filtered
+	}
+	return unfilteredLinkReplacer.Replace(input)
+}
+
+func (s *Sanitizer) filterLinks(input string) string {
+	if input == "" || !strings.Contains(input, "](") && !strings.Contains(input, "]:") && !strings.Contains(input, "<") {
+		return input
+	}
+
+	lines := strings.Split(input, "\n")
+	filtered := make([]string, 0, len(lines))
+	// text holds the lines outside of fenced code blocks since the last one
+	var text []string
+	flush := func() {
+		if len(text) > 0 {
+			filtered = append(filtered, s.filterTextLinks(strings.Join(text, "\n")))
+			text = text[:0]
+		}
+	}
+	fence, fenceIndent := "", 0
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if fence != "" {
+			// A fence in a list item ends with it, so lines indented less than the fence aren't trusted as code
+			if trimmed == "" || indent >= fenceIndent {
+				filtered = append(filtered, line)
+				if strings.HasPrefix(trimmed, fence) {
+					fence = ""
+				}
+				continue
+			}
+			fence = ""
+		}
+		if marker := codeFenceMarker(trimmed); marker != "" && indent <= 3 {
+			flush()
+			filtered = append(filtered, line)
+			fence, fenceIndent = marker, indent
+			continue
+		}
+		text = append(text, line)
+	}
+	flush()
+	return strings.Join(filtered, "\n")
+}
+
+// codeFenceMarker returns the backticks or tildes opening a fenced code block, or "" if line does not open one.
+func codeFenceMarker(line string) string {
+	for _, c := range []byte{'`', '~'} {
+		n := 0
+		for n < len(line) && line[n] == c {
+			n++
+		}
+		if n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// filterTextLinks filters the links of text outside of fenced code blocks.
+func (s *Sanitizer) filterTextLinks(text string) string {
+	// A reference definition cannot interrupt a paragraph, so HTML in its title can be rendered
+	lines := strings.Split(s.filterHTMLLinks(text), "\n")
+	filtered := make([]string, 0, len(lines))
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			filtered = append(filtered, s.filterInlineLinks(strings.Join(paragraph, "\n")))
+			paragraph = paragraph[:0]
+		}
+	}
+	for _, line := range lines {
+		// Code spans don't span paragraphs
+		if strings.TrimSpace(line) == "" {
+			flush()
+			filtered = append(filtered, line)
+			continue
+		}
+		if match := markdownLinkDefinitionRegex.FindStringSubmatch(line); match != nil {
+			flush()
+			if !s.isAllowedLinkURL(match[2]) {
+				line = match[1] + "#"
+			}
+			filtered = append(filtered, line)
+			continue
+		}
+		paragraph = append(paragraph, line)
+	}
+	flush()
+	return strings.Join(filtered, "\n")
+}
+
+// filterHTMLLinks filters the autolinks and the URL attributes of the HTML tags of text, which are tokenized as
+// a browser does.
+func (s *Sanitizer) filterHTMLLinks(text string) string {
+	if !strings.Contains(text, "<") {
+		return text
+	}
+
+	text = markdownAutolinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		if s.isAllowedLinkURL(link) {
+			return link
+		}
+		return "[link removed]"
+	})
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(text))
+	for {
+		tt := z.Next()
+		raw := string(z.Raw())
+		if tt == html.ErrorToken {
+			// The tokenizer only stops at the end of text, which can end with an unterminated tag
+			b.WriteString(raw)
+			break
+		}
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			if tag, ok := s.filterHTMLTag(z.Token()); ok {
+				b.WriteString(tag)
+				continue
+			}
+		}
+		b.WriteString(raw)
+	}
+	return b.String()
+}
+
+// filterHTMLTag returns tok without its URL attributes outside the allowed hosts, or a placeholder if tok is an
+// image loading such a URL. It returns false if tok has no such attribute.
+func (s *Sanitizer) filterHTMLTag(tok html.Token) (string, bool) {
+	attrs := make([]html.Attribute, 0, len(tok.Attr))
+	alt := ""
+	removedSource := false
+	for _, attr := range tok.Attr {
+		if attr.Key == "alt" {
+			alt = strings.Join(strings.Fields(attr.Val), " ")
+		}
+		if s.isAllowedHTMLAttribute(attr) {
+			attrs = append(attrs, attr)
+			continue
+		}
+		removedSource = removedSource || attr.Key == "src" || attr.Key == "srcset"
+	}
+	if len(attrs) == len(tok.Attr) {
+		return "", false
+	}
+	if tok.DataAtom == atom.Img && removedSource {
+		return removedImage(alt), true
+	}
+	tok.Attr = attrs
+	return tok.String(), true
+}
+
+func (s *Sanitizer) isAllowedHTMLAttribute(attr html.Attribute) bool {
+	switch {
+	case attr.Key == "srcset":
+		// Image candidates are separated by commas, and made of a URL and an optional descriptor
+		for _, candidate := range strings.Split(attr.Val, ",") {
+			if fields := strings.Fields(candidate); len(fields) > 0 && !s.isAllowedLinkURL(fields[0]) {
+				return false
+			}
+		}
+		return true
+	case attr.Key == "ping":
+		for _, target := range strings.Fields(attr.Val) {
+			if !s.isAllowedLinkURL(target) {
+				return false
+			}
+		}
+		return true
+	case attr.Key == "style":
+		// CSS loads URLs with url(), image-set() and their escaped forms
+		return !strings.Contains(attr.Val, "(")
+	case htmlURLAttributes[attr.Key]:
+		return s.isAllowedLinkURL(strings.TrimSpace(attr.Val))
+	default:
+		return true
+	}
+}
+
+// filterInlineLinks filters the inline links and images of a paragraph outside of its code spans.
+func (s *Sanitizer) filterInlineLinks(line string) string {
+	var b strings.Builder
+	for line != "" {
+		start := strings.IndexByte(line, '`')
+		if start == -1 {
+			b.WriteString(s.filterInlineLinksText(line))
+			break
+		}
+		b.WriteString(s.filterInlineLinksText(line[:start]))
+
+		// A code span ends with a backtick string of the same length
+		n := 1
+		for start+n < len(line) && line[start+n] == '`' {
+			n++
+		}
+		delimiter := line[start : start+n]
+		end := strings.Index(line[start+n:], delimiter)
+		if end == -1 {
+			b.WriteString(delimiter)
+			line = line[start+n:]
+			continue
+		}
+		end += start + 2*n
+		b.WriteString(line[start:end])
+		line = line[end:]
+	}
+	return b.String()
+}
+
+func (s *Sanitizer) filterInlineLinksText(text string) string {
+	if !strings.Contains(text, "](") {
+		return text
+	}
+	return markdownInlineLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownInlineLinkRegex.FindStringSubmatch(link)
+		isImage, label, target := match[1] == "!", match[2], match[3]
+		// The text of a link can be an image, as for badges
+		filteredLabel := s.filterInlineLinksText(label)
+		if s.isAllowedLinkURL(target) {
+			if filteredLabel == label {
+				return link
+			}
+			return strings.Replace(link, "["+label+"]", "["+filteredLabel+"]", 1)
+		}
+		if isImage {
+			return removedImage(filteredLabel)
+		}
+		return filteredLabel
+	})
+}
+
+// removedImage returns the placeholder of an image with the given alt text.
+func removedImage(alt string) string {
+	if alt == "" {
+		return "[image removed]"
+	}
+	return "[image removed: " + alt + "]"
+}
+
+func (s *Sanitizer) isAllowedLinkURL(target string) bool {
+	target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+	if target == "" || strings.HasPrefix(target, "#") {
+		return true
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		// Relative URLs resolve against the page, unless they are protocol-relative
+		return u.Host == ""
+	case "mailto":
+		return true
+	case "http", "https":
+		return s.isAllowedLinkHost(u.Hostname())
+	default:
+		return false
+	}
+}
+
+func (s *Sanitizer) isAllowedLinkHost(host string) bool {
+	host = strings.ToLower(host)
+	for _, allowed := range s.allowedLinkHosts {
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMarkdownLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "allowed link",
+			input:    "See [the issue](https://github.com/owner/repo/issues/1).",
+			expected: "See [the issue](https://github.com/owner/repo/issues/1).",
+		},
+		{
+			name:     "allowed image on a subdomain",
+			input:    "![screenshot](https://user-images.githubusercontent.com/1/screenshot.png)",
+			expected: "![screenshot](https://user-images.githubusercontent.com/1/screenshot.png)",
+		},
+		{
+			name:     "link to another host keeps its text",
+			input:    "See [the docs](https://attacker.example/docs?q=secret) for details.",
+			expected: "See the docs for details.",
+		},
+		{
+			name:     "image on another host is removed",
+			input:    "Status: ![build](https://attacker.example/pixel.png?data=secret \"title\")",
+			expected: "Status: [image removed: build]",
+		},
+		{
+			name:     "image without alt text",
+			input:    "![](https://attacker.example/pixel.png)",
+			expected: "[image removed]",
+		},
+		{
+			name:     "host suffix is not a subdomain",
+			input:    "[x](https://github.com.attacker.example/) [y](https://notgithub.com/)",
+			expected: "x y",
+		},
+		{
+			name:     "badge with an image on another host",
+			input:    "[![badge](https://attacker.example/badge.svg)](https://github.com/owner/repo/actions)",
+			expected: "[[image removed: badge]](https://github.com/owner/repo/actions)",
+		},
+		{
+			name:     "link with an image on another host",
+			input:    "[![badge](https://attacker.example/badge.svg)](https://attacker.example/)",
+			expected: "[image removed: badge]",
+		},
+		{
+			name:     "relative links and anchors",
+			input:    "[readme](README.md) [section](#usage) [mail](mailto:octocat@github.com)",
+			expected: "[readme](README.md) [section](#usage) [mail](mailto:octocat@github.com)",
+		},
+		{
+			name:     "protocol-relative link",
+			input:    "[x](//attacker.example/x)",
+			expected: "x",
+		},
+		{
+			name:     "other schemes",
+			input:    "[click](javascript:alert(1)) ![x](data:image/png;base64,AAAA)",
+			expected: "click [image removed: x]",
+		},
+		{
+			name:     "angle brackets and parentheses in the URL",
+			input:    "[a](<https://attacker.example/a b>) [b](https://attacker.example/wiki/Go_(language))",
+			expected: "a b",
+		},
+		{
+			name:     "reference definition",
+			input:    "See [the docs][docs].\n\n[docs]: https://attacker.example/docs \"Docs\"\n[repo]: https://github.com/owner/repo",
+			expected: "See [the docs][docs].\n\n[docs]: #\n[repo]: https://github.com/owner/repo",
+		},
+		{
+			name:     "code span",
+			input:    "Use `![x](https://attacker.example/x.png)` in ``[y](https://attacker.example)``, but ![z](https://attacker.example/z.png)",
+			expected: "Use `![x](https://attacker.example/x.png)` in ``[y](https://attacker.example)``, but [image removed: z]",
+		},
+		{
+			name:     "fenced code block",
+			input:    "```markdown\n![x](https://attacker.example/x.png)\n```\n![y](https://attacker.example/y.png)",
+			expected: "```markdown\n![x](https://attacker.example/x.png)\n```\n[image removed: y]",
+		},
+		{
+			name:     "unclosed code span",
+			input:    "a ` b [c](https://attacker.example)",
+			expected: "a ` b c",
+		},
+		{
+			name:     "code span across paragraphs",
+			input:    "a `\n\n[b](https://attacker.example) `",
+			expected: "a `\n\nb `",
+		},
+		{
+			name:     "image across lines",
+			input:    "![a\nb](https://attacker.example/x.png)",
+			expected: "[image removed: a\nb]",
+		},
+		{
+			name:     "autolinks",
+			input:    "See <https://attacker.example/?d=secret>, <https://github.com/owner/repo> and <mailto:octocat@github.com>",
+			expected: "See [link removed], <https://github.com/owner/repo> and <mailto:octocat@github.com>",
+		},
+		{
+			name:     "HTML image on another host",
+			input:    `Status: <img src="https://attacker.example/?d=secret" alt="build">`,
+			expected: "Status: [image removed: build]",
+		},
+		{
+			name:     "HTML image on an allowed host",
+			input:    `<img src="https://github.com/owner/repo/raw/main/logo.png" alt="logo">`,
+			expected: `<img src="https://github.com/owner/repo/raw/main/logo.png" alt="logo">`,
+		},
+		{
+			name:     "HTML link to another host",
+			input:    `See <a href="https://attacker.example" title="docs">the docs</a>.`,
+			expected: `See <a title="docs">the docs</a>.`,
+		},
+		{
+			name:     "HTML tags parsed as a browser does",
+			input:    "<img/src=//attacker.example/a> <img src=\"https&#58;//attacker.example/b\"\nalt=\"two\nlines\">",
+			expected: "[image removed] [image removed: two lines]",
+		},
+		{
+			name:     "HTML URL attributes",
+			input:    `<source srcset="https://github.com/a.png 1x, https://attacker.example/b.png 2x"><p style="background: url(https://attacker.example)">x</p>`,
+			expected: `<source><p>x</p>`,
+		},
+		{
+			name:     "HTML in a code span",
+			input:    "a && b <T> `<img src=\"https://attacker.example\">`",
+			expected: "a && b <T> `[image removed]`",
+		},
+		{
+			name:     "HTML in a fenced code block",
+			input:    "```html\n<img src=\"https://attacker.example\">\n```",
+			expected: "```html\n<img src=\"https://attacker.example\">\n```",
+		},
+		{
+			name:     "fenced code block ended by its list item",
+			input:    "1. a\n   ```\n<img src=\"https://attacker.example\">\n   ```",
+			expected: "1. a\n   ```\n[image removed]\n   ```",
+		},
+		{
+			name:     "reference definition in a paragraph",
+			input:    "a\n[b]: https://github.com <img src=\"https://attacker.example\">",
+			expected: "a\n[b]: https://github.com [image removed]",
+		},
+		{
+			name:     "link joined into another one",
+			input:    "<[i](https://attacker.example)mg src=\"https://attacker.example/x.png\"> ![[a](https://attacker.example)](https://attacker.example/x.png)",
+			expected: "[image removed] [image removed: a]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FilterMarkdownLinks(tc.input))
+		})
+	}
+}
+
+func TestSanitizerAllowedLinkHosts(t *testing.T) {
+	input := "![diagram](https://ghes.example.com/storage/diagram.png) [issue](https://github.com/owner/repo/issues/1)"
+	assert.Equal(t, "[image removed: diagram] [issue](https://github.com/owner/repo/issues/1)", FilterMarkdownLinks(input))
+
+	ghes := NewSanitizer(append([]string{" GHES.example.com "}, DefaultAllowedLinkHosts...))
+	assert.Equal(t, input, ghes.FilterMarkdownLinks(input))
+	// Sanitizers don't affect each other nor the package level functions
+	assert.Equal(t, "[image removed: diagram] issue", NewSanitizer(nil).FilterMarkdownLinks(input))
+	assert.Equal(t, "[image removed: diagram] [issue](https://github.com/owner/repo/issues/1)", Sanitize(input))
+	assert.Equal(t, input, ghes.FilterMarkdownLinks(input))
+}
+
+func TestSanitizeFiltersMarkdownLinks(t *testing.T) {
+	assert.Equal(t, "Fixed [image removed: x]", Sanitize("Fixed ![x](https://attacker.example/x.png?d=1)"))
+	assert.Equal(t, "Fixed [image removed]", Sanitize(`Fixed <img src="https://attacker.example/?d=secret">`))
+	assert.Equal(t, "See x", Sanitize(`See <a href="https://attacker.example">x</a>`))
+	assert.Equal(t, "See [link removed]", Sanitize("See <https://attacker.example/?d=secret>"))
+}
+
+func TestSanitizerHTMLPolicy(t *testing.T) {
+	policy := NewSanitizer(DefaultAllowedLinkHosts).policy
+	assert.Equal(t, `<img alt="x">`, policy.Sanitize(`<img src="https://attacker.example/?d=secret" alt="x">`))
+	assert.Equal(t, `<img alt="x">`, policy.Sanitize(`<img src="//attacker.example/?d=secret" alt="x">`))
+	assert.Equal(t, `<img src="https://github.com/x.png" alt="x">`, policy.Sanitize(`<img src="https://github.com/x.png" alt="x">`))
+	assert.Equal(t, `<img src="x.png">`, policy.Sanitize(`<img src="x.png">`))
+	assert.Equal(t, "x", policy.Sanitize(`<a href="https://attacker.example">x</a>`))
+	assert.Equal(t, "x", policy.Sanitize(`<a href=" //attacker.example">x</a>`))
+	assert.Equal(t, `<a href="https://docs.github.com" rel="nofollow noreferrer noopener" target="_blank">x</a>`, policy.Sanitize(`<a href="https://docs.github.com">x</a>`))
+}
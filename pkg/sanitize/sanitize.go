@@ -1,6 +1,8 @@
 package sanitize
 
 import (
+	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"unicode"
@@ -11,8 +13,31 @@ import (
 var policy *bluemonday.Policy
 var policyOnce sync.Once
 
+// Sanitize sanitizes input with the DefaultAllowedLinkHosts, see Sanitizer.Sanitize.
 func Sanitize(input string) string {
-	return FilterHTMLTags(FilterCodeFenceMetadata(FilterInvisibleCharacters(input)))
+	return defaultSanitizer.Sanitize(input)
+}
+
+// Sanitize removes invisible characters, suspicious code fence metadata, links outside of the allowed hosts and
+// unsafe HTML from input.
+func (s *Sanitizer) Sanitize(input string) string {
+	input = s.FilterMarkdownLinks(FilterCodeFenceMetadata(FilterInvisibleCharacters(input)))
+	if input == "" {
+		return input
+	}
+	return s.policy.Sanitize(input)
+}
+
+// SanitizeText sanitizes text that may be code with the DefaultAllowedLinkHosts, see Sanitizer.SanitizeText.
+func SanitizeText(input string) string {
+	return defaultSanitizer.SanitizeText(input)
+}
+
+// SanitizeText removes invisible characters and markdown links outside of the allowed hosts from text that may
+// be code, such as a gist file or a commit message. Unlike Sanitize, it doesn't filter HTML, which would escape
+// the code.
+func (s *Sanitizer) SanitizeText(input string) string {
+	return s.FilterMarkdownLinks(FilterInvisibleCharacters(input))
 }
 
 // FilterInvisibleCharacters removes invisible or control characters that should not appear
@@ -35,6 +60,21 @@ func FilterInvisibleCharacters(input string) string {
 	return string(out)
 }
 
+// markdownTableCellReplacer escapes backslashes first, so that they cannot escape the pipes around cells.
+var markdownTableCellReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", " ",
+	"\r", " ",
+	"\n", " ",
+)
+
+// EscapeMarkdownTableCell escapes text for a cell of a markdown table, so that it cannot end the cell, the row
+// or the table. Line breaks are replaced by spaces.
+func EscapeMarkdownTableCell(input string) string {
+	return markdownTableCellReplacer.Replace(input)
+}
+
 func FilterHTMLTags(input string) string {
 	if input == "" {
 		return input
@@ -147,32 +187,65 @@ func isSafeCodeFenceToken(token string) bool {
 
 func getPolicy() *bluemonday.Policy {
 	policyOnce.Do(func() {
-		p := bluemonday.StrictPolicy()
-
-		p.AllowElements(
-			"b", "blockquote", "br", "code", "em",
-			"h1", "h2", "h3", "h4", "h5", "h6",
-			"hr", "i", "li", "ol", "p", "pre",
-			"strong", "sub", "sup", "table", "tbody",
-			"td", "th", "thead", "tr", "ul",
-			"a", "img",
-		)
-
-		p.AllowAttrs("href").OnElements("a")
-		p.AllowURLSchemes("http", "https")
-		p.RequireParseableURLs(true)
-		p.RequireNoFollowOnLinks(true)
-		p.RequireNoReferrerOnLinks(true)
-		p.AddTargetBlankToFullyQualifiedLinks(true)
-
-		p.AllowImages()
-		p.AllowAttrs("src", "alt", "title").OnElements("img")
-
-		policy = p
+		policy = newPolicy(nil)
 	})
 	return policy
 }
 
+// newPolicy returns an HTML policy whose links and images must match urlPattern, if it isn't nil.
+func newPolicy(urlPattern *regexp.Regexp) *bluemonday.Policy {
+	p := bluemonday.StrictPolicy()
+
+	p.AllowElements(
+		"b", "blockquote", "br", "code", "em",
+		"h1", "h2", "h3", "h4", "h5", "h6",
+		"hr", "i", "li", "ol", "p", "pre",
+		"strong", "sub", "sup", "table", "tbody",
+		"td", "th", "thead", "tr", "ul",
+		"a", "img",
+	)
+
+	href := p.AllowAttrs("href")
+	if urlPattern != nil {
+		href = href.Matching(urlPattern)
+	}
+	href.OnElements("a")
+	p.AllowURLSchemes("http", "https")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+
+	// As p.AllowImages(), with the src attribute below
+	p.AllowAttrs("align").Matching(bluemonday.ImageAlign).OnElements("img")
+	p.AllowAttrs("alt").Matching(bluemonday.Paragraph).OnElements("img")
+	p.AllowAttrs("height", "width").Matching(bluemonday.NumberOrPercent).OnElements("img")
+	p.AllowStandardURLs()
+	src := p.AllowAttrs("src")
+	if urlPattern != nil {
+		src = src.Matching(urlPattern)
+	}
+	src.OnElements("img")
+	p.AllowAttrs("alt", "title").OnElements("img")
+
+	return p
+}
+
+// notProtocolRelativeURLRegex matches the URLs that don't start with //, as the policy of a Sanitizer allows
+// relative URLs without checking their host.
+var notProtocolRelativeURLRegex = regexp.MustCompile(`^\s*(?:[^\s/\\]|[/\\](?:[^/\\]|$)|$)`)
+
+// htmlPolicy returns the HTML policy of s, which only allows links and images to its allowed hosts.
+func (s *Sanitizer) htmlPolicy() *bluemonday.Policy {
+	p := newPolicy(notProtocolRelativeURLRegex)
+	for _, scheme := range []string{"http", "https"} {
+		p.AllowURLSchemeWithCustomPolicy(scheme, func(u *url.URL) bool {
+			return s.isAllowedLinkHost(u.Hostname())
+		})
+	}
+	return p
+}
+
 func shouldRemoveRune(r rune) bool {
 	switch r {
 	case 0x200B, // ZERO WIDTH SPACE
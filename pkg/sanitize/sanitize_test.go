@@ -300,3 +300,43 @@ func TestSanitizeRemovesInvisibleCodeFenceMetadata(t *testing.T) {
 	result := Sanitize(input)
 	assert.Equal(t, expected, result)
 }
+
+func TestEscapeMarkdownTableCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text unchanged",
+			input:    "Fix bug in parser",
+			expected: "Fix bug in parser",
+		},
+		{
+			name:     "pipes escaped",
+			input:    "a | b",
+			expected: `a \| b`,
+		},
+		{
+			name:     "line breaks replaced",
+			input:    "first\nsecond\r\nthird\rfourth",
+			expected: "first second third fourth",
+		},
+		{
+			name:     "backslash cannot escape the escaped pipe",
+			input:    `\| injected |`,
+			expected: `\\\| injected \|`,
+		},
+		{
+			name:     "new row cannot be started",
+			input:    "title |\n| --- |\n| injected",
+			expected: `title \| \| --- \| \| injected`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EscapeMarkdownTableCell(tt.input))
+		})
+	}
+}
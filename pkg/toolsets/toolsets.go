@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -37,6 +39,10 @@ type ServerTool struct {
 	RegisterFunc func(s *mcp.Server)
 }
 
+// NewServerTool creates a ServerTool from a tool definition and its typed handler. If the tool declares an
+// OutputSchema, the output returned by the handler is sent as the structured content of successful results,
+// alongside the text content built by the handler. Slices are wrapped in an object under "items", as
+// structured content must be an object; see OutputSchema.
 func NewServerTool[In any, Out any](tool mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) ServerTool {
 	return ServerTool{Tool: tool, RegisterFunc: func(s *mcp.Server) {
 		th := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -45,7 +51,10 @@ func NewServerTool[In any, Out any](tool mcp.Tool, handler mcp.ToolHandlerFor[In
 				return nil, err
 			}
 
-			resp, _, err := handler(ctx, req, arguments)
+			resp, out, err := handler(ctx, req, arguments)
+			if err == nil && tool.OutputSchema != nil {
+				setStructuredContent(resp, out)
+			}
 
 			return resp, err
 		}
@@ -54,6 +63,66 @@ func NewServerTool[In any, Out any](tool mcp.Tool, handler mcp.ToolHandlerFor[In
 	}}
 }
 
+// listOutput is the structured content of tools whose output is a slice.
+type listOutput struct {
+	Items any `json:"items"`
+}
+
+func setStructuredContent(resp *mcp.CallToolResult, out any) {
+	if resp == nil || resp.IsError || resp.StructuredContent != nil || out == nil {
+		return
+	}
+
+	v := reflect.ValueOf(out)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			// Send an empty list rather than null, which the output schema does not allow
+			out = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+		}
+		out = listOutput{Items: out}
+	}
+
+	resp.StructuredContent = out
+}
+
+// OutputSchema derives the output schema of a tool from the type of the output returned by its handler.
+// Pointers are described by the schema of the type they point to. As structured content must be an object,
+// slices are described as an object with the slice under "items", matching the structured content sent by
+// the handler created with NewServerTool.
+func OutputSchema[Out any](opts *jsonschema.ForOptions) (*jsonschema.Schema, error) {
+	if opts == nil {
+		opts = &jsonschema.ForOptions{}
+	}
+
+	t := reflect.TypeFor[Out]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := jsonschema.ForType(t, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive output schema: %w", err)
+	}
+
+	if t.Kind() == reflect.Slice {
+		return &jsonschema.Schema{
+			Type:       "object",
+			Properties: map[string]*jsonschema.Schema{"items": schema},
+			Required:   []string{"items"},
+		}, nil
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("output schema must have type object, got %s", t)
+	}
+
+	return schema, nil
+}
+
 type ServerResourceTemplate struct {
 	Template mcp.ResourceTemplate
 	Handler  mcp.ResourceHandler
@@ -91,6 +160,9 @@ type Toolset struct {
 	resourceTemplates []ServerResourceTemplate
 	// prompts are also not tools but are namespaced similarly
 	prompts []ServerPrompt
+	// userContentFields are the paths of the fields of tool results holding user-authored text, nil meaning the
+	// defaults
+	userContentFields []string
 }
 
 func (t *Toolset) GetActiveTools() []ServerTool {
@@ -134,6 +206,19 @@ func (t *Toolset) AddPrompts(prompts ...ServerPrompt) *Toolset {
 	return t
 }
 
+// SetUserContentFields sets the paths of the fields of the JSON results of the tools holding user-authored text,
+// such as titles and bodies, which are sanitized before being returned. Toolsets that do not set them use the defaults of the
+// server, and setting no fields turns off the sanitization of the results of the toolset.
+func (t *Toolset) SetUserContentFields(fields ...string) *Toolset {
+	t.userContentFields = append([]string{}, fields...)
+	return t
+}
+
+// GetUserContentFields returns the fields set with SetUserContentFields, or nil if they were not set.
+func (t *Toolset) GetUserContentFields() []string {
+	return t.userContentFields
+}
+
 func (t *Toolset) GetActiveResourceTemplates() []ServerResourceTemplate {
 	if !t.Enabled {
 		return nil
@@ -145,6 +230,10 @@ func (t *Toolset) GetAvailableResourceTemplates() []ServerResourceTemplate {
 	return t.resourceTemplates
 }
 
+func (t *Toolset) GetAvailablePrompts() []ServerPrompt {
+	return t.prompts
+}
+
 func (t *Toolset) RegisterResourcesTemplates(s *mcp.Server) {
 	if !t.Enabled {
 		return
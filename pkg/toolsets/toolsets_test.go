@@ -3,6 +3,8 @@ package toolsets
 import (
 	"errors"
 	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func TestNewToolsetGroupIsEmptyWithoutEverythingOn(t *testing.T) {
@@ -262,3 +264,90 @@ func TestToolsetGroup_GetToolset(t *testing.T) {
 		t.Errorf("expected error to be ToolsetDoesNotExistError, got %v", err)
 	}
 }
+
+func TestSetUserContentFields(t *testing.T) {
+	toolset := NewToolset("my-toolset", "desc")
+	if got := toolset.GetUserContentFields(); got != nil {
+		t.Errorf("expected nil fields by default, got %v", got)
+	}
+
+	fields := []string{"title", "body"}
+	toolset.SetUserContentFields(fields...)
+	fields[0] = "changed"
+	if got := toolset.GetUserContentFields(); len(got) != 2 || got[0] != "title" || got[1] != "body" {
+		t.Errorf("expected [title body], got %v", got)
+	}
+
+	// No fields turn off sanitization, which differs from not setting them
+	toolset.SetUserContentFields()
+	if got := toolset.GetUserContentFields(); got == nil || len(got) != 0 {
+		t.Errorf("expected empty non-nil fields, got %v", got)
+	}
+}
+
+type testOutput struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+}
+
+func TestOutputSchema(t *testing.T) {
+	schema, err := OutputSchema[*testOutput](nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if schema.Type != "object" {
+		t.Errorf("expected pointer output to be described as an object, got %q", schema.Type)
+	}
+	if _, ok := schema.Properties["name"]; !ok {
+		t.Error("expected schema to describe the name property")
+	}
+
+	schema, err = OutputSchema[[]testOutput](nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if schema.Type != "object" || len(schema.Required) != 1 || schema.Required[0] != "items" {
+		t.Errorf("expected slice output to be wrapped in an object with required items, got %+v", schema)
+	}
+	if schema.Properties["items"].Type != "array" {
+		t.Errorf("expected items to be an array, got %q", schema.Properties["items"].Type)
+	}
+
+	if _, err := OutputSchema[string](nil); err == nil {
+		t.Error("expected error for non-object output, got nil")
+	}
+}
+
+func TestSetStructuredContent(t *testing.T) {
+	resp := &mcp.CallToolResult{}
+	setStructuredContent(resp, &testOutput{Name: "a"})
+	if got, ok := resp.StructuredContent.(*testOutput); !ok || got.Name != "a" {
+		t.Errorf("expected structured content to be the output, got %#v", resp.StructuredContent)
+	}
+
+	resp = &mcp.CallToolResult{}
+	setStructuredContent(resp, []testOutput{{Name: "a"}})
+	if got, ok := resp.StructuredContent.(listOutput); !ok || len(got.Items.([]testOutput)) != 1 {
+		t.Errorf("expected slice output to be wrapped in items, got %#v", resp.StructuredContent)
+	}
+
+	// A nil slice is sent as an empty list
+	resp = &mcp.CallToolResult{}
+	setStructuredContent(resp, []testOutput(nil))
+	if got, ok := resp.StructuredContent.(listOutput); !ok || got.Items.([]testOutput) == nil {
+		t.Errorf("expected nil slice to be sent as an empty list, got %#v", resp.StructuredContent)
+	}
+
+	// Nil outputs and error results have no structured content
+	resp = &mcp.CallToolResult{}
+	setStructuredContent(resp, (*testOutput)(nil))
+	if resp.StructuredContent != nil {
+		t.Errorf("expected no structured content for nil output, got %#v", resp.StructuredContent)
+	}
+
+	resp = &mcp.CallToolResult{IsError: true}
+	setStructuredContent(resp, &testOutput{Name: "a"})
+	if resp.StructuredContent != nil {
+		t.Errorf("expected no structured content for error result, got %#v", resp.StructuredContent)
+	}
+}
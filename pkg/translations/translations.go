@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/viper"
 )
@@ -16,8 +19,54 @@ func NullTranslationHelper(_ string, defaultValue string) string {
 	return defaultValue
 }
 
-func TranslationHelper() (TranslationHelperFunc, func()) {
-	var translationKeyMap = map[string]string{}
+type translatorOptions struct {
+	locale          string
+	translationsDir string
+}
+
+// Option configures a Translator.
+type Option func(*translatorOptions)
+
+// WithLocale selects the locale whose bundles are used, e.g. "pt-BR". Bundles are looked up from the most
+// specific locale to the least specific one, so "pt-BR" falls back to "pt" before the default values.
+func WithLocale(locale string) Option {
+	return func(o *translatorOptions) {
+		o.locale = locale
+	}
+}
+
+// WithTranslationsDir sets the directory that locale bundles are read from. Each bundle is a JSON file named
+// after its locale, e.g. pt-BR.json, using the same format as github-mcp-server-config.json.
+// Defaults to the current directory.
+func WithTranslationsDir(dir string) Option {
+	return func(o *translatorOptions) {
+		o.translationsDir = dir
+	}
+}
+
+type localeBundle struct {
+	strings map[string]string
+}
+
+// Translator resolves the user facing text of the server. A value is taken from the first of the following
+// that defines its key: a GITHUB_MCP_<KEY> environment variable, the locale bundles from most to least specific,
+// the github-mcp-server-config.json file in the current directory, and finally the default value.
+type Translator struct {
+	mu                sync.Mutex
+	bundles           []localeBundle
+	config            *viper.Viper
+	configKeys        []string
+	translationKeyMap map[string]string
+}
+
+// NewTranslator creates a Translator. It returns an error if a locale is configured but none of the
+// bundles in its fallback chain exist, or if a bundle cannot be parsed.
+func NewTranslator(opts ...Option) (*Translator, error) {
+	o := &translatorOptions{translationsDir: "."}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	v := viper.New()
 
 	// Load from JSON file
@@ -32,28 +81,166 @@ func TranslationHelper() (TranslationHelperFunc, func()) {
 		}
 	}
 
-	// create a function that takes both a key, and a default value and returns either the default value or an override value
-	return func(key string, defaultValue string) string {
-			key = strings.ToUpper(key)
-			if value, exists := translationKeyMap[key]; exists {
-				return value
-			}
-			// check if the env var exists
-			if value, exists := os.LookupEnv("GITHUB_MCP_" + key); exists {
-				// TODO I could not get Viper to play ball reading the env var
-				translationKeyMap[key] = value
-				return value
-			}
+	tr := &Translator{
+		config:            v,
+		configKeys:        v.AllKeys(),
+		translationKeyMap: map[string]string{},
+	}
 
-			v.SetDefault(key, defaultValue)
-			translationKeyMap[key] = v.GetString(key)
-			return translationKeyMap[key]
-		}, func() {
-			// dump the translationKeyMap to a json file
-			if err := DumpTranslationKeyMap(translationKeyMap); err != nil {
-				log.Fatalf("Could not dump translation key map: %v", err)
+	if o.locale == "" {
+		return tr, nil
+	}
+
+	for _, locale := range LocaleFallbackChain(o.locale) {
+		bundle, err := loadLocaleBundle(o.translationsDir, locale)
+		if err != nil {
+			return nil, err
+		}
+		if bundle != nil {
+			tr.bundles = append(tr.bundles, *bundle)
+		}
+	}
+
+	if len(tr.bundles) == 0 {
+		return nil, fmt.Errorf("no translation bundle found for locale %s in %s", o.locale, o.translationsDir)
+	}
+
+	return tr, nil
+}
+
+// LocaleFallbackChain returns the locales to look up for the given locale, from most to least specific.
+// Underscores are treated as hyphens, so "pt_BR" results in ["pt-BR", "pt"].
+func LocaleFallbackChain(locale string) []string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return nil
+	}
+
+	parts := strings.Split(locale, "-")
+	chain := make([]string, 0, len(parts))
+	for i := len(parts); i > 0; i-- {
+		chain = append(chain, strings.Join(parts[:i], "-"))
+	}
+	return chain
+}
+
+// loadLocaleBundle reads the bundle for a single locale. A missing bundle is not an error, as a less specific
+// locale in the fallback chain may provide it, so nil is returned instead.
+func loadLocaleBundle(dir, locale string) (*localeBundle, error) {
+	path := filepath.Join(dir, locale+".json")
+	contents, err := os.ReadFile(path) //nolint:gosec // the translations directory is provided by the user on purpose.
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation bundle %s: %w", path, err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(contents, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse translation bundle %s: %w", path, err)
+	}
+
+	bundle := &localeBundle{strings: make(map[string]string, len(values))}
+	for key, value := range values {
+		bundle.strings[strings.ToUpper(key)] = value
+	}
+	return bundle, nil
+}
+
+// T returns the translated value for a key, or the default value if the key is not translated.
+// It satisfies TranslationHelperFunc.
+func (tr *Translator) T(key string, defaultValue string) string {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	key = strings.ToUpper(key)
+	if value, exists := tr.translationKeyMap[key]; exists {
+		return value
+	}
+	// check if the env var exists
+	if value, exists := os.LookupEnv("GITHUB_MCP_" + key); exists {
+		// TODO I could not get Viper to play ball reading the env var
+		tr.translationKeyMap[key] = value
+		return value
+	}
+
+	for _, bundle := range tr.bundles {
+		if value, exists := bundle.strings[key]; exists {
+			tr.translationKeyMap[key] = value
+			return value
+		}
+	}
+
+	tr.config.SetDefault(key, defaultValue)
+	tr.translationKeyMap[key] = tr.config.GetString(key)
+	return tr.translationKeyMap[key]
+}
+
+// Dump writes every translation resolved so far to github-mcp-server-config.json.
+func (tr *Translator) Dump() error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	return DumpTranslationKeyMap(tr.translationKeyMap)
+}
+
+// UnknownKeys returns the keys defined in the locale bundles or the config file that are not in knownKeys,
+// which usually means they are misspelt or belong to a tool that no longer exists.
+func (tr *Translator) UnknownKeys(knownKeys map[string]string) []string {
+	defined := map[string]bool{}
+	for _, bundle := range tr.bundles {
+		for key := range bundle.strings {
+			defined[key] = true
+		}
+	}
+	for _, key := range tr.configKeys {
+		defined[strings.ToUpper(key)] = true
+	}
+
+	var unknown []string
+	for key := range defined {
+		if _, ok := knownKeys[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// UntranslatedKeys returns the keys in knownKeys that none of the locale bundles define.
+func (tr *Translator) UntranslatedKeys(knownKeys map[string]string) []string {
+	var untranslated []string
+	for key := range knownKeys {
+		translated := false
+		for _, bundle := range tr.bundles {
+			if _, ok := bundle.strings[key]; ok {
+				translated = true
+				break
 			}
 		}
+		if !translated {
+			untranslated = append(untranslated, key)
+		}
+	}
+	sort.Strings(untranslated)
+	return untranslated
+}
+
+func TranslationHelper(opts ...Option) (TranslationHelperFunc, func()) {
+	tr, err := NewTranslator(opts...)
+	if err != nil {
+		log.Printf("Could not load translations: %v", err)
+		tr, _ = NewTranslator()
+	}
+
+	// create a function that takes both a key, and a default value and returns either the default value or an override value
+	return tr.T, func() {
+		// dump the translationKeyMap to a json file
+		if err := tr.Dump(); err != nil {
+			log.Fatalf("Could not dump translation key map: %v", err)
+		}
+	}
 }
 
 // DumpTranslationKeyMap writes the translation map to a json file called github-mcp-server-config.json
@@ -0,0 +1,80 @@
+package translations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBundle(t *testing.T, dir, locale, contents string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, locale+".json"), []byte(contents), 0600))
+}
+
+func TestLocaleFallbackChain(t *testing.T) {
+	assert.Equal(t, []string{"pt-BR", "pt"}, LocaleFallbackChain("pt-BR"))
+	assert.Equal(t, []string{"pt-BR", "pt"}, LocaleFallbackChain("pt_BR"))
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, LocaleFallbackChain("zh-Hant-TW"))
+	assert.Equal(t, []string{"de"}, LocaleFallbackChain("de"))
+	assert.Nil(t, LocaleFallbackChain(""))
+}
+
+func TestTranslatorPrecedence(t *testing.T) {
+	// Run in an empty directory so that no github-mcp-server-config.json is picked up
+	t.Chdir(t.TempDir())
+	dir := t.TempDir()
+
+	writeBundle(t, dir, "pt-BR", `{"TOOL_A_DESCRIPTION": "a pt-BR", "tool_b_description": "b pt-BR"}`)
+	writeBundle(t, dir, "pt", `{"TOOL_A_DESCRIPTION": "a pt", "TOOL_C_DESCRIPTION": "c pt"}`)
+	t.Setenv("GITHUB_MCP_TOOL_B_DESCRIPTION", "b env")
+
+	tr, err := NewTranslator(WithLocale("pt-BR"), WithTranslationsDir(dir))
+	require.NoError(t, err)
+
+	assert.Equal(t, "a pt-BR", tr.T("TOOL_A_DESCRIPTION", "a default"))
+	assert.Equal(t, "b env", tr.T("TOOL_B_DESCRIPTION", "b default"))
+	assert.Equal(t, "c pt", tr.T("TOOL_C_DESCRIPTION", "c default"))
+	assert.Equal(t, "d default", tr.T("TOOL_D_DESCRIPTION", "d default"))
+}
+
+func TestNewTranslatorMissingBundle(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := NewTranslator(WithLocale("fr"), WithTranslationsDir(t.TempDir()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no translation bundle found for locale fr")
+}
+
+func TestNewTranslatorMalformedBundle(t *testing.T) {
+	t.Chdir(t.TempDir())
+	dir := t.TempDir()
+	writeBundle(t, dir, "fr", `not-json`)
+
+	_, err := NewTranslator(WithLocale("fr"), WithTranslationsDir(dir))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse translation bundle")
+}
+
+func TestTranslatorKeyValidation(t *testing.T) {
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+	require.NoError(t, os.WriteFile(filepath.Join(workDir, "github-mcp-server-config.json"), []byte(`{"TOOL_TYPO_DESCRIPTION": "typo"}`), 0600))
+
+	dir := t.TempDir()
+	writeBundle(t, dir, "pt", `{"TOOL_A_DESCRIPTION": "a pt", "TOOL_REMOVED_DESCRIPTION": "removed"}`)
+
+	tr, err := NewTranslator(WithLocale("pt"), WithTranslationsDir(dir))
+	require.NoError(t, err)
+
+	knownKeys := map[string]string{
+		"TOOL_A_DESCRIPTION": "a default",
+		"TOOL_B_DESCRIPTION": "b default",
+		"TOOL_C_DESCRIPTION": "c default",
+	}
+
+	assert.Equal(t, []string{"TOOL_REMOVED_DESCRIPTION", "TOOL_TYPO_DESCRIPTION"}, tr.UnknownKeys(knownKeys))
+	assert.Equal(t, []string{"TOOL_B_DESCRIPTION", "TOOL_C_DESCRIPTION"}, tr.UntranslatedKeys(knownKeys))
+}